@@ -3,13 +3,17 @@ package main
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/atombender/go-jsonschema/pkg/generator"
+	"github.com/atombender/go-jsonschema/pkg/schemas"
 )
 
 const (
@@ -26,29 +30,56 @@ var (
 	schemaPackages            []string
 	schemaOutputs             []string
 	schemaRootTypes           []string
+	schemaPackageRules        []string
+	schemaOutputRules         []string
+	schemaMaps                []string
+	schemaPatches             []string
+	defaultDialect            string
 	capitalizations           []string
 	resolveExtensions         []string
 	yamlExtensions            []string
+	jsoncExtensions           []string
+	stdinFormat               string
 	tags                      []string
 	structNameFromTitle       bool
 	minSizedInts              bool
 	minimalNames              bool
 	disableReadOnlyValidation bool
 	disableCustomTypesForMaps bool
+	disableUnionTypes         bool
 	disableOmitEmpty          bool
 	disableOmitZero           bool
-
-	errFlagFormat = errors.New("flag must be in the format URI=PACKAGE")
+	httpTimeout               time.Duration
+	httpRetries               int
+	httpHeaders               []string
+	httpBearerTokens          []string
+	httpCache                 bool
+	httpCacheDir              string
+	offline                   bool
+	lockfile                  string
+	updateLock                bool
+	vendorDir                 string
+	rootDir                   string
+	allowedHosts              []string
+	maxRefDepth               int
+	maxDocumentSize           int64
+
+	errFlagFormat          = errors.New("flag must be in the format URI=PACKAGE")
+	errHTTPHeaderFormat    = errors.New("HTTP header must be in the format HOST=NAME:VALUE")
+	errSchemaPatchFormat   = errors.New("schema patch must be in the format URI_OR_FILE=PATCH_FILE")
+	errOfflineWithoutCache = errors.New("--offline requires an --http-cache-dir, as there is no user cache directory")
 
 	rootCmd = &cobra.Command{
 		Use:   "go-jsonschema FILE ...",
 		Short: "Generates Go code from JSON Schema files.",
+		// Arguments are schema files, rather than names of subcommands.
+		Args: cobra.ArbitraryArgs,
 		Run: func(_ *cobra.Command, args []string) {
 			if len(args) == 0 {
 				abort("No arguments specified. Run with --help for usage.")
 			}
 
-			if defaultPackage == "" && len(schemaPackages) == 0 {
+			if defaultPackage == "" && len(schemaPackages) == 0 && len(schemaPackageRules) == 0 {
 				abort("Package name not specified.")
 			}
 
@@ -67,6 +98,36 @@ var (
 				abortWithErr(err)
 			}
 
+			schemaMapMap, err := stringSliceToStringMap(schemaMaps)
+			if err != nil {
+				abortWithErr(err)
+			}
+
+			dialect, err := schemas.ParseDialect(defaultDialect)
+			if err != nil {
+				abortWithErr(err)
+			}
+
+			format, err := schemas.ParseFormat(stdinFormat)
+			if err != nil {
+				abortWithErr(err)
+			}
+
+			loaderOpts, lock, err := loaderOptions()
+			if err != nil {
+				abortWithErr(err)
+			}
+
+			transformers, err := patchTransformers()
+			if err != nil {
+				abortWithErr(err)
+			}
+
+			rules, err := outputRules()
+			if err != nil {
+				abortWithErr(err)
+			}
+
 			cfg := generator.Config{
 				Warner: func(message string) {
 					logf("Warning: %s", message)
@@ -78,6 +139,8 @@ var (
 				SchemaMappings:            []generator.SchemaMapping{},
 				ResolveExtensions:         resolveExtensions,
 				YAMLExtensions:            yamlExtensions,
+				JSONCExtensions:           jsoncExtensions,
+				StdinFormat:               format,
 				StructNameFromTitle:       structNameFromTitle,
 				Tags:                      tags,
 				OnlyModels:                onlyModels,
@@ -85,8 +148,14 @@ var (
 				MinimalNames:              minimalNames,
 				DisableReadOnlyValidation: disableReadOnlyValidation,
 				DisableCustomTypesForMaps: disableCustomTypesForMaps,
+				DisableUnionTypes:         disableUnionTypes,
 				DisableOmitEmpty:          disableOmitEmpty,
 				DisableOmitZero:           disableOmitZero,
+				URIMappings:               uriMappings(schemaMapMap),
+				DefaultDialect:            dialect,
+				LoaderOptions:             loaderOpts,
+				OutputRules:               rules,
+				Transformers:              transformers,
 			}
 
 			for _, id := range allKeys(schemaPackageMap, schemaOutputMap, schemaRootTypeMap) {
@@ -128,6 +197,8 @@ var (
 				abortWithErr(err)
 			}
 
+			writeLockfile(lock)
+
 			for fileName, source := range sources {
 				if fileName != "-" {
 					verboseLogf("Writing %s", fileName)
@@ -160,6 +231,182 @@ var (
 			os.Exit(0)
 		},
 	}
+
+	lintCmd = &cobra.Command{
+		Use:   "lint FILE ...",
+		Short: "Checks JSON Schema files against their meta-schema and for common mistakes.",
+		Run: func(_ *cobra.Command, args []string) {
+			if len(args) == 0 {
+				abort("No arguments specified. Run with --help for usage.")
+			}
+
+			dialect, err := schemas.ParseDialect(defaultDialect)
+			if err != nil {
+				abortWithErr(err)
+			}
+
+			stdin, err := schemas.ParseFormat(stdinFormat)
+			if err != nil {
+				abortWithErr(err)
+			}
+
+			failed := false
+
+			for _, fileName := range args {
+				verboseLogf("Linting %s", fileName)
+
+				var data []byte
+				if fileName == "-" {
+					data, err = io.ReadAll(os.Stdin)
+				} else {
+					data, err = os.ReadFile(fileName)
+				}
+
+				if err != nil {
+					abortWithErr(err)
+				}
+
+				format := inputFormat(fileName, data, stdin)
+
+				issues, err := schemas.Lint(data, schemas.LintOptions{
+					DefaultDialect: dialect,
+					YAML:           format == schemas.FormatYAML,
+					JSONC:          format == schemas.FormatJSONC,
+				})
+				if err != nil {
+					abortWithErr(fmt.Errorf("%s: %w", fileName, err))
+				}
+
+				for _, issue := range issues {
+					fmt.Fprintf(os.Stdout, "%s:%s\n", fileName, issue)
+
+					failed = failed || issue.Severity == schemas.LintError
+				}
+			}
+
+			if failed {
+				os.Exit(1)
+			}
+
+			os.Exit(0)
+		},
+	}
+
+	bundleCmd = &cobra.Command{
+		Use:   "bundle FILE",
+		Short: "Writes a JSON Schema file and every schema it references as a single document.",
+		Run: func(_ *cobra.Command, args []string) {
+			if len(args) != 1 {
+				abort("Exactly one file must be specified. Run with --help for usage.")
+			}
+
+			schemaMapMap, err := stringSliceToStringMap(schemaMaps)
+			if err != nil {
+				abortWithErr(err)
+			}
+
+			dialect, err := schemas.ParseDialect(defaultDialect)
+			if err != nil {
+				abortWithErr(err)
+			}
+
+			loaderOpts, lock, err := loaderOptions()
+			if err != nil {
+				abortWithErr(err)
+			}
+
+			verboseLogf("Bundling %s", args[0])
+
+			bundle, err := schemas.Bundle(args[0], schemas.BundleOptions{
+				Loader: schemas.NewDefaultCacheLoader(
+					resolveExtensions,
+					yamlExtensions,
+					append([]schemas.LoaderOption{
+						schemas.WithURIMappings(uriMappings(schemaMapMap)...),
+						schemas.WithDefaultDialect(dialect),
+						schemas.WithJSONCExtensions(jsoncExtensions...),
+					}, loaderOpts...)...,
+				),
+				ResolveExtensions: resolveExtensions,
+				// Written in the format of the output file, or of the input file on standard output.
+				YAML: isYAMLFile(defaultOutput) || (defaultOutput == "-" && isYAMLFile(args[0])),
+			})
+			if err != nil {
+				abortWithErr(err)
+			}
+
+			writeLockfile(lock)
+
+			if defaultOutput == "-" {
+				if _, err := os.Stdout.Write(bundle); err != nil {
+					abortWithErr(err)
+				}
+
+				os.Exit(0)
+			}
+
+			verboseLogf("Writing %s", defaultOutput)
+
+			if err := os.MkdirAll(filepath.Dir(defaultOutput), perm755); err != nil {
+				abortWithErr(err)
+			}
+
+			if err := os.WriteFile(defaultOutput, bundle, perm644); err != nil {
+				abortWithErr(err)
+			}
+
+			os.Exit(0)
+		},
+	}
+
+	vendorCmd = &cobra.Command{
+		Use:   "vendor FILE ...",
+		Short: "Saves every schema referenced over HTTP from JSON Schema files to a local directory.",
+		Run: func(_ *cobra.Command, args []string) {
+			if len(args) == 0 {
+				abort("No arguments specified. Run with --help for usage.")
+			}
+
+			schemaMapMap, err := stringSliceToStringMap(schemaMaps)
+			if err != nil {
+				abortWithErr(err)
+			}
+
+			dialect, err := schemas.ParseDialect(defaultDialect)
+			if err != nil {
+				abortWithErr(err)
+			}
+
+			loaderOpts, lock, err := loaderOptions()
+			if err != nil {
+				abortWithErr(err)
+			}
+
+			verboseLogf("Vendoring schemas to %s", vendorDir)
+
+			mappings, err := schemas.Vendor(args, schemas.VendorOptions{
+				Dir:               vendorDir,
+				ResolveExtensions: resolveExtensions,
+				YAMLExtensions:    yamlExtensions,
+				JSONCExtensions:   jsoncExtensions,
+				LoaderOptions: append([]schemas.LoaderOption{
+					schemas.WithURIMappings(uriMappings(schemaMapMap)...),
+					schemas.WithDefaultDialect(dialect),
+				}, loaderOpts...),
+			})
+			if err != nil {
+				abortWithErr(err)
+			}
+
+			writeLockfile(lock)
+
+			for _, m := range mappings {
+				fmt.Fprintf(os.Stdout, "--schema-map=%s=%s\n", m.Prefix, filepath.ToSlash(m.Dir))
+			}
+
+			os.Exit(0)
+		},
+	}
 )
 
 func main() {
@@ -183,6 +430,26 @@ must be in the format URI=FILENAME.`)
 	rootCmd.PersistentFlags().StringSliceVar(&schemaRootTypes, "schema-root-type", nil,
 		`Override name to use for the root type of a specific schema ID;
 must be in the format URI=TYPE. By default, it is derived from the file name.`)
+	rootCmd.PersistentFlags().StringArrayVar(&schemaPackageRules, "schema-package-rule", nil,
+		`Name of package to declare Go files under for the schemas matching a pattern, unless mapped with
+--schema-package; must be in the format PATTERN=PACKAGE. The pattern is a glob if it has wildcards, ** matching
+any number of path segments, matched against the schema ID or else the file or URL it is loaded from; a prefix
+of schema IDs if it is a URI; or else a file or directory of schemas. In the package, {{dir}} stands for the
+directory of the schema relative to the pattern and {{basename}} for its file name without extension.
+The first matching rule applies.`)
+	rootCmd.PersistentFlags().StringArrayVar(&schemaOutputRules, "schema-output-rule", nil,
+		`File to write (- for standard output) the schemas matching a pattern to, unless mapped with
+--schema-output; must be in the format PATTERN=FILENAME, as for --schema-package-rule.`)
+	rootCmd.PersistentFlags().StringSliceVar(&schemaMaps, "schema-map", nil,
+		`Load schemas whose URI starts with a prefix from a local directory instead of the network;
+must be in the format URI_PREFIX=LOCAL_DIR.`)
+	rootCmd.PersistentFlags().StringArrayVar(&schemaPatches, "schema-patch", nil,
+		`Apply a JSON Patch (RFC 6902) or Overlay file to the schema with a specific ID, or loaded from a
+specific file or URL, before generating code; must be in the format URI_OR_FILE=PATCH_FILE. Patches
+apply in order, to the schema with the keywords of the 2020-12 dialect.`)
+	rootCmd.PersistentFlags().StringVar(&defaultDialect, "default-dialect", "",
+		`JSON Schema dialect of schemas that do not declare one with $schema: draft-04, draft-06,
+draft-07, 2019-09 or 2020-12. By default, keywords of all dialects are accepted.`)
 	rootCmd.PersistentFlags().StringSliceVar(&capitalizations, "capitalization", nil,
 		`Specify a preferred Go capitalization for a string. For example, by default a field
 named 'id' becomes 'Id'. With --capitalization ID, it will be generated as 'ID'.`)
@@ -191,6 +458,12 @@ named 'id' becomes 'Id'. With --capitalization ID, it will be generated as 'ID'.
 also look for foo.json if --resolve-extension json is provided.`)
 	rootCmd.PersistentFlags().StringSliceVar(&yamlExtensions, "yaml-extension", []string{".yml", ".yaml"},
 		`Add a file extension that should be recognized as YAML. Default are .yml, .yaml.`)
+	rootCmd.PersistentFlags().StringSliceVar(&jsoncExtensions, "jsonc-extension", []string{".jsonc", ".json5"},
+		`Add a file extension that should be recognized as JSONC or JSON5, which allow comments
+and trailing commas. Default are .jsonc, .json5.`)
+	rootCmd.PersistentFlags().StringVar(&stdinFormat, "stdin-format", "auto",
+		`Format of a schema read from standard input: json, yaml, jsonc, json5, or auto to tell it
+from its content.`)
 	rootCmd.PersistentFlags().BoolVarP(&structNameFromTitle, "struct-name-from-title", "t", false,
 		"Use the schema title as the generated struct name")
 	rootCmd.PersistentFlags().StringSliceVar(&tags, "tags", []string{"json", "yaml", "mapstructure"},
@@ -203,10 +476,50 @@ also look for foo.json if --resolve-extension json is provided.`)
 		"Uses the shortest possible names")
 	rootCmd.PersistentFlags().BoolVar(&disableCustomTypesForMaps, "disable-custom-types-for-maps", false,
 		"Do not generate custom types when generating maps")
+	rootCmd.PersistentFlags().BoolVar(&disableUnionTypes, "disable-union-types", false,
+		"Generate oneOf as interface{} and discriminated anyOf as a merged struct instead of union types")
 	rootCmd.PersistentFlags().BoolVar(&disableOmitEmpty, "disable-omitempty", false,
 		"disable the addition of omitempty tag values")
 	rootCmd.PersistentFlags().BoolVar(&disableOmitZero, "disable-omitzero", false,
 		"disable the addition of omitzero tag values")
+	rootCmd.PersistentFlags().DurationVar(&httpTimeout, "http-timeout", 30*time.Second,
+		"Time allowed for each attempt at retrieving a schema over HTTP; 0 for no limit")
+	rootCmd.PersistentFlags().IntVar(&httpRetries, "http-retries", 2,
+		"Number of times to retry retrieving a schema over HTTP after a network error or a 429 or 5xx status")
+	rootCmd.PersistentFlags().StringArrayVar(&httpHeaders, "http-header", nil,
+		`Header to send when retrieving schemas from a host over HTTP;
+must be in the format HOST=NAME:VALUE.`)
+	rootCmd.PersistentFlags().StringSliceVar(&httpBearerTokens, "http-bearer-token", nil,
+		`Bearer token to authenticate with when retrieving schemas from a host over HTTP;
+must be in the format HOST=TOKEN.`)
+	rootCmd.PersistentFlags().BoolVar(&httpCache, "http-cache", false,
+		`Cache schemas retrieved over HTTP in a go-jsonschema directory of the user's cache directory,
+revalidated on every run`)
+	rootCmd.PersistentFlags().StringVar(&httpCacheDir, "http-cache-dir", "",
+		"Directory to cache schemas retrieved over HTTP in, instead of the one used by --http-cache")
+	rootCmd.PersistentFlags().BoolVar(&offline, "offline", false,
+		"Load schemas referenced over HTTP from the cache only, without network access; implies --http-cache")
+	rootCmd.PersistentFlags().StringVar(&rootDir, "root-dir", "",
+		"Directory that schema files, including referenced ones, must be in after resolving symlinks")
+	rootCmd.PersistentFlags().StringSliceVar(&allowedHosts, "allow-host", nil,
+		`Host that schemas may be retrieved from over HTTP; *.example.com allows example.com and any of its subdomains.
+By default, any host is allowed.`)
+	rootCmd.PersistentFlags().IntVar(&maxRefDepth, "max-ref-depth", 0,
+		"Maximum number of documents a schema may be referenced through; 0 for no limit")
+	rootCmd.PersistentFlags().Int64Var(&maxDocumentSize, "max-document-size", 0,
+		"Maximum size in bytes of a schema document; 0 for no limit")
+	rootCmd.PersistentFlags().StringVar(&lockfile, "lockfile", schemas.DefaultLockfileName,
+		`File recording the digest of every schema loaded over HTTP, to fail if one changes;
+empty to disable it.`)
+	rootCmd.PersistentFlags().BoolVar(&updateLock, "update-lock", false,
+		"Record the current digest of schemas loaded over HTTP that no longer match the lockfile")
+
+	vendorCmd.Flags().StringVar(&vendorDir, "dir", "third_party/schemas",
+		"Directory to save the schemas under")
+
+	rootCmd.AddCommand(lintCmd)
+	rootCmd.AddCommand(bundleCmd)
+	rootCmd.AddCommand(vendorCmd)
 
 	abortWithErr(rootCmd.Execute())
 }
@@ -237,6 +550,227 @@ func stringSliceToStringMap(s []string) (map[string]string, error) {
 	return result, nil
 }
 
+func uriMappings(m map[string]string) []schemas.URIMapping {
+	result := make([]schemas.URIMapping, 0, len(m))
+
+	for prefix, dir := range m {
+		result = append(result, schemas.URIMapping{Prefix: prefix, Dir: dir})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Prefix < result[j].Prefix
+	})
+
+	return result
+}
+
+// loaderOptions returns the options of the loader of schemas, and the lockfile it checks remote
+// schemas against, if any.
+func loaderOptions() ([]schemas.LoaderOption, *schemas.Lockfile, error) {
+	cacheDir := httpCacheDir
+	if cacheDir == "" && (httpCache || offline) {
+		cacheDir = defaultHTTPCacheDir()
+	}
+
+	if offline && cacheDir == "" {
+		return nil, nil, errOfflineWithoutCache
+	}
+
+	opts := []schemas.LoaderOption{
+		schemas.WithHTTPTimeout(httpTimeout),
+		schemas.WithHTTPRetries(httpRetries),
+		schemas.WithHTTPCache(cacheDir),
+		schemas.WithOffline(offline),
+		schemas.WithPolicy(schemas.Policy{
+			RootDir:         rootDir,
+			AllowedHosts:    allowedHosts,
+			MaxRefDepth:     maxRefDepth,
+			MaxDocumentSize: maxDocumentSize,
+		}),
+	}
+
+	for _, header := range httpHeaders {
+		host, field, ok := strings.Cut(header, "=")
+		name, value, ok2 := strings.Cut(field, ":")
+
+		if !ok || !ok2 || host == "" || strings.TrimSpace(name) == "" {
+			return nil, nil, fmt.Errorf("%w: %q", errHTTPHeaderFormat, header)
+		}
+
+		opts = append(opts, schemas.WithHTTPHeader(host, strings.TrimSpace(name), strings.TrimSpace(value)))
+	}
+
+	tokens, err := stringSliceToStringMap(httpBearerTokens)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for host, token := range tokens {
+		opts = append(opts, schemas.WithBearerToken(host, token))
+	}
+
+	if lockfile == "" {
+		return opts, nil, nil
+	}
+
+	lock, err := schemas.ReadLockfile(lockfile)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return append(opts, schemas.WithLockfile(lock, updateLock)), lock, nil
+}
+
+// outputRules returns the rules given with --schema-package-rule and --schema-output-rule, in the order
+// their patterns are first given in.
+func outputRules() ([]generator.OutputRule, error) {
+	var rules []generator.OutputRule
+
+	// Relative globs match the files given on the command line as they are named there.
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+
+	rule := func(pattern string) *generator.OutputRule {
+		for i, r := range rules {
+			if r.IDPrefix+r.Glob+r.Path == pattern {
+				return &rules[i]
+			}
+		}
+
+		r := generator.OutputRule{BaseDir: wd}
+
+		switch {
+		case strings.ContainsAny(pattern, "*?["):
+			r.Glob = pattern
+
+		case strings.Contains(pattern, "://") || strings.HasPrefix(pattern, "urn:"):
+			r.IDPrefix = pattern
+
+		default:
+			r.Path = pattern
+		}
+
+		rules = append(rules, r)
+
+		return &rules[len(rules)-1]
+	}
+
+	for _, p := range schemaPackageRules {
+		pattern, packageName, ok := strings.Cut(p, "=")
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("%w: %q", errFlagFormat, p)
+		}
+
+		rule(pattern).PackageName = packageName
+	}
+
+	for _, p := range schemaOutputRules {
+		pattern, outputName, ok := strings.Cut(p, "=")
+		if !ok || pattern == "" {
+			return nil, fmt.Errorf("%w: %q", errFlagFormat, p)
+		}
+
+		rule(pattern).OutputName = outputName
+	}
+
+	return rules, nil
+}
+
+// patchTransformers returns the transformers applying the patches given with --schema-patch, in order.
+func patchTransformers() ([]schemas.Transformer, error) {
+	transformers := make([]schemas.Transformer, 0, len(schemaPatches))
+
+	for _, p := range schemaPatches {
+		target, fileName, ok := strings.Cut(p, "=")
+		if !ok || target == "" || fileName == "" {
+			return nil, fmt.Errorf("%w: %q", errSchemaPatchFormat, p)
+		}
+
+		data, err := os.ReadFile(fileName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read patch: %w", err)
+		}
+
+		patch, err := schemas.ParsePatch(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fileName, err)
+		}
+
+		transformers = append(transformers, schemas.PatchTransformer(target, patch))
+	}
+
+	return transformers, nil
+}
+
+func writeLockfile(lock *schemas.Lockfile) {
+	if lock == nil || !lock.Changed() {
+		return
+	}
+
+	verboseLogf("Writing %s", lockfile)
+
+	if err := lock.Write(lockfile); err != nil {
+		abortWithErr(err)
+	}
+}
+
+// defaultHTTPCacheDir returns the directory schemas retrieved over HTTP are cached in with --http-cache,
+// or "" if the user has no cache directory.
+func defaultHTTPCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "go-jsonschema", "http")
+}
+
+func isYAMLFile(fileName string) bool {
+	return hasExtension(fileName, yamlExtensions)
+}
+
+func isJSONCFile(fileName string) bool {
+	return hasExtension(fileName, jsoncExtensions)
+}
+
+// inputFormat returns the format of an input file: the given format for standard input, or else
+// the format of its extension, if any, or of its content.
+func inputFormat(fileName string, data []byte, stdin schemas.Format) schemas.Format {
+	format := schemas.FormatUnknown
+
+	switch {
+	case fileName == "-":
+		format = stdin
+
+	case isYAMLFile(fileName):
+		format = schemas.FormatYAML
+
+	case isJSONCFile(fileName):
+		format = schemas.FormatJSONC
+
+	case filepath.Ext(fileName) == ".json":
+		format = schemas.FormatJSON
+	}
+
+	if format == schemas.FormatUnknown {
+		format = schemas.SniffFormat(data)
+	}
+
+	return format
+}
+
+func hasExtension(fileName string, extensions []string) bool {
+	for _, ext := range extensions {
+		if filepath.Ext(fileName) == "."+strings.TrimPrefix(ext, ".") {
+			return true
+		}
+	}
+
+	return false
+}
+
 func allKeys(in ...map[string]string) []string {
 	type dummy struct{}
 
@@ -421,6 +421,28 @@ func (EmptyInterfaceType) Generate(out *Emitter) error {
 	return nil
 }
 
+// InterfaceType is an "interface { <methods> }" with no-argument, no-result methods.
+// It is used to declare sealed interfaces implemented only by generated types.
+type InterfaceType struct {
+	Methods []string
+}
+
+func (InterfaceType) IsNillable() bool { return true }
+
+func (t InterfaceType) Generate(out *Emitter) error {
+	out.Printlnf("interface {")
+	out.Indent(1)
+
+	for _, m := range t.Methods {
+		out.Printlnf("%s()", m)
+	}
+
+	out.Indent(-1)
+	out.Printf("}")
+
+	return nil
+}
+
 type NullType struct{}
 
 func (NullType) IsNillable() bool { return true }
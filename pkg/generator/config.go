@@ -12,6 +12,10 @@ type Config struct {
 	ResolveExtensions []string
 	// YAMLExtensions configures the file extensions that are recognized as YAML files.
 	YAMLExtensions []string
+	// JSONCExtensions configures the file extensions that are recognized as JSONC or JSON5 files.
+	JSONCExtensions []string
+	// StdinFormat is the format of a schema read from standard input, told from its content if unknown.
+	StdinFormat schemas.Format
 	// DefaultPackageName configures the package to declare files under.
 	DefaultPackageName string
 	// DefaultOutputName configures the file to write.
@@ -30,6 +34,17 @@ type Config struct {
 	MinimalNames bool
 	// Loader provides a schema loader for the generator.
 	Loader schemas.Loader
+	// URIMappings rewrites remote schema URIs to files in local directories before they are loaded.
+	URIMappings []schemas.URIMapping
+	// DefaultDialect is the dialect of schemas that do not declare one with $schema.
+	DefaultDialect schemas.Dialect
+	// LoaderOptions configure the default loader, such as the timeouts, headers and cache of its HTTP requests.
+	LoaderOptions []schemas.LoaderOption
+	// OutputRules map the schemas matching patterns to packages and output files, the first matching rule
+	// applying. They apply to the schemas that no SchemaMapping maps by $id.
+	OutputRules []OutputRule
+	// Transformers change every schema document in turn once it is loaded, before any code is generated from it.
+	Transformers []schemas.Transformer
 	// When DisableOmitempty is set to true,
 	// an "omitempty" tag will never be present in generated struct fields.
 	// When DisableOmitempty is set to false,
@@ -45,6 +60,9 @@ type Config struct {
 	// DisableCustomTypesForMaps configures the generator to avoid creating a custom type for maps,
 	// and to use the map type directly.
 	DisableCustomTypesForMaps bool
+	// DisableUnionTypes configures the generator to represent a oneOf as interface{}, and an anyOf with a
+	// discriminator as the merge of its branches, rather than as union types.
+	DisableUnionTypes bool
 	// AliasSingleAllOfAnyOfRefs will convert types with a single nested anyOf or allOf ref type into a type alias.
 	AliasSingleAllOfAnyOfRefs bool
 }
@@ -55,3 +73,24 @@ type SchemaMapping struct {
 	RootType    string
 	OutputName  string
 }
+
+// OutputRule maps the schemas matching a pattern to a package and an output file. A rule matches
+// with one of IDPrefix, Glob and Path. PackageName and OutputName are templates in which {{dir}}
+// stands for the directory of the schema relative to the pattern, and {{basename}} for its file name
+// without extension, as in "internal/gen/{{dir}}/{{basename}}.go".
+type OutputRule struct {
+	// IDPrefix matches the schemas whose $id starts with it.
+	IDPrefix string
+	// Glob matches the schemas whose $id, or else the file or URL they are loaded from, matches it.
+	// In addition to the syntax of path.Match, ** matches any number of path segments.
+	Glob string
+	// Path matches the schemas loaded from the file, or from files in the directory.
+	Path string
+	// BaseDir is the directory that the files matched by a relative Glob are relative to. Without it,
+	// a relative Glob only matches $ids and URLs.
+	BaseDir string
+	// PackageName is the package to declare the files of the schemas under, or the default package if empty.
+	PackageName string
+	// OutputName is the file to write the schemas to, or the default output if empty.
+	OutputName string
+}
@@ -16,4 +16,8 @@ type formatter interface {
 		valueConstant *codegen.Var,
 		wrapInStruct bool,
 	) func(*codegen.Emitter) error
+	unionMarshal(declType *codegen.TypeDecl, union *unionType) func(*codegen.Emitter) error
+	unionUnmarshal(declType *codegen.TypeDecl, union *unionType) func(*codegen.Emitter) error
+	tupleMarshal(declType *codegen.TypeDecl, tuple *tupleType) func(*codegen.Emitter) error
+	tupleUnmarshal(declType *codegen.TypeDecl, tuple *tupleType) func(*codegen.Emitter) error
 }
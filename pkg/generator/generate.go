@@ -30,6 +30,7 @@ var (
 	errDefinitionDoesNotExistInSchema = errors.New("definition does not exist in schema")
 	errCannotGenerateReferencedType   = errors.New("cannot generate referenced type")
 	errCannotGenerateSources          = errors.New("cannot generate sources")
+	errInvalidOutputRule              = errors.New("invalid output rule")
 )
 
 type Generator struct {
@@ -40,6 +41,8 @@ type Generator struct {
 	warner       func(string)
 	formatters   []formatter
 	loader       schemas.Loader
+	transformer  *schemas.TransformingLoader
+	registry     *schemas.Registry
 	minimalNames bool
 }
 
@@ -51,6 +54,12 @@ type qualifiedDefinition struct {
 }
 
 func New(config Config) (*Generator, error) {
+	for _, r := range config.OutputRules {
+		if err := r.validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	formatters := []formatter{
 		&jsonFormatter{},
 	}
@@ -58,6 +67,20 @@ func New(config Config) (*Generator, error) {
 		formatters = append(formatters, &yamlFormatter{})
 	}
 
+	loader := config.Loader
+	if loader == nil {
+		opts := append([]schemas.LoaderOption{
+			schemas.WithURIMappings(config.URIMappings...),
+			schemas.WithDefaultDialect(config.DefaultDialect),
+			schemas.WithJSONCExtensions(config.JSONCExtensions...),
+		}, config.LoaderOptions...)
+
+		loader = schemas.NewDefaultCacheLoader(config.ResolveExtensions, config.YAMLExtensions, opts...)
+	}
+
+	registry := schemas.NewRegistry()
+	transformer := schemas.NewTransformingLoader(loader, config.ResolveExtensions, config.Transformers...)
+
 	generator := &Generator{
 		caser:        text.NewCaser(config.Capitalizations, config.ResolveExtensions),
 		config:       config,
@@ -65,14 +88,12 @@ func New(config Config) (*Generator, error) {
 		outputs:      map[string]*output{},
 		warner:       config.Warner,
 		formatters:   formatters,
-		loader:       config.Loader,
+		loader:       schemas.NewRegistryLoader(transformer, registry, config.ResolveExtensions),
+		transformer:  transformer,
+		registry:     registry,
 		minimalNames: config.MinimalNames,
 	}
 
-	if config.Loader == nil {
-		generator.loader = schemas.NewDefaultCacheLoader(config.ResolveExtensions, config.YAMLExtensions)
-	}
-
 	return generator, nil
 }
 
@@ -80,12 +101,16 @@ func (g *Generator) Sources() (map[string][]byte, error) {
 	var maxLineLength int32 = 80
 
 	sources := make(map[string]*strings.Builder, len(g.outputs))
+	// An output is recorded under the key of every schema written to it.
+	generated := make(map[*output]bool, len(g.outputs))
 
 	for _, output := range g.outputs {
-		if output.file.FileName == "" {
+		if output.file.FileName == "" || generated[output] {
 			continue
 		}
 
+		generated[output] = true
+
 		emitter := codegen.NewEmitter(maxLineLength)
 
 		if err := output.file.Generate(emitter); err != nil {
@@ -126,7 +151,7 @@ func (g *Generator) DoFile(fileName string) error {
 	var schema *schemas.Schema
 
 	if fileName == "-" {
-		schema, err = schemas.FromJSONReader(os.Stdin)
+		schema, err = schemas.FromReaderWithDialect(os.Stdin, g.config.StdinFormat, g.config.DefaultDialect)
 		if err != nil {
 			return fmt.Errorf("error parsing from standard input: %w", err)
 		}
@@ -141,7 +166,13 @@ func (g *Generator) DoFile(fileName string) error {
 }
 
 func (g *Generator) AddFile(fileName string, schema *schemas.Schema) error {
-	o, err := g.findOutputFileForSchemaID(schema.ID)
+	if err := g.transformer.Transform(fileName, schema); err != nil {
+		return err
+	}
+
+	g.registry.Register(schema, fileName)
+
+	o, err := g.findOutputFileForSchemaID(schema.ID, fileName)
 	if err != nil {
 		return err
 	}
@@ -171,37 +202,62 @@ func (g *Generator) getRootTypeName(schema *schemas.Schema, fileName string) str
 	return g.caser.IdentifierFromFileName(fileName)
 }
 
-func (g *Generator) findOutputFileForSchemaID(id string) (*output, error) {
-	if o, ok := g.outputs[id]; ok {
+// findOutputFileForSchemaID returns the output of the schema with the given $id, loaded from the given
+// file or URL: the output its $id is mapped to, or else the output of the first rule matching it, or
+// else the default output.
+func (g *Generator) findOutputFileForSchemaID(id, fileName string) (*output, error) {
+	key := outputKey(id, fileName)
+
+	if o, ok := g.outputs[key]; ok {
 		return o, nil
 	}
 
 	for _, m := range g.config.SchemaMappings {
 		if m.SchemaID == id {
-			return g.beginOutput(id, m.OutputName, m.PackageName)
+			return g.beginOutput(key, m.OutputName, m.PackageName)
+		}
+	}
+
+	for _, r := range g.config.OutputRules {
+		if dir, base, ok := r.match(id, fileName); ok {
+			return g.beginOutput(key,
+				r.outputName(dir, base, g.config.DefaultOutputName),
+				r.packageName(dir, base, g.config.DefaultPackageName))
 		}
 	}
 
-	return g.beginOutput(id, g.config.DefaultOutputName, g.config.DefaultPackageName)
+	return g.beginOutput(key, g.config.DefaultOutputName, g.config.DefaultPackageName)
+}
+
+// outputKey returns the key of the output of a schema, which is its $id, or else the file or URL it
+// is loaded from.
+func outputKey(id, fileName string) string {
+	if id != "" {
+		return id
+	}
+
+	return fileName
 }
 
 func (g *Generator) beginOutput(
-	id string,
+	key string,
 	outputName,
 	packageName string,
 ) (*output, error) {
 	if packageName == "" {
-		return nil, fmt.Errorf("%w: %q", errMapURIToPackageName, id)
+		return nil, fmt.Errorf("%w: %q", errMapURIToPackageName, key)
 	}
 
 	for _, o := range g.outputs {
 		if o.file.FileName == outputName && o.file.Package.QualifiedName != packageName {
 			return nil, fmt.Errorf(
 				"%w (%s) mapped to two different Go packages (%q and %q) for schema %q",
-				errConflictSameFile, o.file.FileName, o.file.Package.QualifiedName, packageName, id)
+				errConflictSameFile, o.file.FileName, o.file.Package.QualifiedName, packageName, key)
 		}
 
 		if o.file.FileName == outputName && o.file.Package.QualifiedName == packageName {
+			g.outputs[key] = o
+
 			return o, nil
 		}
 	}
@@ -220,9 +276,11 @@ func (g *Generator) beginOutput(
 		declsBySchema:           map[*schemas.Type]*codegen.TypeDecl{},
 		declsByName:             map[string]*codegen.TypeDecl{},
 		unmarshallersByTypeDecl: map[*codegen.TypeDecl]bool{},
+		unionsByTypeDecl:        map[*codegen.TypeDecl]*unionType{},
+		tuplesByTypeDecl:        map[*codegen.TypeDecl]*tupleType{},
 		processedSchemas:        map[string]bool{},
 	}
-	g.outputs[id] = output
+	g.outputs[key] = output
 
 	return output, nil
 }
@@ -1,8 +1,10 @@
 package generator
 
 import (
+	"encoding/json"
 	"fmt"
 	"math"
+	"strconv"
 	"strings"
 
 	"github.com/atombender/go-jsonschema/pkg/codegen"
@@ -167,6 +169,138 @@ func (jf *jsonFormatter) enumUnmarshal(
 	}
 }
 
+func (jf *jsonFormatter) unionMarshal(
+	declType *codegen.TypeDecl,
+	union *unionType,
+) func(*codegen.Emitter) error {
+	return func(out *codegen.Emitter) error {
+		out.Commentf("Marshal%s implements %s.Marshaler.", strings.ToUpper(formatJSON), formatJSON)
+		out.Printlnf("func (j %s) Marshal%s() ([]byte, error) {", declType.Name, strings.ToUpper(formatJSON))
+		out.Indent(1)
+
+		if union.discriminator != "" {
+			if err := generateUnionTagSwitch(out, union, fmt.Sprintf("return %s.Marshal(j.Value)", formatJSON)); err != nil {
+				return err
+			}
+
+			name, err := json.Marshal(union.discriminator)
+			if err != nil {
+				return fmt.Errorf("cannot marshal discriminator name: %w", err)
+			}
+
+			// The fields of the variant are copied as they are, after the discriminator, so that
+			// neither their order nor the precision of their values changes.
+			out.Printlnf("data, err := %s.Marshal(j.Value)", formatJSON)
+			out.Printlnf("if err != nil { return nil, err }")
+			out.Printlnf("tagValue, err := %s.Marshal(tag)", formatJSON)
+			out.Printlnf("if err != nil { return nil, err }")
+			out.Printlnf("buf := append([]byte(%s), tagValue...)", goStringLiteral("{"+string(name)+":"))
+			out.Printlnf("dec := %s.NewDecoder(bytes.NewReader(data))", formatJSON)
+			out.Printlnf("if _, err := dec.Token(); err != nil { return nil, err }")
+			out.Printlnf("for dec.More() {")
+			out.Indent(1)
+			out.Printlnf("key, err := dec.Token()")
+			out.Printlnf("if err != nil { return nil, err }")
+			out.Printlnf("var field %s.RawMessage", formatJSON)
+			out.Printlnf("if err := dec.Decode(&field); err != nil { return nil, err }")
+			out.Printlnf("if key == %q { continue }", union.discriminator)
+			out.Printlnf("keyName, err := %s.Marshal(key)", formatJSON)
+			out.Printlnf("if err != nil { return nil, err }")
+			out.Printlnf("buf = append(append(append(append(buf, ','), keyName...), ':'), field...)")
+			out.Indent(-1)
+			out.Printlnf("}")
+			out.Printlnf("return append(buf, '}'), nil")
+		} else {
+			out.Printlnf("return %s.Marshal(j.Value)", formatJSON)
+		}
+
+		out.Indent(-1)
+		out.Printlnf("}")
+
+		return nil
+	}
+}
+
+// goStringLiteral returns a raw string literal holding s, or an interpreted one if s cannot be held raw.
+func goStringLiteral(s string) string {
+	if strconv.CanBackquote(s) {
+		return "`" + s + "`"
+	}
+
+	return strconv.Quote(s)
+}
+
+func (jf *jsonFormatter) unionUnmarshal(
+	declType *codegen.TypeDecl,
+	union *unionType,
+) func(*codegen.Emitter) error {
+	return func(out *codegen.Emitter) error {
+		out.Commentf("Unmarshal%s implements %s.Unmarshaler.", strings.ToUpper(formatJSON), formatJSON)
+		out.Printlnf("func (j *%s) Unmarshal%s(value []byte) error {", declType.Name, strings.ToUpper(formatJSON))
+		out.Indent(1)
+		out.Printlnf("var %s interface{}", varNameRawMap)
+		out.Printlnf("if err := %s.Unmarshal(value, &%s); err != nil { return err }", formatJSON, varNameRawMap)
+
+		generateUnionNullCheck(out, declType, union)
+
+		err := generateUnionDecode(out, declType, union, func(varName string) string {
+			return fmt.Sprintf("%s.Unmarshal(value, &%s)", formatJSON, varName)
+		}, nil)
+		if err != nil {
+			return err
+		}
+
+		out.Indent(-1)
+		out.Printlnf("}")
+
+		return nil
+	}
+}
+
+func (jf *jsonFormatter) tupleMarshal(
+	declType *codegen.TypeDecl,
+	tuple *tupleType,
+) func(*codegen.Emitter) error {
+	return func(out *codegen.Emitter) error {
+		out.Commentf("Marshal%s implements %s.Marshaler.", strings.ToUpper(formatJSON), formatJSON)
+		out.Printlnf("func (j %s) Marshal%s() ([]byte, error) {", declType.Name, strings.ToUpper(formatJSON))
+		out.Indent(1)
+
+		generateTupleItems(out, declType, tuple)
+
+		out.Printlnf("return %s.Marshal(items)", formatJSON)
+		out.Indent(-1)
+		out.Printlnf("}")
+
+		return nil
+	}
+}
+
+func (jf *jsonFormatter) tupleUnmarshal(
+	declType *codegen.TypeDecl,
+	tuple *tupleType,
+) func(*codegen.Emitter) error {
+	return func(out *codegen.Emitter) error {
+		out.Commentf("Unmarshal%s implements %s.Unmarshaler.", strings.ToUpper(formatJSON), formatJSON)
+		out.Printlnf("func (j *%s) Unmarshal%s(value []byte) error {", declType.Name, strings.ToUpper(formatJSON))
+		out.Indent(1)
+		out.Printlnf("var items []%s.RawMessage", formatJSON)
+		out.Printlnf("if err := %s.Unmarshal(value, &items); err != nil { return err }", formatJSON)
+
+		err := generateTupleDecode(out, declType, tuple, func(item, varName string) string {
+			return fmt.Sprintf("%s.Unmarshal(%s, &%s)", formatJSON, item, varName)
+		})
+		if err != nil {
+			return err
+		}
+
+		out.Indent(-1)
+		out.Printlnf("}")
+
+		return nil
+	}
+}
+
 func (jf *jsonFormatter) addImport(out *codegen.File, declType *codegen.TypeDecl) {
 	out.Package.AddImport("encoding/json", "")
 
@@ -16,6 +16,8 @@ type output struct {
 	declsByName             map[string]*codegen.TypeDecl
 	declsBySchema           map[*schemas.Type]*codegen.TypeDecl
 	unmarshallersByTypeDecl map[*codegen.TypeDecl]bool
+	unionsByTypeDecl        map[*codegen.TypeDecl]*unionType
+	tuplesByTypeDecl        map[*codegen.TypeDecl]*tupleType
 	processedSchemas        map[string]bool
 	warner                  func(string)
 }
@@ -0,0 +1,219 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/atombender/go-jsonschema/pkg/schemas"
+)
+
+func (r OutputRule) validate() error {
+	matchers := 0
+
+	for _, m := range []string{r.IDPrefix, r.Glob, r.Path} {
+		if m != "" {
+			matchers++
+		}
+	}
+
+	if matchers != 1 {
+		return fmt.Errorf("%w: exactly one of an $id prefix, a glob and a path must be given", errInvalidOutputRule)
+	}
+
+	if _, err := path.Match(r.Glob, ""); err != nil {
+		return fmt.Errorf("%w: glob %q: %w", errInvalidOutputRule, r.Glob, err)
+	}
+
+	for _, template := range []string{r.PackageName, r.OutputName} {
+		if strings.Contains(expandOutputTemplate(template, "", ""), "{{") {
+			return fmt.Errorf("%w: %q has placeholders other than {{dir}} and {{basename}}", errInvalidOutputRule, template)
+		}
+	}
+
+	return nil
+}
+
+// match tells whether the rule matches the schema with the given $id loaded from the given file
+// or URL, and if so the directory and the base name of the schema relative to its pattern.
+func (r OutputRule) match(id, fileName string) (string, string, bool) {
+	id, _, _ = strings.Cut(id, "#")
+
+	switch {
+	case r.IDPrefix != "":
+		if id == "" || !strings.HasPrefix(id, r.IDPrefix) {
+			return "", "", false
+		}
+
+		return splitOutputPath(strings.TrimPrefix(id, r.IDPrefix))
+
+	case r.Glob != "":
+		for _, name := range []string{id, globSource(r.Glob, r.BaseDir, fileName)} {
+			if name != "" && globMatch(r.Glob, name) {
+				if base := globBase(r.Glob); base != "" {
+					name = strings.TrimPrefix(name, base+"/")
+				}
+
+				return splitOutputPath(name)
+			}
+		}
+
+		return "", "", false
+
+	default:
+		if !isLocalFile(fileName) {
+			return "", "", false
+		}
+
+		file, err := filepath.Abs(strings.TrimPrefix(fileName, "file://"))
+		if err != nil {
+			return "", "", false
+		}
+
+		dir, err := filepath.Abs(r.Path)
+		if err != nil {
+			return "", "", false
+		}
+
+		if file == dir {
+			return splitOutputPath(filepath.Base(file))
+		}
+
+		rel, err := filepath.Rel(dir, file)
+		if rel = filepath.ToSlash(rel); err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+			return "", "", false
+		}
+
+		return splitOutputPath(rel)
+	}
+}
+
+// packageName returns the package of the schema matched at the given directory and base name.
+func (r OutputRule) packageName(dir, base, defaultName string) string {
+	if r.PackageName == "" {
+		return defaultName
+	}
+
+	return path.Clean(expandOutputTemplate(r.PackageName, dir, base))
+}
+
+// outputName returns the output file of the schema matched at the given directory and base name.
+func (r OutputRule) outputName(dir, base, defaultName string) string {
+	if r.OutputName == "" {
+		return defaultName
+	}
+
+	name := expandOutputTemplate(r.OutputName, dir, base)
+	if name == "-" {
+		return name
+	}
+
+	return filepath.Clean(name)
+}
+
+func expandOutputTemplate(template, dir, base string) string {
+	return strings.NewReplacer("{{dir}}", dir, "{{basename}}", base).Replace(template)
+}
+
+// splitOutputPath returns the directory and the file name without extension of a slash-separated
+// path, the directory being empty for a path without one.
+func splitOutputPath(p string) (string, string, bool) {
+	p = strings.TrimPrefix(p, "/")
+
+	dir := path.Dir(p)
+	if dir == "." {
+		dir = ""
+	}
+
+	base := path.Base(p)
+
+	return dir, strings.TrimSuffix(base, path.Ext(base)), true
+}
+
+func isLocalFile(fileName string) bool {
+	refType, err := schemas.GetRefType(fileName)
+
+	return fileName != "-" && err == nil && refType == schemas.RefTypeFile
+}
+
+// globSource returns the name of the file or URL a schema is loaded from, for a glob to match: a
+// file name is relative to the base directory unless the glob is absolute, and is not matched by a
+// relative glob without a base directory.
+func globSource(glob, baseDir, fileName string) string {
+	if !isLocalFile(fileName) {
+		return fileName
+	}
+
+	fileName = strings.TrimPrefix(fileName, "file://")
+
+	abs, err := filepath.Abs(fileName)
+	if err != nil {
+		return ""
+	}
+
+	if path.IsAbs(glob) || filepath.IsAbs(glob) {
+		return filepath.ToSlash(abs)
+	}
+
+	if baseDir == "" {
+		return ""
+	}
+
+	base, err := filepath.Abs(baseDir)
+	if err != nil {
+		return ""
+	}
+
+	rel, err := filepath.Rel(base, abs)
+	if err != nil {
+		return ""
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+// globMatch matches a slash-separated name against a glob, in which ** matches any number of
+// path segments.
+func globMatch(glob, name string) bool {
+	return matchGlobSegments(strings.Split(glob, "/"), strings.Split(name, "/"))
+}
+
+func matchGlobSegments(glob, name []string) bool {
+	for len(glob) > 0 {
+		if glob[0] == "**" {
+			for i := range len(name) + 1 {
+				if matchGlobSegments(glob[1:], name[i:]) {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+
+		if ok, _ := path.Match(glob[0], name[0]); !ok {
+			return false
+		}
+
+		glob, name = glob[1:], name[1:]
+	}
+
+	return len(name) == 0
+}
+
+// globBase returns the leading path segments of a glob that have no wildcards, which the directory
+// of the names it matches is relative to.
+func globBase(glob string) string {
+	segments := strings.Split(glob, "/")
+
+	n := 0
+	for n < len(segments)-1 && !strings.ContainsAny(segments[n], `*?[\`) {
+		n++
+	}
+
+	return strings.Join(segments[:n], "/")
+}
@@ -0,0 +1,162 @@
+package generator_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/atombender/go-jsonschema/pkg/generator"
+	"github.com/atombender/go-jsonschema/pkg/schemas"
+)
+
+func TestOutputRules(t *testing.T) {
+	t.Parallel()
+
+	const (
+		petID    = "https://example.com/schemas/pets/pet.json"
+		petFile  = "schemas/pets/pet.json"
+		template = "gen/{{dir}}/{{basename}}.go"
+	)
+
+	testCases := []struct {
+		desc        string
+		rules       []generator.OutputRule
+		mappings    []generator.SchemaMapping
+		id          string
+		wantOutput  string
+		wantPackage string
+	}{
+		{
+			desc:        "path of a directory",
+			rules:       []generator.OutputRule{{Path: "schemas", PackageName: "example.com/gen/{{dir}}", OutputName: template}},
+			wantOutput:  "gen/pets/pet.go",
+			wantPackage: "pets",
+		},
+		{
+			desc:        "path of a file",
+			rules:       []generator.OutputRule{{Path: petFile, PackageName: "example.com/gen", OutputName: template}},
+			wantOutput:  "gen/pet.go",
+			wantPackage: "gen",
+		},
+		{
+			desc: "glob of files",
+			rules: []generator.OutputRule{
+				{Glob: "schemas/**/*.json", BaseDir: ".", PackageName: "example.com/gen/{{dir}}", OutputName: template},
+			},
+			wantOutput:  "gen/pets/pet.go",
+			wantPackage: "pets",
+		},
+		{
+			desc: "glob of files relative to the base directory",
+			rules: []generator.OutputRule{
+				{Glob: "pets/*.json", BaseDir: "schemas", PackageName: "example.com/{{basename}}", OutputName: template},
+			},
+			wantOutput:  "gen/pet.go",
+			wantPackage: "pet",
+		},
+		{
+			desc: "glob of $ids",
+			rules: []generator.OutputRule{
+				{Glob: "https://example.com/schemas/**", PackageName: "example.com/gen/{{dir}}", OutputName: template},
+			},
+			id:          petID,
+			wantOutput:  "gen/pets/pet.go",
+			wantPackage: "pets",
+		},
+		{
+			desc:        "$id prefix",
+			rules:       []generator.OutputRule{{IDPrefix: "https://example.com/schemas/", OutputName: template}},
+			id:          petID,
+			wantOutput:  "gen/pets/pet.go",
+			wantPackage: "test",
+		},
+		{
+			desc: "first matching rule",
+			rules: []generator.OutputRule{
+				{IDPrefix: "https://example.com/other/", OutputName: "other.go"},
+				{IDPrefix: "https://example.com/", OutputName: "first.go"},
+				{Path: "schemas", OutputName: "second.go"},
+			},
+			id:          petID,
+			wantOutput:  "first.go",
+			wantPackage: "test",
+		},
+		{
+			desc:  "schema mapping before rules",
+			rules: []generator.OutputRule{{Path: "schemas", OutputName: template}},
+			mappings: []generator.SchemaMapping{
+				{SchemaID: petID, PackageName: "example.com/mapped", OutputName: "mapped.go"},
+			},
+			id:          petID,
+			wantOutput:  "mapped.go",
+			wantPackage: "mapped",
+		},
+		{
+			desc: "no matching rule",
+			rules: []generator.OutputRule{
+				{IDPrefix: "https://example.com/other/", OutputName: template},
+				{Glob: "schemas/**/*.json", OutputName: template},
+				{Path: "other", OutputName: template},
+			},
+			id:          petID,
+			wantOutput:  "default.go",
+			wantPackage: "test",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			sources := generateWithRules(t, tC.rules, tC.mappings, map[string]string{petFile: tC.id})
+
+			require.Len(t, sources, 1)
+			require.Contains(t, sources, tC.wantOutput)
+			assert.Contains(t, string(sources[tC.wantOutput]), "package "+tC.wantPackage+"\n")
+		})
+	}
+}
+
+func TestOutputRulesSharedOutput(t *testing.T) {
+	t.Parallel()
+
+	sources := generateWithRules(t, []generator.OutputRule{{Path: "schemas", OutputName: "all.go"}}, nil,
+		map[string]string{"schemas/pets/pet.json": "", "schemas/pets/owner.json": ""})
+
+	require.Len(t, sources, 1)
+	assert.Equal(t, 1, strings.Count(string(sources["all.go"]), "type PetJson struct"))
+	assert.Equal(t, 1, strings.Count(string(sources["all.go"]), "type OwnerJson struct"))
+}
+
+// generateWithRules generates code from object schemas with the given $ids, by file name, and returns
+// the sources by output file.
+func generateWithRules(
+	t *testing.T,
+	rules []generator.OutputRule,
+	mappings []generator.SchemaMapping,
+	ids map[string]string,
+) map[string][]byte {
+	t.Helper()
+
+	g, err := generator.New(generator.Config{
+		DefaultPackageName: "example.com/test",
+		DefaultOutputName:  "default.go",
+		SchemaMappings:     mappings,
+		OutputRules:        rules,
+		Warner:             func(string) {},
+	})
+	require.NoError(t, err)
+
+	for fileName, id := range ids {
+		schema, err := schemas.FromJSONReader(strings.NewReader(
+			`{"$id": "` + id + `", "type": "object", "properties": {"name": {"type": "string"}}}`))
+		require.NoError(t, err)
+		require.NoError(t, g.AddFile(fileName, schema))
+	}
+
+	sources, err := g.Sources()
+	require.NoError(t, err)
+
+	return sources
+}
@@ -3,6 +3,8 @@ package generator
 import (
 	"errors"
 	"fmt"
+	"net/url"
+	"reflect"
 	"slices"
 	"strings"
 
@@ -68,7 +70,19 @@ func (g *schemaGenerator) generateRootType() error {
 		}
 	}
 
-	if len(g.schema.Type) == 0 {
+	nested := g.schema.NestedDefinitions()
+	for _, ptr := range sortedKeys(nested) {
+		pointer, err := schemas.ParseJSONPointer(ptr)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errCannotGenerateReferencedType, err)
+		}
+
+		if _, err := g.generateDeclaredType(nested[ptr], g.pointerScope(pointer)); err != nil {
+			return err
+		}
+	}
+
+	if len(g.schema.Type) == 0 && len(g.unionBranches((*schemas.Type)(g.schema.ObjectAsType))) == 0 {
 		return nil
 	}
 
@@ -83,31 +97,20 @@ func (g *schemaGenerator) generateRootType() error {
 }
 
 func (g *schemaGenerator) generateReferencedType(t *schemas.Type) (codegen.Type, error) {
-	defName, fileName, err := g.extractRefNames(t)
+	pointer, fileName, err := g.extractRefNames(t)
 	if err != nil {
-		return nil, err
+		return nil, schemas.ErrorAt(t, err)
 	}
 
-	if fileName == "" {
-		if schemaOutput, ok := g.outputs[g.schema.ID]; ok {
-			if decl, ok := schemaOutput.declsByName[defName]; ok {
-				if decl != nil {
-					return &codegen.NamedType{Decl: decl}, nil
-				}
-			}
-		}
-	}
+	defName := definitionName(pointer)
 
-	if t.Ref == "#" {
-		if schemaOutput, ok := g.outputs[g.schema.ID]; ok {
-			if decl, ok := schemaOutput.declsBySchema[t]; ok {
-				if decl != nil {
-					return decl.Type, nil
-				}
+	if fileName == "" {
+		if schemaOutput, ok := g.outputs[outputKey(g.schema.ID, g.schemaFileName)]; ok {
+			// The output may be shared with other schemas, so the name alone does not identify the definition.
+			if decl, ok := schemaOutput.declsByName[defName]; ok && decl != nil && g.declaresDefinition(decl, pointer) {
+				return &codegen.NamedType{Decl: decl}, nil
 			}
 		}
-
-		return emptyInterfaceTypeVal, nil
 	}
 
 	schema := g.schema
@@ -118,41 +121,46 @@ func (g *schemaGenerator) generateReferencedType(t *schemas.Type) (codegen.Type,
 
 		schema, serr = g.loader.Load(fileName, g.schemaFileName)
 		if serr != nil {
-			return nil, fmt.Errorf("could not follow $ref %q to file %q: %w", t.Ref, fileName, serr)
+			return nil, schemas.ErrorAt(t, fmt.Errorf("could not follow $ref %q to file %q: %w", t.Ref, fileName, serr))
 		}
 
-		qualified, qerr := schemas.QualifiedFileName(fileName, g.schemaFileName, g.config.ResolveExtensions)
+		qualified, qerr := schemas.LoaderQualifiedFileName(g.loader, fileName, g.schemaFileName, g.config.ResolveExtensions)
 		if qerr != nil {
-			return nil, fmt.Errorf("could not resolve qualified file name for %s: %w", fileName, qerr)
+			return nil, schemas.ErrorAt(t, fmt.Errorf("could not resolve qualified file name for %s: %w", fileName, qerr))
 		}
 
 		if ferr := g.AddFile(qualified, schema); ferr != nil {
 			return nil, ferr
 		}
 
-		output, oerr := g.findOutputFileForSchemaID(schema.ID)
+		output, oerr := g.findOutputFileForSchemaID(schema.ID, qualified)
 		if oerr != nil {
 			return nil, oerr
 		}
 
+		// The pointer may address the schemas of an OpenAPI document, which are held as definitions.
+		pointer = schema.ModelTokens(pointer)
+
 		sg = newSchemaGenerator(g.Generator, schema, qualified, output)
 	}
 
-	var def *schemas.Type
+	var (
+		def   *schemas.Type
+		scope nameScope
+	)
 
-	if defName != "" {
-		// TODO: Support nested definitions.
-		var ok bool
+	if len(pointer) > 0 {
+		var rerr error
 
-		def, ok = schema.Definitions[defName]
-		if !ok {
-			return nil, fmt.Errorf("%w: %q (from ref %q)", errDefinitionDoesNotExistInSchema, defName, t.Ref)
+		def, rerr = schema.ResolveJSONPointer(pointer)
+		if rerr != nil {
+			return nil, schemas.ErrorAt(t, fmt.Errorf("%w: %w (from ref %q)", errDefinitionDoesNotExistInSchema, rerr, t.Ref))
 		}
 
-		defName = g.caser.Identifierize(defName)
+		scope = sg.pointerScope(pointer)
 	} else {
 		def = (*schemas.Type)(schema.ObjectAsType)
-		defName = g.getRootTypeName(schema, fileName)
+		scope = newNameScope(g.getRootTypeName(schema, fileName))
 
 		if len(def.Type) == 0 {
 			// Minor hack to make definitions default to being objects.
@@ -167,7 +175,7 @@ func (g *schemaGenerator) generateReferencedType(t *schemas.Type) (codegen.Type,
 
 	defer cleanupCycle()
 
-	dt, err := sg.generateDeclaredType(def, newNameScope(defName))
+	dt, err := sg.generateDeclaredType(def, scope)
 	if err != nil {
 		return nil, err
 	}
@@ -213,40 +221,150 @@ func (g *schemaGenerator) generateReferencedType(t *schemas.Type) (codegen.Type,
 	}, nil
 }
 
-func (g *schemaGenerator) extractRefNames(t *schemas.Type) (string, string, error) {
-	scope := ""
-	defName := ""
-	fileName := t.Ref
+// extractRefNames splits a reference into the JSON Pointer tokens of its fragment and the file it points into,
+// which is empty for references within the current schema. References to an $id or $anchor known to the
+// registry are translated to the location of the subschema they identify.
+func (g *schemaGenerator) extractRefNames(t *schemas.Type) ([]string, string, error) {
+	if res, ok := g.registry.Resolve(t.Ref, t); ok {
+		if res.Document == g.schema {
+			return res.Pointer, "", nil
+		}
 
-	if i := strings.IndexRune(t.Ref, '#'); i != -1 {
-		var prefix string
+		return res.Pointer, g.mapURI(res.Location), nil
+	}
 
-		fileName, scope = t.Ref[0:i], t.Ref[i+1:]
-		lowercaseScope := strings.ToLower(scope)
+	fileName, fragment, _ := strings.Cut(t.Ref, "#")
+	if fileName != "" {
+		fileName = g.refFileName(t)
+	}
+
+	// The pointer is percent-encoded as any other URI fragment.
+	fragment, err := url.PathUnescape(fragment)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %w", errCannotGenerateReferencedType, err)
+	}
 
-		for _, currentPrefix := range []string{
-			"/$defs/",       // Draft-handrews-json-schema-validation-02.
-			"/definitions/", // Legacy.
-		} {
-			if strings.HasPrefix(lowercaseScope, currentPrefix) {
-				prefix = currentPrefix
+	pointer, err := schemas.ParseJSONPointer(fragment)
+	if err != nil {
+		return nil, "", fmt.Errorf("%w: %w", errCannotGenerateReferencedType, err)
+	}
 
-				break
+	if fileName == "" {
+		pointer = g.schema.ModelTokens(pointer)
+	}
+
+	return pointer, fileName, nil
+}
+
+// declaresDefinition reports whether the declaration was generated from the definition that the
+// JSON Pointer addresses in the schema being generated.
+func (g *schemaGenerator) declaresDefinition(decl *codegen.TypeDecl, pointer []string) bool {
+	if len(pointer) == 0 {
+		return decl.SchemaType == (*schemas.Type)(g.schema.ObjectAsType)
+	}
+
+	def, err := g.schema.ResolveJSONPointer(pointer)
+
+	return err == nil && decl.SchemaType == def
+}
+
+// refFileName returns the file name or URL of the document a reference to another document points into.
+// Of the locations the reference may be resolved to, against the $id in effect or against the location
+// of the current schema, the first one that can be loaded is preferred.
+func (g *schemaGenerator) refFileName(t *schemas.Type) string {
+	locations, _ := g.registry.RefLocations(t.Ref, t)
+
+	for i, location := range locations {
+		locations[i] = g.mapURI(location)
+	}
+
+	if len(locations) > 1 {
+		for _, location := range locations {
+			if _, err := g.loader.Load(location, g.schemaFileName); err == nil {
+				return location
 			}
 		}
+	}
 
-		if len(prefix) == 0 {
-			return "", "", fmt.Errorf(
-				"%w: value must point to definition within file: '%s'",
-				errCannotGenerateReferencedType,
-				t.Ref,
-			)
-		}
+	return locations[0]
+}
+
+// mapURI rewrites a remote URI to a local file when it matches one of the configured mappings,
+// so that the schema is generated exactly as if it had been referenced through its local path.
+func (g *schemaGenerator) mapURI(uri string) string {
+	if fileName, ok := schemas.MapURI(g.config.URIMappings, uri); ok {
+		return fileName
+	}
+
+	return uri
+}
+
+// deprecatedComment adds a deprecation notice, as recognized by Go tools, to the comment of the
+// declaration of a type or field whose schema is deprecated.
+func deprecatedComment(comment string, t *schemas.Type) string {
+	if !t.Deprecated {
+		return comment
+	}
+
+	notice := "Deprecated: marked as deprecated in the JSON schema."
+	if comment == "" {
+		return notice
+	}
+
+	return comment + "\n\n" + notice
+}
+
+// definitionName returns the name of the top-level definition a JSON Pointer refers to,
+// or an empty string if it points anywhere else.
+func definitionName(pointer []string) string {
+	if len(pointer) == 2 && isDefinitionsKeyword(pointer[0]) {
+		return pointer[1]
+	}
+
+	return ""
+}
+
+func isDefinitionsKeyword(token string) bool {
+	return token == "$defs" || token == "definitions"
+}
+
+// pointerScope derives the name of the type declared for the subschema a JSON Pointer
+// refers to, from the definition and property names along the way.
+func (g *schemaGenerator) pointerScope(pointer []string) nameScope {
+	var scope nameScope
+
+	i := 0
+
+	if len(pointer) >= 2 && isDefinitionsKeyword(pointer[0]) {
+		scope = newNameScope(g.caser.Identifierize(pointer[1]))
+		i = 2
+	} else {
+		scope = newNameScope(g.getRootTypeName(g.schema, g.schemaFileName))
+	}
+
+	for ; i < len(pointer); i++ {
+		keyword := pointer[i]
+		hasNext := i+1 < len(pointer)
+
+		switch {
+		case keyword == "items":
+			scope = g.singularScope(scope)
+
+		case hasNext && (isDefinitionsKeyword(keyword) || keyword == "properties" ||
+			keyword == "patternProperties" || keyword == "dependentSchemas" || keyword == "dependencies"):
+			i++
+			scope = scope.add(g.caser.Identifierize(pointer[i]))
+
+		case hasNext && (keyword == "allOf" || keyword == "anyOf" || keyword == "oneOf"):
+			i++
+			scope = scope.add("_" + pointer[i])
 
-		defName = scope[len(prefix):]
+		default:
+			scope = scope.add(g.caser.Identifierize(keyword))
+		}
 	}
 
-	return defName, fileName, nil
+	return scope
 }
 
 //nolint:gocyclo // todo: reduce cyclomatic complexity
@@ -296,7 +414,7 @@ func (g *schemaGenerator) generateDeclaredType(t *schemas.Type, scope nameScope)
 
 	decl := codegen.TypeDecl{
 		Name:       name,
-		Comment:    t.Description,
+		Comment:    deprecatedComment(t.Description, t),
 		SchemaType: t,
 	}
 	g.output.declsBySchema[t] = &decl
@@ -327,6 +445,18 @@ func (g *schemaGenerator) generateDeclaredType(t *schemas.Type, scope nameScope)
 
 	switch tt := theType.(type) {
 	case *codegen.StructType:
+		if union, ok := g.output.unionsByTypeDecl[&decl]; ok {
+			g.generateUnionMarshalers(&decl, union)
+
+			return &codegen.NamedType{Decl: &decl}, nil
+		}
+
+		if tuple, ok := g.output.tuplesByTypeDecl[&decl]; ok {
+			g.generateTupleMarshalers(&decl, tuple)
+
+			return &codegen.NamedType{Decl: &decl}, nil
+		}
+
 		if t.GetSubSchemaType() == schemas.SubSchemaTypeAnyOf {
 			validators = append(validators, &anyOfValidator{decl.Name, t.GetSubSchemasCount()})
 			g.generateUnmarshaler(&decl, validators)
@@ -338,6 +468,10 @@ func (g *schemaGenerator) generateDeclaredType(t *schemas.Type, scope nameScope)
 			validators = append(validators, &requiredValidator{f, decl.Name})
 		}
 
+		if cv := g.conditionalValidator(t, decl.Name); cv != nil {
+			validators = append(validators, cv)
+		}
+
 		for _, f := range tt.Fields {
 			if f.DefaultValue != nil {
 				if f.Name == additionalProperties {
@@ -529,6 +663,115 @@ func (g *schemaGenerator) structFieldValidators(
 	return validators
 }
 
+// conditionalValidator translates if/then/else into a validator evaluated against the raw map,
+// or returns nil if there's nothing to enforce.
+func (g *schemaGenerator) conditionalValidator(t *schemas.Type, declName string) *conditionalValidator {
+	if t.If == nil || (t.Then == nil && t.Else == nil) {
+		return nil
+	}
+
+	ifCond, unsupported := g.conditionalConstraints(t.If)
+	if len(unsupported) > 0 {
+		g.warner(fmt.Sprintf("Cannot evaluate \"if\" in %s because of unsupported keywords %s; "+
+			"skipping validation code for its then/else", declName, strings.Join(unsupported, ", ")))
+
+		return nil
+	}
+
+	v := &conditionalValidator{
+		declName: declName,
+		ifCond:   *ifCond,
+	}
+
+	v.thenCond = g.conditionalBranch(t.Then, "then", declName)
+	v.elseCond = g.conditionalBranch(t.Else, "else", declName)
+
+	if v.thenCond == nil && v.elseCond == nil {
+		return nil
+	}
+
+	return v
+}
+
+func (g *schemaGenerator) conditionalBranch(t *schemas.Type, keyword, declName string) *conditionalConstraints {
+	if t == nil {
+		return nil
+	}
+
+	cond, unsupported := g.conditionalConstraints(t)
+	if len(unsupported) > 0 {
+		g.warner(fmt.Sprintf("Ignoring unsupported keywords %s in %q of %s",
+			strings.Join(unsupported, ", "), keyword, declName))
+	}
+
+	return cond
+}
+
+// conditionalConstraints extracts the required properties and the const or enum property
+// values of an if/then/else subschema. Everything else is returned as unsupported.
+func (g *schemaGenerator) conditionalConstraints(t *schemas.Type) (*conditionalConstraints, []string) {
+	if t.Ref != "" {
+		resolved, err := g.resolveRef(t)
+		if err != nil {
+			return nil, []string{"$ref"}
+		}
+
+		t = resolved
+	}
+
+	var unsupported []string
+
+	val := reflect.ValueOf(*t)
+	for i := range val.NumField() {
+		field := val.Type().Field(i)
+		if !field.IsExported() || val.Field(i).IsZero() {
+			continue
+		}
+
+		switch name := strings.Split(field.Tag.Get("json"), ",")[0]; name {
+		case "-", "$schema", "title", "description", "properties", "required":
+		case "type":
+			if !t.Type.Equals(schemas.TypeList{schemas.TypeNameObject}) {
+				unsupported = append(unsupported, name)
+			}
+
+		default:
+			unsupported = append(unsupported, name)
+		}
+	}
+
+	c := &conditionalConstraints{
+		required: t.Required,
+		values:   make(map[string][]any, len(t.Properties)),
+	}
+
+	for _, name := range sortedKeys(t.Properties) {
+		prop := t.Properties[name]
+
+		values := prop.Enum
+		if prop.Const != nil {
+			values = []any{prop.Const}
+		}
+
+		if len(values) == 0 || slices.ContainsFunc(values, func(v any) bool {
+			switch v.(type) {
+			case nil, string, float64, bool:
+				return false
+			}
+
+			return true
+		}) {
+			unsupported = append(unsupported, "properties/"+name)
+
+			continue
+		}
+
+		c.values[name] = values
+	}
+
+	return c, unsupported
+}
+
 func (g *schemaGenerator) generateUnmarshaler(decl *codegen.TypeDecl, validators []validator) {
 	if g.config.OnlyModels {
 		return
@@ -589,10 +832,18 @@ func (g *schemaGenerator) generateType(t *schemas.Type, scope nameScope) (codege
 		return g.generateReferencedType(t)
 	}
 
+	if branches := g.unionBranches(t); len(branches) > 0 {
+		return g.generateUnionType(t, branches, scope)
+	}
+
 	typeName, typePtr := g.determineTypeName(t)
 
 	switch typeName {
 	case schemas.TypeNameArray:
+		if len(t.PrefixItems) > 0 {
+			return g.generateTupleType(t, scope)
+		}
+
 		if t.Items == nil {
 			return arrayTypeVal, nil
 		}
@@ -622,7 +873,7 @@ func (g *schemaGenerator) generateType(t *schemas.Type, scope nameScope) (codege
 			&t.ExclusiveMaximum,
 		)
 		if err != nil {
-			return nil, fmt.Errorf("invalid type %q: %w", typeName, err)
+			return nil, schemas.ErrorAt(t, fmt.Errorf("invalid type %q: %w", typeName, err))
 		}
 
 		if ncg, ok := cg.(codegen.NamedType); ok {
@@ -882,6 +1133,8 @@ func (g *schemaGenerator) addStructField(
 		comment = fmt.Sprintf("%s corresponds to the JSON schema field %q.", fieldName, name)
 	}
 
+	comment = deprecatedComment(comment, prop)
+
 	structFieldType, err := g.generateStructFieldType(prop, scope.add(fieldName), isRequired)
 	if err != nil {
 		return fmt.Errorf("cannot add struct field: %w", err)
@@ -998,7 +1251,7 @@ func (g *schemaGenerator) appendStructRequiredJSONFields(
 
 func (g *schemaGenerator) generateAnyOfType(t *schemas.Type, scope nameScope) (codegen.Type, error) {
 	if len(t.AnyOf) == 0 {
-		return nil, errEmptyInAnyOf
+		return nil, schemas.ErrorAt(t, errEmptyInAnyOf)
 	}
 
 	if g.config.AliasSingleAllOfAnyOfRefs && len(t.AnyOf) == 1 && t.IsEmptyObject() {
@@ -1016,6 +1269,13 @@ func (g *schemaGenerator) generateAnyOfType(t *schemas.Type, scope nameScope) (c
 	isCycle := false
 	rAnyOf, hasNull := g.resolveRefs(t.AnyOf, false)
 
+	if !g.config.DisableUnionTypes && t.Discriminator == nil && len(rAnyOf) > 1 {
+		if discriminator, _ := inferredDiscriminator(rAnyOf); discriminator != "" {
+			g.warner(fmt.Sprintf("anyOf branches are told apart by property %q, but only a oneOf or an anyOf "+
+				"with a discriminator is generated as a union", discriminator))
+		}
+	}
+
 	for i, typ := range rAnyOf {
 		// infer type from base if not set
 		if len(typ.Type) == 0 {
@@ -1085,6 +1345,338 @@ func (g *schemaGenerator) generateAllOfType(t *schemas.Type, scope nameScope) (c
 	return g.generateTypeInline(allOfType, scope)
 }
 
+// generateUnionType generates a union: a struct wrapping a sealed interface
+// that is implemented by one declared type per branch.
+func (g *schemaGenerator) generateUnionType(
+	t *schemas.Type,
+	branches []*schemas.Type,
+	scope nameScope,
+) (codegen.Type, error) {
+	decl, ok := g.output.declsBySchema[t]
+	if !ok {
+		// The sealed interface and its methods hang off the union's declaration.
+		return g.generateDeclaredType(t, scope)
+	}
+
+	var base *schemas.Type
+
+	if len(t.Properties) > 0 || len(t.Required) > 0 {
+		base = &schemas.Type{
+			Type:                 t.Type,
+			Properties:           t.Properties,
+			Required:             t.Required,
+			AdditionalProperties: t.AdditionalProperties,
+		}
+	}
+
+	discriminator, tags := g.unionDiscriminator(t, branches)
+
+	union := &unionType{
+		markerMethod:  "is" + decl.Name,
+		discriminator: discriminator,
+	}
+
+	// Referenced variants may be declared in another package, where they cannot implement the sealed
+	// interface. Find out before declaring any other variant, so that none is left behind unused.
+	variantTypes := make([]codegen.Type, len(branches))
+
+	for i, variant := range branches {
+		if base != nil || variant.Ref == "" || isNullSchemaType(variant) {
+			continue
+		}
+
+		vt, err := g.generateUnionVariant(variant, t, base, scope.add(fmt.Sprintf("_%d", i)))
+		if err != nil {
+			return nil, err
+		}
+
+		if nt, err := g.extractPointedType(vt); err != nil || nt.Package != nil {
+			return g.unionFallback(decl, i), nil
+		}
+
+		variantTypes[i] = vt
+	}
+
+	for i, variant := range branches {
+		if isNullSchemaType(variant) {
+			union.nullable = true
+
+			continue
+		}
+
+		vt := variantTypes[i]
+		if vt == nil {
+			var err error
+
+			if vt, err = g.generateUnionVariant(variant, t, base, scope.add(fmt.Sprintf("_%d", i))); err != nil {
+				return nil, err
+			}
+		}
+
+		nt, err := g.extractPointedType(vt)
+		if err != nil || nt.Package != nil {
+			return g.unionFallback(decl, i), nil
+		}
+
+		var variantTags []string
+		if tags != nil {
+			variantTags = tags[i]
+		}
+
+		union.addVariant(nt, variantTags)
+	}
+
+	if len(union.variants) == 0 {
+		return emptyInterfaceTypeVal, nil
+	}
+
+	union.interfaceDecl = &codegen.TypeDecl{
+		Name: g.output.uniqueTypeName(newNameScope(decl.Name + "Variant")),
+		Type: codegen.InterfaceType{Methods: []string{union.markerMethod}},
+	}
+	union.interfaceDecl.Comment = fmt.Sprintf(
+		"%s is implemented by every type that %s can hold.", union.interfaceDecl.Name, decl.Name)
+
+	g.output.file.Package.AddDecl(union.interfaceDecl)
+	g.output.declsByName[union.interfaceDecl.Name] = union.interfaceDecl
+
+	for _, v := range union.variants {
+		variantName := v.typ.Decl.Name
+		markerMethod := union.markerMethod
+
+		g.output.file.Package.AddDecl(&codegen.Method{
+			Name: variantName + "_oneOf_" + decl.Name,
+			Impl: func(out *codegen.Emitter) error {
+				out.Printlnf("func (%s) %s() {}", variantName, markerMethod)
+
+				return nil
+			},
+		})
+	}
+
+	g.output.unionsByTypeDecl[decl] = union
+
+	return &codegen.StructType{
+		Fields: []codegen.StructField{
+			{
+				Name:    "Value",
+				Comment: fmt.Sprintf("Value holds exactly one of the types implementing %s.", union.interfaceDecl.Name),
+				Type:    &codegen.NamedType{Decl: union.interfaceDecl},
+			},
+		},
+	}, nil
+}
+
+// unionFallback warns that a variant of a union cannot implement its sealed interface, and returns
+// the type the union is represented by instead.
+func (g *schemaGenerator) unionFallback(decl *codegen.TypeDecl, variant int) codegen.Type {
+	g.warner(fmt.Sprintf("Variant %d of %s cannot implement a sealed interface; "+
+		"will be represented as interface{} with no validation", variant, decl.Name))
+
+	return emptyInterfaceTypeVal
+}
+
+// generateTupleType generates a tuple: a struct with a field for every item of prefixItems,
+// and a Rest slice for the items allowed after them by items.
+func (g *schemaGenerator) generateTupleType(t *schemas.Type, scope nameScope) (codegen.Type, error) {
+	decl, ok := g.output.declsBySchema[t]
+	if !ok {
+		// The marshalers hang off the tuple's declaration.
+		return g.generateDeclaredType(t, scope)
+	}
+
+	tuple := &tupleType{
+		minItems: t.MinItems,
+		maxItems: t.MaxItems,
+	}
+
+	fields := make([]codegen.StructField, 0, len(t.PrefixItems)+1)
+	names := map[string]bool{"Rest": true}
+
+	for i, item := range t.PrefixItems {
+		name := fmt.Sprintf("Item%d", i)
+		if title := g.caser.Identifierize(item.Title); item.Title != "" && !names[title] {
+			name = title
+		}
+
+		names[name] = true
+
+		ft, err := g.generateTypeInline(item, scope.add(name))
+		if err != nil {
+			return nil, err
+		}
+
+		optional := i >= t.MinItems
+		if optional {
+			ft = codegen.WrapTypeInPointer(ft)
+		}
+
+		comment := item.Description
+		if comment == "" {
+			comment = fmt.Sprintf("%s corresponds to item %d of the JSON schema tuple.", name, i)
+		}
+
+		fields = append(fields, codegen.StructField{
+			Name:       name,
+			Type:       ft,
+			Comment:    comment,
+			SchemaType: item,
+		})
+		tuple.items = append(tuple.items, tupleItem{fieldName: name, optional: optional})
+	}
+
+	if t.Items == nil || !t.Items.IsFalse() {
+		tuple.rest = emptyInterfaceTypeVal
+
+		if t.Items != nil {
+			rt, err := g.generateTypeInline(t.Items, g.singularScope(scope))
+			if err != nil {
+				return nil, err
+			}
+
+			tuple.rest = rt
+		}
+
+		fields = append(fields, codegen.StructField{
+			Name:    "Rest",
+			Type:    codegen.ArrayType{Type: tuple.rest},
+			Comment: "Rest holds the items following the positional ones.",
+		})
+	}
+
+	g.output.tuplesByTypeDecl[decl] = tuple
+
+	return &codegen.StructType{Fields: fields}, nil
+}
+
+func (g *schemaGenerator) generateTupleMarshalers(decl *codegen.TypeDecl, tuple *tupleType) {
+	g.output.file.Package.AddImport("fmt", "")
+
+	for _, formatter := range g.formatters {
+		formatter.addImport(g.output.file, decl)
+
+		g.output.file.Package.AddDecl(&codegen.Method{
+			Impl: formatter.tupleMarshal(decl, tuple),
+			Name: decl.GetName() + "_tuple_marshal_" + formatter.getName(),
+		})
+
+		g.output.file.Package.AddDecl(&codegen.Method{
+			Impl: formatter.tupleUnmarshal(decl, tuple),
+			Name: decl.GetName() + "_tuple_unmarshal_" + formatter.getName(),
+		})
+	}
+}
+
+// unionBranches returns the branches a union is generated from: those of a oneOf, or those of
+// an anyOf whose branches are mutually exclusive because a declared discriminator tells them apart.
+func (g *schemaGenerator) unionBranches(t *schemas.Type) []*schemas.Type {
+	if g.config.DisableUnionTypes {
+		return nil
+	}
+
+	if len(t.OneOf) > 0 {
+		return t.OneOf
+	}
+
+	if len(t.AnyOf) > 1 && t.Discriminator != nil {
+		if discriminator, _ := g.unionDiscriminator(t, t.AnyOf); discriminator != "" {
+			return t.AnyOf
+		}
+	}
+
+	return nil
+}
+
+// unionDiscriminator finds the property telling the branches of a union apart, along with the values
+// selecting each branch. It is either declared through an OpenAPI discriminator, or inferred from
+// a property that every branch requires and pins to a distinct string const.
+func (g *schemaGenerator) unionDiscriminator(t *schemas.Type, branches []*schemas.Type) (string, [][]string) {
+	resolved := make([]*schemas.Type, len(branches))
+
+	for i, b := range branches {
+		r, err := g.resolveRef(b)
+		if err != nil {
+			return "", nil
+		}
+
+		resolved[i] = r
+	}
+
+	if d := t.Discriminator; d != nil && d.PropertyName != "" {
+		tags := declaredDiscriminatorTags(d, branches, resolved)
+		if !validDiscriminatorTags(tags, resolved) {
+			g.warner(fmt.Sprintf("Discriminator %q does not tell every variant apart; "+
+				"every variant will be tried instead", d.PropertyName))
+
+			return "", nil
+		}
+
+		return d.PropertyName, tags
+	}
+
+	return inferredDiscriminator(resolved)
+}
+
+// generateUnionVariant declares the type of a single union branch. Properties declared next to
+// the union are merged into every branch, so that each variant is a complete type on its own.
+func (g *schemaGenerator) generateUnionVariant(
+	variant, parent, base *schemas.Type,
+	scope nameScope,
+) (codegen.Type, error) {
+	if base == nil {
+		if variant.Ref == "" && len(variant.Type) == 0 && len(variant.OneOf) == 0 {
+			// The branch takes the type of the union; copy it rather than change the parsed schema.
+			typed := *variant
+			typed.Type = append(schemas.TypeList{}, parent.Type...)
+			variant = &typed
+		}
+
+		return g.generateDeclaredType(variant, scope)
+	}
+
+	resolved, err := g.resolveRef(variant)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, err := schemas.AllOf([]*schemas.Type{resolved}, base)
+	if err != nil {
+		return nil, fmt.Errorf("could not merge union variant: %w", err)
+	}
+
+	if len(merged.Type) == 0 {
+		merged.Type = schemas.TypeList{schemas.TypeNameObject}
+	}
+
+	return g.generateDeclaredType(merged, scope)
+}
+
+func (g *schemaGenerator) generateUnionMarshalers(decl *codegen.TypeDecl, union *unionType) {
+	if union.discriminator == "" {
+		g.output.file.Package.AddImport("errors", "")
+	}
+
+	g.output.file.Package.AddImport("fmt", "")
+
+	for _, formatter := range g.formatters {
+		formatter.addImport(g.output.file, decl)
+
+		if union.discriminator != "" && formatter.getName() == formatJSON {
+			g.output.file.Package.AddImport("bytes", "")
+		}
+
+		g.output.file.Package.AddDecl(&codegen.Method{
+			Impl: formatter.unionMarshal(decl, union),
+			Name: decl.GetName() + "_union_marshal_" + formatter.getName(),
+		})
+
+		g.output.file.Package.AddDecl(&codegen.Method{
+			Impl: formatter.unionUnmarshal(decl, union),
+			Name: decl.GetName() + "_union_unmarshal_" + formatter.getName(),
+		})
+	}
+}
+
 func (g *schemaGenerator) defaultPropertyValue(prop *schemas.Type) any {
 	if prop.AdditionalProperties != nil {
 		if len(prop.AdditionalProperties.Type) == 0 {
@@ -1136,6 +1728,19 @@ func (g *schemaGenerator) generateTypeInline(t *schemas.Type, scope nameScope) (
 			}
 		}
 
+		if len(g.unionBranches(t)) > 0 {
+			dt, err := g.generateDeclaredType(t, scope)
+			if err != nil {
+				return nil, err
+			}
+
+			if typeIsNullable {
+				return codegen.WrapTypeInPointer(dt), nil
+			}
+
+			return dt, nil
+		}
+
 		if len(t.AnyOf) > 0 {
 			return g.generateAnyOfType(t, scope)
 		}
@@ -1179,7 +1784,7 @@ func (g *schemaGenerator) generateTypeInline(t *schemas.Type, scope nameScope) (
 				&t.ExclusiveMaximum,
 			)
 			if err != nil {
-				return nil, fmt.Errorf("invalid type %q: %w", t.Type[typeIndex], err)
+				return nil, schemas.ErrorAt(t, fmt.Errorf("invalid type %q: %w", t.Type[typeIndex], err))
 			}
 
 			if ncg, ok := cg.(codegen.NamedType); ok {
@@ -1209,7 +1814,7 @@ func (g *schemaGenerator) generateTypeInline(t *schemas.Type, scope nameScope) (
 			return cg, nil
 		}
 
-		if typeIndex != -1 && t.Type[typeIndex] == schemas.TypeNameArray {
+		if typeIndex != -1 && t.Type[typeIndex] == schemas.TypeNameArray && len(t.PrefixItems) == 0 {
 			var theType codegen.Type = emptyInterfaceTypeVal
 
 			if t.Items != nil {
@@ -1257,7 +1862,7 @@ func (g *schemaGenerator) generateEnumType(
 	t *schemas.Type, scope nameScope,
 ) (codegen.Type, error) {
 	if len(t.Enum) == 0 {
-		return nil, errEnumArrCannotBeEmpty
+		return nil, schemas.ErrorAt(t, errEnumArrCannotBeEmpty)
 	}
 
 	var wrapInStruct bool
@@ -1276,7 +1881,7 @@ func (g *schemaGenerator) generateEnumType(
 			&t.ExclusiveMinimum,
 			&t.ExclusiveMaximum,
 		); err != nil {
-			return nil, fmt.Errorf("invalid type %q: %w", t.Type[0], err)
+			return nil, schemas.ErrorAt(t, fmt.Errorf("invalid type %q: %w", t.Type[0], err))
 		}
 
 		// Enforce integer type for enum values.
@@ -1287,7 +1892,7 @@ func (g *schemaGenerator) generateEnumType(
 					t.Enum[i] = int(v)
 
 				default:
-					return nil, fmt.Errorf("%w %v", errEnumNonPrimitiveVal, v)
+					return nil, schemas.ErrorAt(t, fmt.Errorf("%w %v", errEnumNonPrimitiveVal, v))
 				}
 			}
 		}
@@ -1318,7 +1923,7 @@ func (g *schemaGenerator) generateEnumType(
 					valueType = "bool"
 
 				default:
-					return nil, fmt.Errorf("%w %v", errEnumNonPrimitiveVal, v)
+					return nil, schemas.ErrorAt(t, fmt.Errorf("%w %v", errEnumNonPrimitiveVal, v))
 				}
 			}
 
@@ -1445,14 +2050,14 @@ func (g *schemaGenerator) resolveRef(t *schemas.Type) (*schemas.Type, error) {
 
 	ntyp, err := g.extractPointedType(typ)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %w", errCannotResolveRef, err)
+		return nil, schemas.ErrorAt(t, fmt.Errorf("%w: %w", errCannotResolveRef, err))
 	}
 
 	// After resolving the ref type we lose info about the original schema
 	// so rewrite all nested refs to include the original schema id
 	_, fileName, err := g.extractRefNames(t)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %w", errCannotResolveRef, err)
+		return nil, schemas.ErrorAt(t, fmt.Errorf("%w: %w", errCannotResolveRef, err))
 	}
 
 	if fileName != "" {
@@ -1484,11 +2089,13 @@ func (g *schemaGenerator) extractPointedType(typ codegen.Type) (*codegen.NamedTy
 }
 
 func (g *schemaGenerator) detectCycle(t *schemas.Type) (bool, func(), error) {
-	defName, filename, err := g.extractRefNames(t)
+	pointer, filename, err := g.extractRefNames(t)
 	if err != nil {
 		return false, func() {}, err
 	}
 
+	defName := schemas.FormatJSONPointer(pointer)
+
 	if defName == "" && filename == "" && !t.Dereferenced {
 		return false, func() {}, nil
 	}
@@ -0,0 +1,169 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/atombender/go-jsonschema/pkg/codegen"
+)
+
+// tupleType describes a type generated from prefixItems, or from an array in items: a struct
+// with one field per position, and a trailing slice for the items following them.
+type tupleType struct {
+	items []tupleItem
+	// rest is the type of the items following the positional ones, or nil if there may be none.
+	rest     codegen.Type
+	minItems int
+	maxItems int
+}
+
+type tupleItem struct {
+	fieldName string
+	// optional is set for the positions past minItems, which are held in pointers.
+	optional bool
+}
+
+// maxLength returns the largest number of items the tuple holds, or 0 if it is unbounded.
+func (t *tupleType) maxLength() int {
+	switch {
+	case t.rest == nil && (t.maxItems == 0 || t.maxItems > len(t.items)):
+		return len(t.items)
+
+	default:
+		return t.maxItems
+	}
+}
+
+// generateTupleItems emits the collection of the tuple's fields into an "items" slice, stopping
+// at the first absent optional position. Setting a field after an absent one is an error.
+func generateTupleItems(out *codegen.Emitter, declType *codegen.TypeDecl, tuple *tupleType) {
+	out.Printf("items := []interface{}{")
+
+	for i, item := range tuple.items {
+		if item.optional {
+			break
+		}
+
+		if i > 0 {
+			out.Printf(", ")
+		}
+
+		out.Printf("j.%s", item.fieldName)
+	}
+
+	out.Printlnf("}")
+
+	for i, item := range tuple.items {
+		if !item.optional {
+			continue
+		}
+
+		out.Printlnf("if j.%s != nil {", item.fieldName)
+		out.Indent(1)
+
+		if i > 0 && tuple.items[i-1].optional {
+			out.Printlnf("if len(items) != %d {", i)
+			out.Indent(1)
+			out.Printlnf(`return nil, fmt.Errorf("%s: %s cannot be set without %s")`,
+				declType.Name, item.fieldName, tuple.items[i-1].fieldName)
+			out.Indent(-1)
+			out.Printlnf("}")
+		}
+
+		out.Printlnf("items = append(items, j.%s)", item.fieldName)
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+
+	if tuple.rest == nil {
+		return
+	}
+
+	if len(tuple.items) > 0 && tuple.items[len(tuple.items)-1].optional {
+		out.Printlnf("if len(j.Rest) > 0 && len(items) != %d {", len(tuple.items))
+		out.Indent(1)
+		out.Printlnf(`return nil, fmt.Errorf("%s: Rest cannot be set without %s")`,
+			declType.Name, tuple.items[len(tuple.items)-1].fieldName)
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+
+	out.Printlnf("for _, item := range j.Rest {")
+	out.Indent(1)
+	out.Printlnf("items = append(items, item)")
+	out.Indent(-1)
+	out.Printlnf("}")
+}
+
+// generateTupleDecode emits the decoding of an "items" slice into the tuple's fields. The decode
+// callback returns the expression decoding the given item into the given variable.
+func generateTupleDecode(
+	out *codegen.Emitter,
+	declType *codegen.TypeDecl,
+	tuple *tupleType,
+	decode func(item, varName string) string,
+) error {
+	if tuple.minItems > 0 {
+		out.Printlnf("if len(items) < %d {", tuple.minItems)
+		out.Indent(1)
+		out.Printlnf(`return fmt.Errorf("%s: expected at least %d items, got %%d", len(items))`,
+			declType.Name, tuple.minItems)
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+
+	if maxLength := tuple.maxLength(); maxLength > 0 {
+		out.Printlnf("if len(items) > %d {", maxLength)
+		out.Indent(1)
+		out.Printlnf(`return fmt.Errorf("%s: expected at most %d items, got %%d", len(items))`,
+			declType.Name, maxLength)
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+
+	out.Printlnf("var v %s", declType.Name)
+
+	for i, item := range tuple.items {
+		field := "v." + item.fieldName
+
+		if item.optional {
+			out.Printlnf("if len(items) > %d {", i)
+			out.Indent(1)
+		}
+
+		out.Printlnf("if err := %s; err != nil {", decode(fmt.Sprintf("items[%d]", i), field))
+		out.Indent(1)
+		out.Printlnf(`return fmt.Errorf("%s: item %d: %%w", err)`, declType.Name, i)
+		out.Indent(-1)
+		out.Printlnf("}")
+
+		if item.optional {
+			out.Indent(-1)
+			out.Printlnf("}")
+		}
+	}
+
+	if tuple.rest != nil {
+		out.Printlnf("for i := %d; i < len(items); i++ {", len(tuple.items))
+		out.Indent(1)
+		out.Printf("var item ")
+
+		if err := tuple.rest.Generate(out); err != nil {
+			return fmt.Errorf("cannot generate tuple rest: %w", err)
+		}
+
+		out.Newline()
+		out.Printlnf("if err := %s; err != nil {", decode("items[i]", "item"))
+		out.Indent(1)
+		out.Printlnf(`return fmt.Errorf("%s: item %%d: %%w", i, err)`, declType.Name)
+		out.Indent(-1)
+		out.Printlnf("}")
+		out.Printlnf("v.Rest = append(v.Rest, item)")
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+
+	out.Printlnf("*j = v")
+	out.Printlnf("return nil")
+
+	return nil
+}
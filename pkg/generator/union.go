@@ -0,0 +1,352 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/atombender/go-jsonschema/pkg/codegen"
+	"github.com/atombender/go-jsonschema/pkg/schemas"
+)
+
+// unionType describes a type generated from a oneOf: a wrapper struct holding
+// a value of a sealed interface that is implemented by every variant.
+type unionType struct {
+	interfaceDecl *codegen.TypeDecl
+	markerMethod  string
+	variants      []unionVariant
+	nullable      bool
+	// discriminator is the name of the property whose value selects the variant, if any.
+	discriminator string
+}
+
+type unionVariant struct {
+	typ *codegen.NamedType
+	// tags are the discriminator values selecting this variant; the first one is written on marshal.
+	tags []string
+	// schemaTypes are the JSON types the variant's schema accepts, if it declares any.
+	schemaTypes schemas.TypeList
+}
+
+func (u *unionType) addVariant(nt *codegen.NamedType, tags []string) {
+	for i, v := range u.variants {
+		if v.typ.Decl == nt.Decl {
+			u.variants[i].tags = append(u.variants[i].tags, tags...)
+
+			return
+		}
+	}
+
+	v := unionVariant{typ: nt, tags: tags}
+	if nt.Decl.SchemaType != nil {
+		v.schemaTypes = nt.Decl.SchemaType.Type
+	}
+
+	u.variants = append(u.variants, v)
+}
+
+func isNullSchemaType(t *schemas.Type) bool {
+	return t.Ref == "" && len(t.Type) == 1 && t.Type[0] == schemas.TypeNameNull
+}
+
+// constTag returns the string a property is pinned to by a const or a single-valued enum.
+func constTag(t *schemas.Type, propertyName string) (string, bool) {
+	prop, ok := t.Properties[propertyName]
+	if !ok {
+		return "", false
+	}
+
+	if s, ok := prop.Const.(string); ok {
+		return s, true
+	}
+
+	if len(prop.Enum) == 1 {
+		if s, ok := prop.Enum[0].(string); ok {
+			return s, true
+		}
+	}
+
+	return "", false
+}
+
+// declaredDiscriminatorTags computes the tags of every branch for an OpenAPI discriminator. Explicit
+// mappings come first, then a const on the property and finally the name of the referenced schema.
+func declaredDiscriminatorTags(d *schemas.Discriminator, branches, resolved []*schemas.Type) [][]string {
+	tags := make([][]string, len(branches))
+
+	for _, value := range sortedKeys(d.Mapping) {
+		ref := d.Mapping[value]
+
+		for i, b := range branches {
+			if b.Ref != "" && (b.Ref == ref || path.Base(b.Ref) == path.Base(ref)) {
+				tags[i] = append(tags[i], value)
+			}
+		}
+	}
+
+	for i, b := range branches {
+		if len(tags[i]) > 0 || isNullSchemaType(resolved[i]) {
+			continue
+		}
+
+		if tag, ok := constTag(resolved[i], d.PropertyName); ok {
+			tags[i] = []string{tag}
+		} else if b.Ref != "" {
+			tags[i] = []string{path.Base(b.Ref)}
+		}
+	}
+
+	return tags
+}
+
+// inferredDiscriminatorTags computes the tags of every branch for a property that every branch
+// requires and pins to a string const. It returns nil if any branch does not.
+func inferredDiscriminatorTags(propertyName string, resolved []*schemas.Type) [][]string {
+	tags := make([][]string, len(resolved))
+
+	for i, r := range resolved {
+		if isNullSchemaType(r) {
+			continue
+		}
+
+		tag, ok := constTag(r, propertyName)
+		if !ok || !slices.Contains(r.Required, propertyName) {
+			return nil
+		}
+
+		tags[i] = []string{tag}
+	}
+
+	return tags
+}
+
+// inferredDiscriminator finds a property that every resolved branch requires and pins to a distinct
+// string const, and returns it with the tags of every branch.
+func inferredDiscriminator(resolved []*schemas.Type) (string, [][]string) {
+	for _, r := range resolved {
+		if isNullSchemaType(r) {
+			continue
+		}
+
+		for _, name := range sortedKeys(r.Properties) {
+			if tags := inferredDiscriminatorTags(name, resolved); validDiscriminatorTags(tags, resolved) {
+				return name, tags
+			}
+		}
+
+		break
+	}
+
+	return "", nil
+}
+
+// validDiscriminatorTags checks that every non-null branch has at least one tag,
+// and that no tag selects more than one branch.
+func validDiscriminatorTags(tags [][]string, resolved []*schemas.Type) bool {
+	if tags == nil {
+		return false
+	}
+
+	seen := map[string]int{}
+
+	for i, r := range resolved {
+		if isNullSchemaType(r) {
+			continue
+		}
+
+		if len(tags[i]) == 0 {
+			return false
+		}
+
+		for _, tag := range tags[i] {
+			if j, ok := seen[tag]; ok && j != i {
+				return false
+			}
+
+			seen[tag] = i
+		}
+	}
+
+	return len(seen) > 0
+}
+
+// generateUnionNullCheck emits the handling of a null input, which only succeeds
+// when one of the oneOf branches has type "null".
+func generateUnionNullCheck(out *codegen.Emitter, declType *codegen.TypeDecl, union *unionType) {
+	out.Printlnf("if %s == nil {", varNameRawMap)
+	out.Indent(1)
+
+	if union.nullable {
+		out.Printlnf("j.Value = nil")
+		out.Printlnf("return nil")
+	} else {
+		out.Printlnf(`return fmt.Errorf("%s: value cannot be null")`, declType.Name)
+	}
+
+	out.Indent(-1)
+	out.Printlnf("}")
+}
+
+// generateUnionDecode emits the decoding of the raw input into the variant it holds. The decode
+// callback returns the expression decoding the input into the given variable. The optional rejects
+// callback returns a condition ruling a variant out without trying it, or "" if there is none.
+func generateUnionDecode(
+	out *codegen.Emitter,
+	declType *codegen.TypeDecl,
+	union *unionType,
+	decode func(varName string) string,
+	rejects func(v unionVariant) string,
+) error {
+	if union.discriminator != "" {
+		return generateUnionDispatch(out, declType, union, decode)
+	}
+
+	return generateUnionMatch(out, declType, union, decode, rejects)
+}
+
+// generateUnionDispatch emits a switch on the discriminator property read from the raw map,
+// decoding the input only into the variant it selects.
+func generateUnionDispatch(
+	out *codegen.Emitter,
+	declType *codegen.TypeDecl,
+	union *unionType,
+	decode func(varName string) string,
+) error {
+	out.Printlnf("obj, ok := %s.(map[string]interface{})", varNameRawMap)
+	out.Printlnf("if !ok {")
+	out.Indent(1)
+	out.Printlnf(`return fmt.Errorf("%s: expected an object with discriminator %s")`, declType.Name, union.discriminator)
+	out.Indent(-1)
+	out.Printlnf("}")
+	out.Printlnf("switch obj[%q] {", union.discriminator)
+
+	for _, v := range union.variants {
+		cases := make([]string, 0, len(v.tags))
+		for _, tag := range v.tags {
+			cases = append(cases, fmt.Sprintf("%q", tag))
+		}
+
+		out.Printlnf("case %s:", strings.Join(cases, ", "))
+		out.Indent(1)
+		out.Printf("var v ")
+
+		if err := v.typ.Generate(out); err != nil {
+			return fmt.Errorf("cannot generate union variant: %w", err)
+		}
+
+		out.Newline()
+		out.Printlnf("if err := %s; err != nil { return err }", decode("v"))
+		out.Printlnf("j.Value = v")
+		out.Indent(-1)
+	}
+
+	out.Printlnf("default:")
+	out.Indent(1)
+	out.Printlnf(`return fmt.Errorf("%s: unexpected value %%#v for discriminator %s", obj[%q])`,
+		declType.Name, union.discriminator, union.discriminator)
+	out.Indent(-1)
+	out.Printlnf("}")
+	out.Printlnf("return nil")
+
+	return nil
+}
+
+// generateUnionMatch emits the trial decoding of every variant, requiring exactly one to succeed.
+func generateUnionMatch(
+	out *codegen.Emitter,
+	declType *codegen.TypeDecl,
+	union *unionType,
+	decode func(varName string) string,
+	rejects func(v unionVariant) string,
+) error {
+	out.Printlnf("var matches []%s", union.interfaceDecl.Name)
+	out.Printlnf("var errs []error")
+
+	for i, v := range union.variants {
+		varName := fmt.Sprintf("v%d", i)
+
+		out.Printf("var %s ", varName)
+
+		if err := v.typ.Generate(out); err != nil {
+			return fmt.Errorf("cannot generate union variant: %w", err)
+		}
+
+		out.Newline()
+
+		if cond := rejectsCondition(rejects, v); cond != "" {
+			out.Printlnf("if %s {", cond)
+			out.Indent(1)
+			out.Printlnf(`errs = append(errs, fmt.Errorf("%s: input is not of type %s"))`,
+				v.typ.Decl.Name, strings.Join(v.schemaTypes, " or "))
+			out.Indent(-1)
+			out.Printf("} else ")
+		}
+
+		out.Printlnf("if err := %s; err != nil {", decode(varName))
+		out.Indent(1)
+		out.Printlnf("errs = append(errs, err)")
+		out.Indent(-1)
+		out.Printlnf("} else {")
+		out.Indent(1)
+		out.Printlnf("matches = append(matches, %s)", varName)
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+
+	out.Printlnf("switch len(matches) {")
+	out.Printlnf("case 0:")
+	out.Indent(1)
+	out.Printlnf(`return fmt.Errorf("%s: no oneOf variant matched: %%w", errors.Join(errs...))`, declType.Name)
+	out.Indent(-1)
+	out.Printlnf("case 1:")
+	out.Indent(1)
+	out.Printlnf("j.Value = matches[0]")
+	out.Printlnf("return nil")
+	out.Indent(-1)
+	out.Printlnf("default:")
+	out.Indent(1)
+	out.Printlnf(`return fmt.Errorf("%s: value matches %%d oneOf variants, expected exactly one", len(matches))`,
+		declType.Name)
+	out.Indent(-1)
+	out.Printlnf("}")
+
+	return nil
+}
+
+// rejectsCondition returns the condition ruling a variant out, or "" if there is no rejects callback.
+func rejectsCondition(rejects func(v unionVariant) string, v unionVariant) string {
+	if rejects == nil {
+		return ""
+	}
+
+	return rejects(v)
+}
+
+// generateUnionTagSwitch emits a type switch assigning the discriminator value of the held
+// variant to a "tag" variable. A nil value falls through to the given default statement.
+func generateUnionTagSwitch(out *codegen.Emitter, union *unionType, defaultStmt string) error {
+	out.Printlnf("var tag string")
+	out.Printlnf("switch j.Value.(type) {")
+
+	for _, v := range union.variants {
+		out.Printf("case ")
+
+		if err := v.typ.Generate(out); err != nil {
+			return fmt.Errorf("cannot generate union variant: %w", err)
+		}
+
+		out.Printlnf(":")
+		out.Indent(1)
+		out.Printlnf("tag = %q", v.tags[0])
+		out.Indent(-1)
+	}
+
+	out.Printlnf("default:")
+	out.Indent(1)
+	out.Printlnf("%s", defaultStmt)
+	out.Indent(-1)
+	out.Printlnf("}")
+
+	return nil
+}
@@ -3,6 +3,7 @@ package generator
 import (
 	"errors"
 	"fmt"
+	"math"
 	"reflect"
 	"strings"
 
@@ -48,6 +49,7 @@ var (
 	_ validator = new(stringValidator)
 	_ validator = new(numericValidator)
 	_ validator = new(anyOfValidator)
+	_ validator = new(conditionalValidator)
 
 	ErrCannotDumpDefaultSlice = errors.New("cannot dump default slice")
 )
@@ -731,6 +733,135 @@ func (v *anyOfValidator) desc() *validatorDesc {
 	}
 }
 
+// conditionalConstraints is the subset of an if/then/else subschema that can be
+// checked against the raw map: required properties and allowed property values.
+type conditionalConstraints struct {
+	required []string
+	values   map[string][]any
+}
+
+type conditionalValidator struct {
+	declName string
+	ifCond   conditionalConstraints
+	thenCond *conditionalConstraints
+	elseCond *conditionalConstraints
+}
+
+func (v *conditionalValidator) generate(out *codegen.Emitter, format string) error {
+	out.Printlnf(`if %s != nil {`, varNameRawMap)
+	out.Indent(1)
+	out.Printlnf(`ifMatches := true`)
+
+	for _, name := range v.ifCond.required {
+		out.Printlnf(`if _, ok := %s["%s"]; !ok {`, varNameRawMap, name)
+		out.Indent(1)
+		out.Printlnf(`ifMatches = false`)
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+
+	for _, name := range sortedKeys(v.ifCond.values) {
+		out.Printlnf(`if v, ok := %s["%s"]; ok && !slices.Contains(%s, v) {`,
+			varNameRawMap, name, conditionalValueList(v.ifCond.values[name]))
+		out.Indent(1)
+		out.Printlnf(`ifMatches = false`)
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+
+	if v.thenCond != nil {
+		out.Printlnf(`if ifMatches {`)
+		out.Indent(1)
+		v.generateAssertions(out, v.thenCond, "then")
+		out.Indent(-1)
+
+		if v.elseCond != nil {
+			out.Printlnf("} else {")
+			out.Indent(1)
+			v.generateAssertions(out, v.elseCond, "else")
+			out.Indent(-1)
+		}
+	} else {
+		out.Printlnf(`if !ifMatches {`)
+		out.Indent(1)
+		v.generateAssertions(out, v.elseCond, "else")
+		out.Indent(-1)
+	}
+
+	out.Printlnf("}")
+	out.Indent(-1)
+	out.Printlnf("}")
+
+	return nil
+}
+
+func (v *conditionalValidator) generateAssertions(out *codegen.Emitter, c *conditionalConstraints, branch string) {
+	for _, name := range c.required {
+		out.Printlnf(`if _, ok := %s["%s"]; !ok {`, varNameRawMap, name)
+		out.Indent(1)
+		out.Printlnf(`return fmt.Errorf("field %s in %s: required by %s")`, name, v.declName, branch)
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+
+	for _, name := range sortedKeys(c.values) {
+		values := conditionalValueList(c.values[name])
+
+		out.Printlnf(`if v, ok := %s["%s"]; ok && !slices.Contains(%s, v) {`, varNameRawMap, name, values)
+		out.Indent(1)
+		out.Printlnf(`return fmt.Errorf("field %s in %s: invalid value (%s expects one of %%#v): %%#v", %s, v)`,
+			name, v.declName, branch, values)
+		out.Indent(-1)
+		out.Printlnf("}")
+	}
+}
+
+func (v *conditionalValidator) desc() *validatorDesc {
+	return &validatorDesc{
+		hasError:            true,
+		beforeJSONUnmarshal: true,
+		imports:             []packageImport{{qualifiedName: "slices"}},
+	}
+}
+
+// conditionalValueList renders values as a []interface{} literal that can be compared
+// against decoded values. Integers are listed both as float64, which encoding/json
+// produces, and as int, which gopkg.in/yaml.v3 produces, or as uint64 beyond the
+// range of an int64.
+func conditionalValueList(values []any) string {
+	items := make([]string, 0, len(values))
+
+	for _, value := range values {
+		switch x := value.(type) {
+		case nil:
+			items = append(items, "nil")
+
+		case string:
+			items = append(items, fmt.Sprintf("%q", x))
+
+		case float64:
+			switch {
+			case x != math.Trunc(x):
+				items = append(items, fmt.Sprintf("%v", x))
+
+			case x >= math.MinInt64 && x < math.MaxInt64:
+				items = append(items, fmt.Sprintf("float64(%d)", int64(x)), fmt.Sprintf("%d", int64(x)))
+
+			case x >= 0 && x < math.MaxUint64:
+				items = append(items, fmt.Sprintf("float64(%d)", uint64(x)), fmt.Sprintf("uint64(%d)", uint64(x)))
+
+			default:
+				items = append(items, fmt.Sprintf("%v", x))
+			}
+
+		default:
+			items = append(items, fmt.Sprintf("%v", x))
+		}
+	}
+
+	return fmt.Sprintf("[]interface{}{%s}", strings.Join(items, ", "))
+}
+
 func lowerFirst(s string) string {
 	return strings.ToLower(s[:1]) + s[1:]
 }
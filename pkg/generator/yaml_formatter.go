@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/atombender/go-jsonschema/pkg/codegen"
+	"github.com/atombender/go-jsonschema/pkg/schemas"
 )
 
 const (
@@ -164,6 +165,160 @@ func (yf *yamlFormatter) enumUnmarshal(
 	}
 }
 
+func (yf *yamlFormatter) unionMarshal(
+	declType *codegen.TypeDecl,
+	union *unionType,
+) func(*codegen.Emitter) error {
+	return func(out *codegen.Emitter) error {
+		out.Commentf("Marshal%s implements %s.Marshaler.", strings.ToUpper(formatYAML), formatYAML)
+		out.Printlnf("func (j %s) Marshal%s() (interface{}, error) {", declType.Name, strings.ToUpper(formatYAML))
+		out.Indent(1)
+
+		if union.discriminator != "" {
+			if err := generateUnionTagSwitch(out, union, "return j.Value, nil"); err != nil {
+				return err
+			}
+
+			// The nodes of the variant are kept as they are, after the discriminator, so that
+			// neither their order nor the precision of their values changes.
+			out.Printlnf("var node %s.Node", formatYAML)
+			out.Printlnf("if err := node.Encode(j.Value); err != nil { return nil, err }")
+			out.Printlnf("content := []*%s.Node{", formatYAML)
+			out.Indent(1)
+			out.Printlnf(`{Kind: %s.ScalarNode, Tag: "!!str", Value: %q},`, formatYAML, union.discriminator)
+			out.Printlnf(`{Kind: %s.ScalarNode, Tag: "!!str", Value: tag},`, formatYAML)
+			out.Indent(-1)
+			out.Printlnf("}")
+			out.Printlnf("for i := 0; i+1 < len(node.Content); i += 2 {")
+			out.Indent(1)
+			out.Printlnf("if node.Content[i].Value != %q {", union.discriminator)
+			out.Indent(1)
+			out.Printlnf("content = append(content, node.Content[i], node.Content[i+1])")
+			out.Indent(-1)
+			out.Printlnf("}")
+			out.Indent(-1)
+			out.Printlnf("}")
+			out.Printlnf("node.Content = content")
+			out.Printlnf("return &node, nil")
+		} else {
+			out.Printlnf("return j.Value, nil")
+		}
+
+		out.Indent(-1)
+		out.Printlnf("}")
+
+		return nil
+	}
+}
+
+func (yf *yamlFormatter) unionUnmarshal(
+	declType *codegen.TypeDecl,
+	union *unionType,
+) func(*codegen.Emitter) error {
+	return func(out *codegen.Emitter) error {
+		out.Commentf("Unmarshal%s implements %s.Unmarshaler.", strings.ToUpper(formatYAML), formatYAML)
+		out.Printlnf("func (j *%s) Unmarshal%s(value *yaml.Node) error {", declType.Name, strings.ToUpper(formatYAML))
+		out.Indent(1)
+		out.Printlnf("var %s interface{}", varNameRawMap)
+		out.Printlnf("if err := value.Decode(&%s); err != nil { return err }", varNameRawMap)
+
+		generateUnionNullCheck(out, declType, union)
+
+		err := generateUnionDecode(out, declType, union, func(varName string) string {
+			return fmt.Sprintf("value.Decode(&%s)", varName)
+		}, func(v unionVariant) string {
+			return yamlNodeMismatch(v.schemaTypes)
+		})
+		if err != nil {
+			return err
+		}
+
+		out.Indent(-1)
+		out.Printlnf("}")
+
+		return nil
+	}
+}
+
+// yamlNodeMismatch returns a condition on the "value" node that holds if it is of none of the
+// given JSON types. Decoding alone is not strict enough to tell variants apart, since yaml.v3
+// decodes any scalar, such as an integer, into a string.
+func yamlNodeMismatch(types schemas.TypeList) string {
+	conds := make([]string, 0, len(types))
+
+	for _, t := range types {
+		switch t {
+		case schemas.TypeNameString:
+			conds = append(conds, `value.ShortTag() != "!!str"`)
+
+		case schemas.TypeNameInteger:
+			conds = append(conds, `value.ShortTag() != "!!int"`)
+
+		case schemas.TypeNameNumber:
+			conds = append(conds, `value.ShortTag() != "!!int"`, `value.ShortTag() != "!!float"`)
+
+		case schemas.TypeNameBoolean:
+			conds = append(conds, `value.ShortTag() != "!!bool"`)
+
+		case schemas.TypeNameObject:
+			conds = append(conds, "value.Kind != yaml.MappingNode")
+
+		case schemas.TypeNameArray:
+			conds = append(conds, "value.Kind != yaml.SequenceNode")
+
+		default:
+			// Any other type, such as null, leaves it to decoding to tell.
+			return ""
+		}
+	}
+
+	return strings.Join(conds, " && ")
+}
+
+func (yf *yamlFormatter) tupleMarshal(
+	declType *codegen.TypeDecl,
+	tuple *tupleType,
+) func(*codegen.Emitter) error {
+	return func(out *codegen.Emitter) error {
+		out.Commentf("Marshal%s implements %s.Marshaler.", strings.ToUpper(formatYAML), formatYAML)
+		out.Printlnf("func (j %s) Marshal%s() (interface{}, error) {", declType.Name, strings.ToUpper(formatYAML))
+		out.Indent(1)
+
+		generateTupleItems(out, declType, tuple)
+
+		out.Printlnf("return items, nil")
+		out.Indent(-1)
+		out.Printlnf("}")
+
+		return nil
+	}
+}
+
+func (yf *yamlFormatter) tupleUnmarshal(
+	declType *codegen.TypeDecl,
+	tuple *tupleType,
+) func(*codegen.Emitter) error {
+	return func(out *codegen.Emitter) error {
+		out.Commentf("Unmarshal%s implements %s.Unmarshaler.", strings.ToUpper(formatYAML), formatYAML)
+		out.Printlnf("func (j *%s) Unmarshal%s(value *yaml.Node) error {", declType.Name, strings.ToUpper(formatYAML))
+		out.Indent(1)
+		out.Printlnf("var items []yaml.Node")
+		out.Printlnf("if err := value.Decode(&items); err != nil { return err }")
+
+		err := generateTupleDecode(out, declType, tuple, func(item, varName string) string {
+			return fmt.Sprintf("%s.Decode(&%s)", item, varName)
+		})
+		if err != nil {
+			return err
+		}
+
+		out.Indent(-1)
+		out.Printlnf("}")
+
+		return nil
+	}
+}
+
 func (yf *yamlFormatter) addImport(out *codegen.File, declType *codegen.TypeDecl) {
 	out.Package.AddImport(YAMLPackage, "yaml")
 
@@ -0,0 +1,341 @@
+package schemas
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+var ErrUnresolvedReference = errors.New("reference does not resolve")
+
+type BundleOptions struct {
+	// Loader loads the referenced schemas. It defaults to the loader used by the generator.
+	Loader Loader
+	// ResolveExtensions are tried in turn on file names that do not exist as they are.
+	ResolveExtensions []string
+	// YAMLExtensions are the file extensions of YAML schemas, for the default loader.
+	YAMLExtensions []string
+	// JSONCExtensions are the file extensions of JSONC or JSON5 schemas, for the default loader.
+	JSONCExtensions []string
+	// YAML makes Bundle write YAML rather than JSON.
+	YAML bool
+}
+
+// Bundle reads a schema and every schema it references from other files or URLs, and writes a
+// single self-contained schema document. Each referenced schema is copied into $defs, under the
+// name of its definition or of its file, made unique, and references to it are rewritten to
+// point there. The document is written with the keywords of the 2020-12 dialect, whatever the
+// dialects of the schemas it gathers.
+func Bundle(fileName string, opts BundleOptions) ([]byte, error) {
+	loader := opts.Loader
+	if loader == nil {
+		loader = NewDefaultCacheLoader(opts.ResolveExtensions, opts.YAMLExtensions, WithJSONCExtensions(opts.JSONCExtensions...))
+	}
+
+	if qualified, err := LoaderQualifiedFileName(loader, fileName, "", opts.ResolveExtensions); err == nil {
+		fileName = qualified
+	}
+
+	registry := NewRegistry()
+	b := &bundler{
+		loader:   NewRegistryLoader(loader, registry, opts.ResolveExtensions),
+		registry: registry,
+		names:    map[*Type]string{},
+	}
+
+	root, err := b.bundle(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal bundle: %w", err)
+	}
+
+	if opts.YAML {
+		if data, err = yaml.JSONToYAML(data); err != nil {
+			return nil, fmt.Errorf("failed to convert bundle to YAML: %w", err)
+		}
+
+		return data, nil
+	}
+
+	return append(data, '\n'), nil
+}
+
+type bundler struct {
+	loader   Loader
+	registry *Registry
+	root     *Schema
+	defs     Definitions
+	// rootPaths holds the JSON Pointer tokens of the subschemas of the schema, in the bundle.
+	rootPaths map[*Type][]string
+	// names holds the definitions that schemas of other documents were copied to.
+	names map[*Type]string
+}
+
+// bundledSchema is a schema copied into the bundle, whose references remain to be rewritten.
+type bundledSchema struct {
+	document *Schema
+	pairs    [][2]*Type
+}
+
+func (b *bundler) bundle(fileName string) (*Type, error) {
+	schema, err := b.loader.Load(fileName, "")
+	if err != nil {
+		return nil, err
+	}
+
+	b.root = schema
+	b.rootPaths = map[*Type][]string{}
+
+	schema.walkSubschemas(func(tokens []string, t *Type) {
+		b.rootPaths[t] = tokens
+	})
+
+	var pairs [][2]*Type
+
+	record := func(orig, cp *Type) {
+		pairs = append(pairs, [2]*Type{orig, cp})
+	}
+
+	root := &Type{}
+	if schema.ObjectAsType != nil {
+		root = (*Type)(schema.ObjectAsType).bundleCopy(record, false)
+	}
+
+	root.ID = schema.ID
+	root.Version = DialectDraft2020.MetaSchemaURI()
+
+	b.defs = Definitions{}
+	for _, name := range sortedDefinitionNames(schema.Definitions) {
+		b.defs[name] = schema.Definitions[name].bundleCopy(record, false)
+	}
+
+	queue := []bundledSchema{{document: schema, pairs: pairs}}
+
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+
+		for _, pair := range next.pairs {
+			if pair[0].Ref == "" {
+				continue
+			}
+
+			copied, err := b.rewriteRef(next.document, pair[0], pair[1])
+			if err != nil {
+				return nil, err
+			}
+
+			if copied != nil {
+				queue = append(queue, *copied)
+			}
+		}
+	}
+
+	if len(b.defs) > 0 {
+		root.Definitions = b.defs
+	}
+
+	return root, nil
+}
+
+// rewriteRef points the copy of a subschema holding a reference to where the referenced schema is
+// found in the bundle. A schema not found in the bundle yet is copied to it and returned.
+func (b *bundler) rewriteRef(document *Schema, orig, cp *Type) (*bundledSchema, error) {
+	res, err := b.resolve(document, orig)
+	if err != nil {
+		return nil, ErrorAt(orig, err)
+	}
+
+	if res.Document == b.root {
+		// References within the schema are written as is if they are relative to another $id.
+		sameBase := b.registry.baseURIs[orig] == b.registry.baseURIs[(*Type)(b.root.ObjectAsType)]
+		if document == b.root && !sameBase {
+			return nil, nil //nolint:nilnil // Nothing to copy.
+		}
+
+		target, err := b.root.ResolveJSONPointer(res.Pointer)
+		if err != nil {
+			return nil, ErrorAt(orig, fmt.Errorf("%w: %q: %w", ErrUnresolvedReference, orig.Ref, err))
+		}
+
+		// The pointer may use keywords of older dialects, unlike the bundle.
+		cp.Ref = "#" + FormatJSONPointer(b.rootPaths[target])
+
+		return nil, nil //nolint:nilnil // Nothing to copy.
+	}
+
+	target, err := res.Document.ResolveJSONPointer(res.Pointer)
+	if err != nil {
+		return nil, ErrorAt(orig, fmt.Errorf("%w: %q: %w", ErrUnresolvedReference, orig.Ref, err))
+	}
+
+	if name, ok := b.names[target]; ok {
+		cp.Ref = "#" + FormatJSONPointer([]string{"$defs", name})
+
+		return nil, nil //nolint:nilnil // Already copied.
+	}
+
+	name := b.uniqueName(bundleDefinitionName(res))
+	b.names[target] = name
+	cp.Ref = "#" + FormatJSONPointer([]string{"$defs", name})
+
+	copied := &bundledSchema{document: res.Document}
+	b.defs[name] = target.bundleCopy(func(orig, cp *Type) {
+		copied.pairs = append(copied.pairs, [2]*Type{orig, cp})
+	}, true)
+
+	return copied, nil
+}
+
+// resolve finds the schema a reference made from a document points to, loading the document it is
+// in if needed.
+func (b *bundler) resolve(from *Schema, t *Type) (*Resource, error) {
+	if res, ok := b.registry.Resolve(t.Ref, t); ok {
+		return res, nil
+	}
+
+	parent, _ := b.registry.Location(from)
+
+	document, fragment, err := b.registry.loadRef(b.loader, t.Ref, t, parent)
+	if err != nil {
+		return nil, fmt.Errorf("could not follow $ref %q: %w", t.Ref, err)
+	}
+
+	if res, ok := b.registry.Resolve(t.Ref, t); ok {
+		return res, nil
+	}
+
+	// The document may identify itself by an $id other than the location it was loaded from.
+	unescaped, err := url.PathUnescape(fragment)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", ErrUnresolvedReference, t.Ref, err)
+	}
+
+	tokens, err := ParseJSONPointer(unescaped)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %w", ErrUnresolvedReference, t.Ref, err)
+	}
+
+	location, _ := b.registry.Location(document)
+
+	return &Resource{Document: document, Location: location, Pointer: document.ModelTokens(tokens)}, nil
+}
+
+// uniqueName returns the given definition name, or the first one followed by a number
+// that no other definition of the bundle has.
+func (b *bundler) uniqueName(name string) string {
+	if _, ok := b.defs[name]; !ok {
+		return name
+	}
+
+	for i := 2; ; i++ {
+		if _, ok := b.defs[name+strconv.Itoa(i)]; !ok {
+			return name + strconv.Itoa(i)
+		}
+	}
+}
+
+// bundleDefinitionName names a referenced schema after its definition, the last token of its
+// pointer, or the file it is in.
+func bundleDefinitionName(res *Resource) string {
+	for i := len(res.Pointer) - 1; i >= 0; i-- {
+		if _, err := strconv.Atoi(res.Pointer[i]); err != nil {
+			return res.Pointer[i]
+		}
+	}
+
+	base := path.Base(filepath.ToSlash(stripFragment(res.Location)))
+
+	return strings.TrimSuffix(base, path.Ext(base))
+}
+
+// bundleCopy copies the type and its subschemas, calling record for every subschema and its copy.
+// The copy is expressed with the keywords of the 2020-12 dialect. Copies of schemas from other
+// documents drop their identifiers, as every reference to them is rewritten.
+func (value *Type) bundleCopy(record func(orig, cp *Type), foreign bool) *Type {
+	cp := *value
+	record(value, &cp)
+
+	cp.Version = ""
+	// Tuples are described by prefixItems, followed by items.
+	cp.AdditionalItems = nil
+
+	if foreign {
+		cp.ID = ""
+		cp.Anchor = ""
+	}
+
+	cp.Maximum, cp.ExclusiveMaximum = exclusiveBound(value.Maximum, value.ExclusiveMaximum)
+	cp.Minimum, cp.ExclusiveMinimum = exclusiveBound(value.Minimum, value.ExclusiveMinimum)
+
+	val := reflect.ValueOf(&cp).Elem()
+
+	for i := range val.NumField() {
+		field := val.Field(i)
+		if !val.Type().Field(i).IsExported() || !isSubschemaKind(field.Type()) || field.IsNil() {
+			continue
+		}
+
+		switch field.Kind() { //nolint:exhaustive
+		case reflect.Pointer:
+			field.Set(reflect.ValueOf(field.Interface().(*Type).bundleCopy(record, foreign)))
+
+		case reflect.Slice:
+			items := reflect.MakeSlice(field.Type(), field.Len(), field.Len())
+
+			for j := range field.Len() {
+				if t, _ := field.Index(j).Interface().(*Type); t != nil {
+					items.Index(j).Set(reflect.ValueOf(t.bundleCopy(record, foreign)))
+				}
+			}
+
+			field.Set(items)
+
+		case reflect.Map:
+			entries := reflect.MakeMapWithSize(field.Type(), field.Len())
+
+			for _, k := range field.MapKeys() {
+				if t, _ := field.MapIndex(k).Interface().(*Type); t != nil {
+					entries.SetMapIndex(k, reflect.ValueOf(t.bundleCopy(record, foreign)))
+				}
+			}
+
+			field.Set(entries)
+		}
+	}
+
+	return &cp
+}
+
+// exclusiveBound turns a bound made exclusive the draft-04 way, by a boolean, into the numeric
+// exclusive bound of later drafts.
+func exclusiveBound(bound *float64, exclusive *any) (*float64, *any) {
+	if exclusive == nil {
+		return bound, nil
+	}
+
+	b, ok := (*exclusive).(bool)
+	if !ok {
+		return bound, exclusive
+	}
+
+	if !b || bound == nil {
+		return bound, nil
+	}
+
+	var limit any = *bound
+
+	return nil, &limit
+}
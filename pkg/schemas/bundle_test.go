@@ -0,0 +1,178 @@
+package schemas_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/atombender/go-jsonschema/pkg/schemas"
+)
+
+func TestBundle(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writeFiles(t, dir, map[string]string{
+		"root.json": `{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"type": "object",
+			"properties": {
+				"home": {"$ref": "./common.json#/definitions/Address"},
+				"work": {"$ref": "common.json#/definitions/Address"},
+				"pet": {"$ref": "pets/pet.yaml"},
+				"local": {"$ref": "#/definitions/Address"}
+			},
+			"definitions": {
+				"Address": {"type": "string"}
+			}
+		}`,
+		"common.json": `{
+			"$schema": "http://json-schema.org/draft-04/schema#",
+			"definitions": {
+				"Address": {
+					"type": "object",
+					"properties": {
+						"number": {"type": "integer", "minimum": 0, "exclusiveMinimum": true},
+						"country": {"$ref": "#/definitions/Country"}
+					}
+				},
+				"Country": {"type": "string"}
+			}
+		}`,
+		"pets/pet.yaml": `
+type: object
+properties:
+  parent:
+    $ref: '#'
+  tags:
+    type: array
+    items:
+      - type: string
+    additionalItems: false
+`,
+	})
+
+	got, err := schemas.Bundle(filepath.Join(dir, "root.json"), schemas.BundleOptions{
+		YAMLExtensions: []string{".yaml"},
+	})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"home": {"$ref": "#/$defs/Address2"},
+			"work": {"$ref": "#/$defs/Address2"},
+			"pet": {"$ref": "#/$defs/pet"},
+			"local": {"$ref": "#/$defs/Address"}
+		},
+		"$defs": {
+			"Address": {"type": "string"},
+			"Address2": {
+				"type": "object",
+				"properties": {
+					"number": {"type": "integer", "exclusiveMinimum": 0},
+					"country": {"$ref": "#/$defs/Country"}
+				}
+			},
+			"Country": {"type": "string"},
+			"pet": {
+				"type": "object",
+				"properties": {
+					"parent": {"$ref": "#/$defs/pet"},
+					"tags": {
+						"type": "array",
+						"prefixItems": [{"type": "string"}],
+						"items": {"not": {}}
+					}
+				}
+			}
+		}
+	}`, string(got))
+}
+
+func TestBundleYAML(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writeFiles(t, dir, map[string]string{
+		"root.json":   `{"properties": {"a": {"$ref": "a.json"}}}`,
+		"a.json":      `{"type": "string"}`,
+		"broken.json": `{"properties": {"a": {"$ref": "a.json#/$defs/missing"}}}`,
+	})
+
+	got, err := schemas.Bundle(filepath.Join(dir, "root.json"), schemas.BundleOptions{YAML: true})
+	require.NoError(t, err)
+
+	assert.Equal(t, `$schema: https://json-schema.org/draft/2020-12/schema
+properties:
+  a:
+    $ref: "#/$defs/a"
+type: object
+$defs:
+  a:
+    type: string
+`, string(got))
+
+	_, err = schemas.Bundle(filepath.Join(dir, "broken.json"), schemas.BundleOptions{})
+	require.ErrorIs(t, err, schemas.ErrUnresolvedReference)
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		fileName := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(fileName), 0o755))
+		require.NoError(t, os.WriteFile(fileName, []byte(content), 0o600))
+	}
+}
+
+func TestBundleRemoteBaseURI(t *testing.T) {
+	t.Parallel()
+
+	var srv *httptest.Server
+
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		documents := map[string]string{
+			"/a/b.json":      `{"properties": {"c": {"$ref": "common.json"}, "d": {"$ref": "d.json#/$defs/d"}}}`,
+			"/a/common.json": `{"type": "string"}`,
+			"/a/d.json":      `{"$id": "` + srv.URL + `/shared/d.json", "$defs": {"d": {"$ref": "e.json"}}}`,
+			"/shared/e.json": `{"type": "integer"}`,
+		}
+
+		document, ok := documents[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(document))
+	}))
+	t.Cleanup(srv.Close)
+
+	got, err := schemas.Bundle(srv.URL+"/a/b.json", schemas.BundleOptions{})
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"properties": {
+			"c": {"$ref": "#/$defs/common"},
+			"d": {"$ref": "#/$defs/d"}
+		},
+		"$defs": {
+			"common": {"type": "string"},
+			"d": {"$ref": "#/$defs/e"},
+			"e": {"type": "integer"}
+		}
+	}`, string(got))
+}
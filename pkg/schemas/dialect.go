@@ -0,0 +1,292 @@
+package schemas
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrUnknownDialect  = errors.New("unknown dialect")
+	ErrInvalidKeyword  = errors.New("invalid keyword for dialect")
+	errTupleItemsArray = errors.New("items must be a schema, use prefixItems for tuples")
+)
+
+// Dialect identifies the version of the JSON Schema specification a schema is written against.
+// It decides how keywords whose meaning changed between drafts are interpreted.
+type Dialect string
+
+const (
+	// DialectUnknown is used when a schema declares no known dialect. Keywords of all
+	// drafts are then accepted, preferring the newest meaning when they conflict.
+	DialectUnknown   Dialect = ""
+	DialectDraft04   Dialect = "draft-04"
+	DialectDraft06   Dialect = "draft-06"
+	DialectDraft07   Dialect = "draft-07"
+	DialectDraft2019 Dialect = "2019-09"
+	DialectDraft2020 Dialect = "2020-12"
+)
+
+// Dialects lists the known dialects, oldest first.
+var Dialects = []Dialect{
+	DialectDraft04,
+	DialectDraft06,
+	DialectDraft07,
+	DialectDraft2019,
+	DialectDraft2020,
+}
+
+var dialectMetaSchemas = map[Dialect]string{
+	DialectDraft04:   "http://json-schema.org/draft-04/schema",
+	DialectDraft06:   "http://json-schema.org/draft-06/schema",
+	DialectDraft07:   "http://json-schema.org/draft-07/schema",
+	DialectDraft2019: "https://json-schema.org/draft/2019-09/schema",
+	DialectDraft2020: "https://json-schema.org/draft/2020-12/schema",
+}
+
+// ParseDialect accepts a dialect name such as "draft-07" or "2020-12", or the URI of its meta-schema.
+func ParseDialect(name string) (Dialect, error) {
+	if name == "" {
+		return DialectUnknown, nil
+	}
+
+	if d := DialectFromURI(name); d != DialectUnknown {
+		return d, nil
+	}
+
+	// Accept "draft-07", "draft7" and "7" alike.
+	version := func(s string) string {
+		return strings.TrimLeft(strings.TrimPrefix(strings.ToLower(s), "draft"), "-0")
+	}
+
+	for _, d := range Dialects {
+		if version(string(d)) == version(name) {
+			return d, nil
+		}
+	}
+
+	return DialectUnknown, fmt.Errorf("%w: %q", ErrUnknownDialect, name)
+}
+
+// DialectFromURI returns the dialect identified by a $schema value, or DialectUnknown
+// if it is not the URI of one of the standard meta-schemas.
+func DialectFromURI(uri string) Dialect {
+	uri = strings.TrimSuffix(strings.TrimSpace(uri), "#")
+	uri = strings.TrimPrefix(strings.TrimPrefix(uri, "https://"), "http://")
+
+	for d, metaSchema := range dialectMetaSchemas {
+		if uri == strings.TrimPrefix(strings.TrimPrefix(metaSchema, "https://"), "http://") {
+			return d
+		}
+	}
+
+	return DialectUnknown
+}
+
+// MetaSchemaURI returns the URI of the dialect's meta-schema.
+func (d Dialect) MetaSchemaURI() string {
+	return dialectMetaSchemas[d]
+}
+
+func (d Dialect) String() string {
+	if d == DialectUnknown {
+		return "unknown"
+	}
+
+	return string(d)
+}
+
+// arrayItems reports whether an array of schemas in items describes a tuple.
+func (d Dialect) arrayItems() bool {
+	return d != DialectDraft2020
+}
+
+// prefixItems reports whether tuples are described by prefixItems, and additionalItems is gone.
+func (d Dialect) prefixItems() bool {
+	return d == DialectDraft2020 || d == DialectUnknown
+}
+
+// dependencies reports whether dependencies holds both dependentRequired and dependentSchemas.
+func (d Dialect) dependencies() bool {
+	return d == DialectDraft04 || d == DialectDraft06 || d == DialectDraft07 || d == DialectUnknown
+}
+
+// dependentKeywords reports whether dependencies is split into dependentRequired and dependentSchemas.
+func (d Dialect) dependentKeywords() bool {
+	return d == DialectDraft2019 || d == DialectDraft2020 || d == DialectUnknown
+}
+
+func (d Dialect) recursiveRef() bool {
+	return d == DialectDraft2019 || d == DialectUnknown
+}
+
+func (d Dialect) dynamicRef() bool {
+	return d == DialectDraft2020 || d == DialectUnknown
+}
+
+// dialectKeywords holds the keywords whose meaning depends on the dialect,
+// until the dialect of the schema they appear in is known.
+type dialectKeywords struct {
+	tupleItems        []*Type
+	dependentRequired map[string][]string
+	dependentSchemas  map[string]*Type
+	recursiveRef      string
+}
+
+func newDialectKeywords(items json.RawMessage, dependencies map[string]json.RawMessage) (*dialectKeywords, error) {
+	kw := &dialectKeywords{}
+
+	if len(items) > 0 && items[0] == '[' {
+		if err := json.Unmarshal(items, &kw.tupleItems); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal type: %w", err)
+		}
+	}
+
+	for name, dependency := range dependencies {
+		if len(dependency) > 0 && dependency[0] == '[' {
+			var required []string
+			if err := json.Unmarshal(dependency, &required); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal type: %w", err)
+			}
+
+			if kw.dependentRequired == nil {
+				kw.dependentRequired = map[string][]string{}
+			}
+
+			kw.dependentRequired[name] = required
+
+			continue
+		}
+
+		var schema *Type
+		if err := json.Unmarshal(dependency, &schema); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal type: %w", err)
+		}
+
+		if kw.dependentSchemas == nil {
+			kw.dependentSchemas = map[string]*Type{}
+		}
+
+		kw.dependentSchemas[name] = schema
+	}
+
+	return kw, nil
+}
+
+// Dialect returns the dialect the schema is interpreted with.
+func (s *Schema) Dialect() Dialect {
+	if s.ObjectAsType != nil {
+		if d := DialectFromURI(s.Version); d != DialectUnknown {
+			return d
+		}
+	}
+
+	return s.defaultDialect
+}
+
+// applyDialect interprets the dialect dependent keywords of every subschema. A subschema that
+// declares its own $schema switches the dialect for itself and everything nested below it.
+func (s *Schema) applyDialect() error {
+	dialects := map[string]Dialect{"": s.Dialect()}
+
+	var err error
+
+	s.walkSubschemas(func(tokens []string, t *Type) {
+		d := dialects[""]
+
+		for i := len(tokens) - 1; i >= 0; i-- {
+			if parent, ok := dialects[FormatJSONPointer(tokens[:i])]; ok {
+				d = parent
+
+				break
+			}
+		}
+
+		if declared := DialectFromURI(t.Version); declared != DialectUnknown && len(tokens) > 0 {
+			d = declared
+			dialects[FormatJSONPointer(tokens)] = d
+		}
+
+		if aerr := t.applyDialect(d); aerr != nil && err == nil {
+			err = &PositionError{
+				Position: Position{Pointer: FormatJSONPointer(tokens)},
+				Err:      fmt.Errorf("%w: %w", ErrInvalidKeyword, aerr),
+			}
+		}
+	})
+
+	return err
+}
+
+func (value *Type) applyDialect(d Dialect) error {
+	kw := value.dialectKeywords
+	if kw == nil {
+		kw = &dialectKeywords{}
+	}
+
+	value.dialectKeywords = nil
+
+	if !d.prefixItems() {
+		value.PrefixItems = nil
+	}
+
+	if kw.tupleItems != nil {
+		if !d.arrayItems() {
+			return errTupleItemsArray
+		}
+
+		if value.PrefixItems == nil {
+			value.PrefixItems = kw.tupleItems
+			value.Items = value.AdditionalItems
+		}
+	}
+
+	if d == DialectDraft2020 {
+		value.AdditionalItems = nil
+	}
+
+	if !d.dependentKeywords() {
+		value.DependentRequired = nil
+		value.DependentSchemas = nil
+	}
+
+	if d.dependencies() {
+		for name, required := range kw.dependentRequired {
+			if _, ok := value.DependentRequired[name]; !ok {
+				if value.DependentRequired == nil {
+					value.DependentRequired = map[string][]string{}
+				}
+
+				value.DependentRequired[name] = required
+			}
+		}
+
+		for name, schema := range kw.dependentSchemas {
+			if _, ok := value.DependentSchemas[name]; !ok {
+				if value.DependentSchemas == nil {
+					value.DependentSchemas = map[string]*Type{}
+				}
+
+				value.DependentSchemas[name] = schema
+			}
+		}
+	}
+
+	if !d.dynamicRef() {
+		value.DynamicRef = ""
+		value.DynamicAnchor = ""
+	}
+
+	if d.recursiveRef() && value.DynamicRef == "" {
+		value.DynamicRef = kw.recursiveRef
+	}
+
+	// Generated code cannot extend the dynamic scope, so a dynamic reference
+	// is followed to where it initially resolves, the same as $ref.
+	if value.Ref == "" {
+		value.Ref = value.DynamicRef
+	}
+
+	return nil
+}
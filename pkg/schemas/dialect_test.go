@@ -0,0 +1,142 @@
+package schemas_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/atombender/go-jsonschema/pkg/schemas"
+)
+
+func TestParseDialect(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		want    schemas.Dialect
+		wantErr error
+	}{
+		{name: "", want: schemas.DialectUnknown},
+		{name: "draft-04", want: schemas.DialectDraft04},
+		{name: "draft7", want: schemas.DialectDraft07},
+		{name: "6", want: schemas.DialectDraft06},
+		{name: "2019-09", want: schemas.DialectDraft2019},
+		{name: "https://json-schema.org/draft/2020-12/schema", want: schemas.DialectDraft2020},
+		{name: "http://json-schema.org/draft-07/schema#", want: schemas.DialectDraft07},
+		{name: "draft-05", wantErr: schemas.ErrUnknownDialect},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := schemas.ParseDialect(tC.name)
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tC.want, got)
+		})
+	}
+}
+
+func TestDialectKeywords(t *testing.T) {
+	t.Parallel()
+
+	const tupleAndDependencies = `{
+		%s
+		"properties": {
+			"pair": {
+				"items": [{"type": "string"}, {"type": "integer"}],
+				"additionalItems": {"type": "boolean"}
+			},
+			"list": {
+				"prefixItems": [{"type": "string"}],
+				"items": {"type": "integer"}
+			}
+		},
+		"dependencies": {
+			"a": ["b"],
+			"c": {"required": ["d"]}
+		},
+		"dependentRequired": {"e": ["f"]}
+	}`
+
+	parse := func(t *testing.T, schema string, dialect schemas.Dialect) (*schemas.Schema, error) {
+		t.Helper()
+
+		return schemas.FromJSONReaderWithDialect(strings.NewReader(schema), dialect)
+	}
+
+	t.Run("draft-07 tuples and dependencies", func(t *testing.T) {
+		t.Parallel()
+
+		schema, err := parse(t, strings.Replace(tupleAndDependencies, "%s", "", 1), schemas.DialectDraft07)
+		require.NoError(t, err)
+
+		pair := schema.Properties["pair"]
+		require.Len(t, pair.PrefixItems, 2)
+		assert.Equal(t, schemas.TypeList{"boolean"}, pair.Items.Type)
+
+		list := schema.Properties["list"]
+		assert.Nil(t, list.PrefixItems)
+		assert.Equal(t, schemas.TypeList{"integer"}, list.Items.Type)
+
+		assert.Equal(t, map[string][]string{"a": {"b"}}, schema.DependentRequired)
+		assert.Equal(t, []string{"d"}, schema.DependentSchemas["c"].Required)
+	})
+
+	t.Run("2020-12 declared by $schema", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parse(t, strings.Replace(tupleAndDependencies, "%s",
+			`"$schema": "https://json-schema.org/draft/2020-12/schema",`, 1), schemas.DialectDraft07)
+		require.ErrorIs(t, err, schemas.ErrInvalidKeyword)
+	})
+
+	t.Run("2019-09 ignores dependencies", func(t *testing.T) {
+		t.Parallel()
+
+		schema, err := parse(t, strings.Replace(tupleAndDependencies, "%s", "", 1), schemas.DialectDraft2019)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string][]string{"e": {"f"}}, schema.DependentRequired)
+		assert.Nil(t, schema.DependentSchemas)
+	})
+
+	t.Run("unknown dialect accepts everything", func(t *testing.T) {
+		t.Parallel()
+
+		schema, err := parse(t, strings.Replace(tupleAndDependencies, "%s", "", 1), schemas.DialectUnknown)
+		require.NoError(t, err)
+
+		assert.Len(t, schema.Properties["pair"].PrefixItems, 2)
+		assert.Len(t, schema.Properties["list"].PrefixItems, 1)
+		assert.Equal(t, map[string][]string{"a": {"b"}, "e": {"f"}}, schema.DependentRequired)
+	})
+
+	t.Run("recursive and dynamic references", func(t *testing.T) {
+		t.Parallel()
+
+		schema, err := parse(t, `{
+			"$schema": "https://json-schema.org/draft/2019-09/schema",
+			"properties": {
+				"recursive": {"$recursiveRef": "#"},
+				"dynamic": {"$dynamicRef": "#node"},
+				"nested": {
+					"$schema": "https://json-schema.org/draft/2020-12/schema",
+					"items": {"$dynamicRef": "#node"}
+				}
+			}
+		}`, schemas.DialectUnknown)
+		require.NoError(t, err)
+
+		assert.Equal(t, "#", schema.Properties["recursive"].Ref)
+		assert.Empty(t, schema.Properties["dynamic"].Ref)
+		assert.Equal(t, "#node", schema.Properties["nested"].Items.Ref)
+	})
+}
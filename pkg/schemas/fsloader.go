@@ -0,0 +1,155 @@
+package schemas
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// NewFSLoader returns a loader reading schema files from the given file system, such as an
+// embed.FS. References are resolved relative to the referencing file, as by FileLoader.
+func NewFSLoader(fsys fs.FS, resolveExtensions, yamlExtensions []string, opts ...LoaderOption) *FSLoader {
+	o := newLoaderOptions(opts)
+
+	return &FSLoader{
+		fsys:              fsys,
+		resolveExtensions: resolveExtensions,
+		yamlExtensions:    toExtensionSet(yamlExtensions),
+		jsoncExtensions:   toExtensionSet(o.jsoncExtensions),
+		defaultDialect:    o.defaultDialect,
+		policy:            o.policy,
+	}
+}
+
+// FSLoader loads schemas from an fs.FS. The qualified name of a file is its slash-separated
+// path in the file system, rooted at "/", which keeps it apart from the files of the OS.
+type FSLoader struct {
+	fsys              fs.FS
+	resolveExtensions []string
+	yamlExtensions    map[string]bool
+	jsoncExtensions   map[string]bool
+	defaultDialect    Dialect
+	policy            Policy
+}
+
+func (l *FSLoader) Load(fileName, parentFileName string) (*Schema, error) {
+	qualified, err := l.QualifiedFileName(fileName, parentFileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if info, err := fs.Stat(l.fsys, fsPath(qualified)); err == nil {
+		if err := l.policy.checkSize(qualified, info.Size()); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := fs.ReadFile(l.fsys, fsPath(qualified))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	format := extensionFormat(path.Ext(qualified), l.yamlExtensions, l.jsoncExtensions)
+
+	return parseSchemaFile(bytes.NewReader(data), qualified, format, l.defaultDialect)
+}
+
+func (l *FSLoader) QualifiedFileName(fileName, parentFileName string) (string, error) {
+	r, err := schemeRefType(fileName)
+	if err != nil {
+		return "", err
+	}
+
+	if r != RefTypeFile {
+		return "", fmt.Errorf("%w: %q", ErrUnsupportedURL, fileName)
+	}
+
+	fileName = slashPath(strings.TrimPrefix(fileName, "file://"))
+
+	if !path.IsAbs(fileName) {
+		fileName = path.Join(path.Dir(slashPath(parentFileName)), fileName)
+	}
+
+	fileName = path.Clean("/" + fileName)
+
+	for _, ext := range append([]string{""}, l.resolveExtensions...) {
+		qualified := fileName + ext
+
+		if info, err := fs.Stat(l.fsys, fsPath(qualified)); err == nil && !info.IsDir() {
+			return qualified, nil
+		}
+	}
+
+	return "", fmt.Errorf("%w %q", ErrCannotResolveSchema, fileName)
+}
+
+// slashPath turns a file name into a slash-separated path, dropping any volume name, as file
+// names handed out by the registry are absolute names of the OS.
+func slashPath(fileName string) string {
+	return filepath.ToSlash(strings.TrimPrefix(fileName, filepath.VolumeName(fileName)))
+}
+
+// fsPath turns a qualified name into a path valid in an fs.FS.
+func fsPath(qualified string) string {
+	if p := strings.TrimPrefix(qualified, "/"); p != "" {
+		return p
+	}
+
+	return "."
+}
+
+// NewMapLoader returns a loader reading schema files from memory, keyed by their slash-separated
+// paths. It behaves as an FSLoader over a file system holding just these files.
+func NewMapLoader(files map[string][]byte, resolveExtensions, yamlExtensions []string, opts ...LoaderOption) *MapLoader {
+	fsys := make(mapFS, len(files))
+	for name, data := range files {
+		fsys[fsPath(path.Clean("/"+slashPath(name)))] = data
+	}
+
+	return &MapLoader{FSLoader: NewFSLoader(fsys, resolveExtensions, yamlExtensions, opts...)}
+}
+
+type MapLoader struct {
+	*FSLoader
+}
+
+// mapFS is a read-only file system of regular files held in memory.
+type mapFS map[string][]byte
+
+func (m mapFS) Open(name string) (fs.File, error) {
+	data, ok := m[name]
+	if !ok || !fs.ValidPath(name) {
+		return nil, &fs.PathError{Op: "open", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return &mapFile{Reader: bytes.NewReader(data), info: mapFileInfo{name: path.Base(name), size: len(data)}}, nil
+}
+
+type mapFile struct {
+	*bytes.Reader
+	info mapFileInfo
+}
+
+func (f *mapFile) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+func (f *mapFile) Close() error {
+	return nil
+}
+
+type mapFileInfo struct {
+	name string
+	size int
+}
+
+func (i mapFileInfo) Name() string       { return i.name }
+func (i mapFileInfo) Size() int64        { return int64(i.size) }
+func (i mapFileInfo) Mode() fs.FileMode  { return 0o444 }
+func (i mapFileInfo) ModTime() time.Time { return time.Time{} }
+func (i mapFileInfo) IsDir() bool        { return false }
+func (i mapFileInfo) Sys() any           { return nil }
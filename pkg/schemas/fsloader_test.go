@@ -0,0 +1,125 @@
+package schemas_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/atombender/go-jsonschema/pkg/schemas"
+)
+
+func TestFSLoader(t *testing.T) {
+	t.Parallel()
+
+	fsys := fstest.MapFS{
+		"schemas/root.json":        {Data: []byte(`{"properties": {"a": {"$ref": "defs/a"}}}`)},
+		"schemas/defs/a.yaml":      {Data: []byte("type: string\n")},
+		"schemas/defs/broken.json": {Data: []byte(`{"type": }`)},
+	}
+
+	testCases := []struct {
+		desc       string
+		fileName   string
+		parent     string
+		want       string
+		wantSource string
+		wantErr    error
+	}{
+		{
+			desc:     "relative to the root",
+			fileName: "schemas/root.json",
+			want:     "/schemas/root.json",
+		},
+		{
+			desc:     "relative to the parent, with a resolved extension",
+			fileName: "defs/a",
+			parent:   "/schemas/root.json",
+			want:     "/schemas/defs/a.yaml",
+		},
+		{
+			desc:     "absolute",
+			fileName: "file:///schemas/defs/a.yaml",
+			parent:   "/schemas/root.json",
+			want:     "/schemas/defs/a.yaml",
+		},
+		{
+			desc:     "parent directory",
+			fileName: "../root.json",
+			parent:   "/schemas/defs/a.yaml",
+			want:     "/schemas/root.json",
+		},
+		{
+			desc:     "missing",
+			fileName: "missing.json",
+			parent:   "/schemas/root.json",
+			wantErr:  schemas.ErrCannotResolveSchema,
+		},
+		{
+			desc:     "directory",
+			fileName: "defs",
+			parent:   "/schemas/root.json",
+			wantErr:  schemas.ErrCannotResolveSchema,
+		},
+		{
+			desc:     "URL",
+			fileName: "https://example.com/a.json",
+			wantErr:  schemas.ErrUnsupportedURL,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			loader := schemas.NewFSLoader(fsys, []string{".json", ".yaml"}, []string{".yaml"})
+
+			qualified, err := loader.QualifiedFileName(tC.fileName, tC.parent)
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tC.want, qualified)
+
+			schema, err := loader.Load(tC.fileName, tC.parent)
+			require.NoError(t, err)
+			assert.Equal(t, tC.want, (*schemas.Type)(schema.ObjectAsType).Position().File)
+		})
+	}
+
+	_, err := schemas.NewFSLoader(fsys, nil, nil).Load("schemas/defs/broken.json", "")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "/schemas/defs/broken.json:1:")
+}
+
+func TestMapLoader(t *testing.T) {
+	t.Parallel()
+
+	loader := schemas.NewMapLoader(map[string][]byte{
+		"/root.json":     []byte(`{"properties": {"a": {"$ref": "a.yml#/$defs/A"}}}`),
+		"./common/a.yml": []byte("$defs:\n  A:\n    type: integer\n"),
+		"a.yml":          []byte("$defs:\n  A:\n    type: string\n"),
+	}, nil, []string{"yml"})
+
+	root, err := loader.Load("root.json", "")
+	require.NoError(t, err)
+	assert.Contains(t, root.Properties, "a")
+
+	a, err := loader.Load("a.yml", "/root.json")
+	require.NoError(t, err)
+	assert.Equal(t, schemas.TypeList{"string"}, a.Definitions["A"].Type)
+
+	a, err = loader.Load("../a.yml", "common/a.yml")
+	require.NoError(t, err)
+	assert.Equal(t, schemas.TypeList{"string"}, a.Definitions["A"].Type)
+
+	a, err = loader.Load("a.yml", "common/b.json")
+	require.NoError(t, err)
+	assert.Equal(t, schemas.TypeList{"integer"}, a.Definitions["A"].Type)
+
+	_, err = loader.Load("b.yml", "")
+	require.ErrorIs(t, err, schemas.ErrCannotResolveSchema)
+}
@@ -0,0 +1,405 @@
+package schemas
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// httpRetryDelay is the delay before the first retry of a request, doubled for every further retry.
+const httpRetryDelay = 100 * time.Millisecond
+
+// WithHTTPClient makes HTTP loaders perform their requests with the given client.
+func WithHTTPClient(client *http.Client) LoaderOption {
+	return func(o *loaderOptions) {
+		o.http.Client = client
+	}
+}
+
+// WithHTTPTimeout bounds the time each attempt at an HTTP request may take.
+func WithHTTPTimeout(timeout time.Duration) LoaderOption {
+	return func(o *loaderOptions) {
+		o.http.Timeout = timeout
+	}
+}
+
+// WithHTTPRetries makes HTTP loaders retry requests failing with a network error, a 429 or a 5xx
+// status up to the given number of times.
+func WithHTTPRetries(retries int) LoaderOption {
+	return func(o *loaderOptions) {
+		o.http.Retries = retries
+	}
+}
+
+// WithHTTPHeader adds a header to the requests made to the given host, with or without its port.
+func WithHTTPHeader(host, name, value string) LoaderOption {
+	return func(o *loaderOptions) {
+		if o.http.Headers == nil {
+			o.http.Headers = map[string]http.Header{}
+		}
+
+		if o.http.Headers[host] == nil {
+			o.http.Headers[host] = http.Header{}
+		}
+
+		o.http.Headers[host].Add(name, value)
+	}
+}
+
+// WithBearerToken authenticates the requests made to the given host with a bearer token.
+func WithBearerToken(host, token string) LoaderOption {
+	return WithHTTPHeader(host, "Authorization", "Bearer "+token)
+}
+
+// WithHTTPCache makes HTTP loaders keep the schemas they retrieve in the given directory, and
+// revalidate them with their ETag or Last-Modified date instead of retrieving them again.
+func WithHTTPCache(dir string) LoaderOption {
+	return func(o *loaderOptions) {
+		o.http.CacheDir = dir
+	}
+}
+
+// WithOffline makes HTTP loaders serve schemas from their cache only, without making any request.
+func WithOffline(offline bool) LoaderOption {
+	return func(o *loaderOptions) {
+		o.http.Offline = offline
+	}
+}
+
+// withFetched makes HTTP loaders call fn with the body of every document they retrieve.
+func withFetched(fn func(uri string, body []byte)) LoaderOption {
+	return func(o *loaderOptions) {
+		o.http.fetched = fn
+	}
+}
+
+func NewHTTPLoader(yamlExtensions []string, opts ...LoaderOption) *HTTPLoader {
+	o := newLoaderOptions(opts)
+
+	l := o.http
+	l.YAMLExtensions = toExtensionSet(yamlExtensions)
+	l.JSONCExtensions = toExtensionSet(o.jsoncExtensions)
+	l.DefaultDialect = o.defaultDialect
+
+	return &l
+}
+
+type HTTPLoader struct {
+	YAMLExtensions map[string]bool
+	// JSONCExtensions are the extensions of the URLs of JSONC or JSON5 schemas.
+	JSONCExtensions map[string]bool
+	DefaultDialect  Dialect
+	// Client performs the requests, or http.DefaultClient if nil.
+	Client *http.Client
+	// Timeout bounds each attempt at a request, if positive.
+	Timeout time.Duration
+	// Retries is the number of times a request failing with a network error, a 429 or a 5xx status is retried.
+	Retries int
+	// Headers are added to requests by host, given with or without its port.
+	Headers map[string]http.Header
+	// CacheDir is the directory responses are cached in, if not empty.
+	CacheDir string
+	// Offline makes the loader serve responses from CacheDir only.
+	Offline bool
+	// Policy restricts the hosts schemas are retrieved from, and their size.
+	Policy Policy
+
+	// fetched is called with the body of every document retrieved, if set.
+	fetched func(uri string, body []byte)
+}
+
+func (l *HTTPLoader) Load(uri, parentURI string) (*Schema, error) {
+	u, err := url.Parse(refURI(uri, parentURI))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse url: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedURL, uri)
+	}
+
+	u.Fragment = ""
+
+	if err := l.Policy.checkURL(u); err != nil {
+		return nil, err
+	}
+
+	resp, err := l.fetch(u)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := l.Policy.checkSize(uri, int64(len(resp.Body))); err != nil {
+		return nil, err
+	}
+
+	if l.fetched != nil {
+		l.fetched(u.String(), []byte(resp.Body))
+	}
+
+	schema, err := FromReaderWithDialect(strings.NewReader(resp.Body), l.format(resp.ContentType, u), l.DefaultDialect)
+	if err != nil {
+		setErrorSource(err, uri)
+
+		return nil, err
+	}
+
+	schema.setSource(uri)
+
+	return schema, nil
+}
+
+// format tells the format of a response by its media type, or by the extension of its URL if the
+// media type is not specific to JSON, JSONC or YAML. As JSONC is a superset of JSON, JSON served
+// from a URL with the extension of JSONC is read as JSONC. The format of other responses is unknown,
+// to be told from their content.
+func (l *HTTPLoader) format(contentType string, u *url.URL) Format {
+	ext := path.Ext(u.Path)
+
+	if mediaType, _, err := mime.ParseMediaType(contentType); err == nil {
+		switch {
+		case jsoncMediaTypes[mediaType] || strings.HasSuffix(mediaType, "+json5"):
+			return FormatJSONC
+
+		case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+			if l.JSONCExtensions[ext] {
+				return FormatJSONC
+			}
+
+			return FormatJSON
+
+		case yamlMediaTypes[mediaType] || strings.HasSuffix(mediaType, "+yaml"):
+			return FormatYAML
+		}
+	}
+
+	return extensionFormat(ext, l.YAMLExtensions, l.JSONCExtensions)
+}
+
+var jsoncMediaTypes = map[string]bool{
+	"application/json5": true,
+	"application/jsonc": true,
+}
+
+var yamlMediaTypes = map[string]bool{
+	"application/yaml":   true,
+	"application/x-yaml": true,
+	"text/yaml":          true,
+	"text/x-yaml":        true,
+}
+
+// httpResponse is a response to a request for a schema, as kept in the cache.
+type httpResponse struct {
+	URL          string `json:"url"`
+	ContentType  string `json:"contentType,omitempty"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Body         string `json:"body"`
+}
+
+// errRetry marks the failures of requests worth retrying.
+var errRetry = errors.New("retrying")
+
+func (l *HTTPLoader) fetch(u *url.URL) (*httpResponse, error) {
+	cached, err := l.readCache(u.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if l.Offline {
+		if cached == nil {
+			return nil, fmt.Errorf("%w: %s", ErrNotCached, u)
+		}
+
+		return cached, nil
+	}
+
+	delay := httpRetryDelay
+
+	for attempt := 0; ; attempt++ {
+		resp, err := l.get(u, cached)
+		if err == nil {
+			if resp != cached {
+				if err := l.writeCache(resp); err != nil {
+					return nil, err
+				}
+			}
+
+			return resp, nil
+		}
+
+		if !errors.Is(err, errRetry) || attempt >= l.Retries {
+			return nil, err
+		}
+
+		time.Sleep(delay)
+
+		delay *= 2
+	}
+}
+
+// get performs a single request, made conditional on the cached response if there is one.
+func (l *HTTPLoader) get(u *url.URL, cached *httpResponse) (*httpResponse, error) {
+	ctx := context.Background()
+
+	if l.Timeout > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, l.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/schema+json, application/json, application/yaml;q=0.9, */*;q=0.8")
+
+	for _, host := range []string{u.Hostname(), u.Host} {
+		for name, values := range l.Headers[host] {
+			req.Header[name] = values
+		}
+	}
+
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	client := l.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	if len(l.Policy.AllowedHosts) > 0 {
+		client = l.Policy.redirectChecking(client)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to perform request: %w: %w", errRetry, err)
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && cached != nil:
+		return cached, nil
+
+	case resp.StatusCode == http.StatusOK:
+		var body []byte
+
+		if limit := l.Policy.MaxDocumentSize; limit > 0 {
+			// Read no more than needed to tell the document is too large.
+			body, err = io.ReadAll(io.LimitReader(resp.Body, limit+1))
+		} else {
+			body, err = io.ReadAll(resp.Body)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w: %w", errRetry, err)
+		}
+
+		return &httpResponse{
+			URL:          u.String(),
+			ContentType:  resp.Header.Get("Content-Type"),
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Body:         string(body),
+		}, nil
+
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError:
+		return nil, fmt.Errorf("%w %s from %s: %w", ErrUnexpectedHTTPStatus, resp.Status, u, errRetry)
+
+	default:
+		return nil, fmt.Errorf("%w %s from %s", ErrUnexpectedHTTPStatus, resp.Status, u)
+	}
+}
+
+// cacheFileName returns the name of the file a response for the URL is cached in.
+func (l *HTTPLoader) cacheFileName(uri string) string {
+	sum := sha256.Sum256([]byte(uri))
+
+	return filepath.Join(l.CacheDir, hex.EncodeToString(sum[:])+".json")
+}
+
+func (l *HTTPLoader) readCache(uri string) (*httpResponse, error) {
+	if l.CacheDir == "" {
+		return nil, nil //nolint:nilnil // Nothing is cached.
+	}
+
+	data, err := os.ReadFile(l.cacheFileName(uri))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil //nolint:nilnil // Not cached yet.
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read HTTP cache: %w", err)
+	}
+
+	var resp httpResponse
+	if err := json.Unmarshal(data, &resp); err != nil || resp.URL != uri {
+		// A corrupt entry is replaced by the next response.
+		return nil, nil //nolint:nilerr,nilnil // Not cached.
+	}
+
+	return &resp, nil
+}
+
+func (l *HTTPLoader) writeCache(resp *httpResponse) error {
+	if l.CacheDir == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("failed to marshal HTTP cache entry: %w", err)
+	}
+
+	if err := os.MkdirAll(l.CacheDir, 0o755); err != nil {
+		return fmt.Errorf("failed to create HTTP cache: %w", err)
+	}
+
+	// Written aside and renamed, so concurrent runs never read a partial entry.
+	f, err := os.CreateTemp(l.CacheDir, "*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write HTTP cache: %w", err)
+	}
+
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	if err == nil {
+		err = os.Rename(f.Name(), l.cacheFileName(resp.URL))
+	}
+
+	if err != nil {
+		_ = os.Remove(f.Name())
+
+		return fmt.Errorf("failed to write HTTP cache: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package schemas_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/atombender/go-jsonschema/pkg/schemas"
+)
+
+func TestHTTPLoaderContentType(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", r.URL.Query().Get("type"))
+
+		if r.URL.Query().Get("yaml") != "" {
+			_, _ = w.Write([]byte("type: string\n"))
+
+			return
+		}
+
+		if r.URL.Query().Get("jsonc") != "" {
+			_, _ = w.Write([]byte(`{"type": 'string', /* trailing comma */}`))
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"type": "string"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	testCases := []struct {
+		desc        string
+		path        string
+		contentType string
+		yaml        bool
+		jsonc       bool
+	}{
+		{desc: "JSON with parameters", path: "/a.yaml", contentType: "application/json; charset=utf-8"},
+		{desc: "JSON suffix", path: "/a.yaml", contentType: "application/schema+json"},
+		{desc: "YAML with parameters", path: "/a.json", contentType: "application/yaml; charset=utf-8", yaml: true},
+		{desc: "YAML suffix", path: "/a.json", contentType: "application/openapi+yaml", yaml: true},
+		{desc: "YAML by extension", path: "/a.yaml", contentType: "text/plain; charset=utf-8", yaml: true},
+		{desc: "JSON5", path: "/a.json", contentType: "application/json5", jsonc: true},
+		{desc: "JSONC by extension", path: "/a.jsonc", contentType: "application/json", jsonc: true},
+		{desc: "JSON by default", path: "/a", contentType: ""},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			query := url.Values{"type": {tC.contentType}}
+			if tC.yaml {
+				query.Set("yaml", "1")
+			}
+
+			if tC.jsonc {
+				query.Set("jsonc", "1")
+			}
+
+			loader := schemas.NewHTTPLoader([]string{".yaml"}, schemas.WithJSONCExtensions(".jsonc"))
+
+			schema, err := loader.Load(server.URL+tC.path+"?"+query.Encode(), "")
+			require.NoError(t, err)
+			assert.Equal(t, schemas.TypeList{"string"}, schema.Type)
+		})
+	}
+}
+
+func TestHTTPLoaderHeaders(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" || r.Header.Get("X-Api-Key") != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"type": "string"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	_, err = schemas.NewHTTPLoader(nil).Load(server.URL+"/a.json", "")
+	require.ErrorIs(t, err, schemas.ErrUnexpectedHTTPStatus)
+
+	_, err = schemas.NewHTTPLoader(nil,
+		schemas.WithBearerToken("example.com", "secret"),
+		schemas.WithHTTPHeader(u.Host, "X-Api-Key", "key"),
+	).Load(server.URL+"/a.json", "")
+	require.ErrorIs(t, err, schemas.ErrUnexpectedHTTPStatus)
+
+	_, err = schemas.NewHTTPLoader(nil,
+		schemas.WithBearerToken(u.Hostname(), "secret"),
+		schemas.WithHTTPHeader(u.Host, "X-Api-Key", "key"),
+	).Load(server.URL+"/a.json", "")
+	require.NoError(t, err)
+}
+
+func TestHTTPLoaderRetries(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch requests.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+		case 2:
+			time.Sleep(200 * time.Millisecond)
+
+		default:
+			_, _ = w.Write([]byte(`{"type": "string"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	_, err := schemas.NewHTTPLoader(nil, schemas.WithHTTPRetries(1), schemas.WithHTTPTimeout(50*time.Millisecond)).
+		Load(server.URL+"/a.json", "")
+	require.Error(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+
+	_, err = schemas.NewHTTPLoader(nil, schemas.WithHTTPRetries(1)).Load(server.URL+"/a.json", "")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), requests.Load())
+}
+
+func TestHTTPLoaderCache(t *testing.T) {
+	t.Parallel()
+
+	var requests, revalidated atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			revalidated.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = w.Write([]byte("type: string\n"))
+	}))
+
+	dir := t.TempDir()
+	uri := server.URL + "/schemas/a#/$defs/x"
+
+	_, err := schemas.NewHTTPLoader(nil, schemas.WithOffline(true), schemas.WithHTTPCache(dir)).Load(uri, "")
+	require.ErrorIs(t, err, schemas.ErrNotCached)
+
+	for range 2 {
+		schema, err := schemas.NewHTTPLoader(nil, schemas.WithHTTPCache(dir)).Load(uri, "")
+		require.NoError(t, err)
+		assert.Equal(t, schemas.TypeList{"string"}, schema.Type)
+	}
+
+	assert.Equal(t, int32(2), requests.Load())
+	assert.Equal(t, int32(1), revalidated.Load())
+
+	server.Close()
+
+	schema, err := schemas.NewHTTPLoader(nil, schemas.WithOffline(true), schemas.WithHTTPCache(dir)).Load(uri, "")
+	require.NoError(t, err)
+	assert.Equal(t, schemas.TypeList{"string"}, schema.Type)
+}
@@ -0,0 +1,488 @@
+package schemas
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/atombender/go-jsonschema/pkg/yamlutils"
+)
+
+// LintSeverity tells whether a lint issue makes a schema invalid, or is likely a mistake.
+type LintSeverity string
+
+const (
+	LintError   LintSeverity = "error"
+	LintWarning LintSeverity = "warning"
+)
+
+// LintIssue is a problem found in a schema document by Lint.
+type LintIssue struct {
+	// Pointer is the JSON Pointer of the offending value within the document.
+	Pointer  string
+	Severity LintSeverity
+	Message  string
+}
+
+func (i LintIssue) String() string {
+	pointer := i.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+
+	return fmt.Sprintf("%s: %s: %s", pointer, i.Severity, i.Message)
+}
+
+type LintOptions struct {
+	// DefaultDialect is the dialect of a schema that does not declare one with $schema.
+	// Without it, such a schema is checked against the latest dialect.
+	DefaultDialect Dialect
+	// YAML is set when the document is YAML rather than JSON.
+	YAML bool
+	// JSONC is set when the document is JSONC or JSON5 rather than JSON.
+	JSONC bool
+}
+
+// Keywords accepted in schemas besides those of the meta-schemas.
+var lintExtensionKeywords = map[string]bool{
+	"$ref":          true,
+	"discriminator": true, // OpenAPI 3.x.
+	"goJSONSchema":  true,
+}
+
+// Lint checks a schema document against the meta-schema of its dialect, and for mistakes the
+// meta-schema does not catch: unknown keywords, definitions that are never referenced, enum values
+// contradicting the type, regular expressions that RE2 does not support, and more. Issues are
+// sorted by their position in the document. An error is only returned if the document cannot be read.
+func Lint(data []byte, opts LintOptions) ([]LintIssue, error) {
+	if opts.JSONC {
+		var err error
+		if data, _, err = jsoncToJSON(data); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.YAML {
+		var m map[string]any
+		if err := yaml.Unmarshal(data, &m); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal YAML: %w", err)
+		}
+
+		yamlutils.FixMapKeys(m)
+
+		var err error
+		if data, err = json.Marshal(m); err != nil {
+			return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+		}
+	}
+
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	l := &linter{doc: doc}
+
+	dialect := opts.DefaultDialect
+	root, _ := doc.(map[string]any)
+
+	if version, ok := root["$schema"].(string); ok {
+		if d := DialectFromURI(version); d != DialectUnknown {
+			dialect = d
+		} else {
+			l.warnf(nil, "unknown $schema %q", version)
+		}
+	}
+
+	if dialect == DialectUnknown {
+		dialect = Dialects[len(Dialects)-1]
+
+		if _, ok := root["$schema"]; !ok {
+			l.warnf(nil, "no $schema declared, checked as %s", dialect)
+		}
+	}
+
+	if err := l.checkMetaSchema(dialect); err != nil {
+		return nil, err
+	}
+
+	if err := l.walk(doc, nil, dialect); err != nil {
+		return nil, err
+	}
+
+	schema, err := FromJSONReaderWithDialect(bytes.NewReader(data), dialect)
+	if err != nil {
+		// Invalid keyword values are better explained by the meta-schema.
+		if len(l.issues) == 0 {
+			l.errorf(nil, "%v", err)
+		}
+	} else {
+		l.checkReferences(schema)
+	}
+
+	sort.SliceStable(l.issues, func(i, j int) bool {
+		return l.issues[i].Pointer < l.issues[j].Pointer
+	})
+
+	return l.issues, nil
+}
+
+type linter struct {
+	doc    any
+	issues []LintIssue
+}
+
+func (l *linter) errorf(tokens []string, format string, args ...any) {
+	l.add(LintIssue{
+		Pointer:  FormatJSONPointer(tokens),
+		Severity: LintError,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *linter) warnf(tokens []string, format string, args ...any) {
+	l.add(LintIssue{
+		Pointer:  FormatJSONPointer(tokens),
+		Severity: LintWarning,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// add records an issue, unless it was already found through another path of the meta-schema.
+func (l *linter) add(issue LintIssue) {
+	if !slices.Contains(l.issues, issue) {
+		l.issues = append(l.issues, issue)
+	}
+}
+
+func (l *linter) hasIssue(tokens []string) bool {
+	pointer := FormatJSONPointer(tokens)
+
+	for _, issue := range l.issues {
+		if issue.Pointer == pointer {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (l *linter) checkMetaSchema(dialect Dialect) error {
+	meta, err := metaSchema(dialect)
+	if err != nil {
+		return err
+	}
+
+	v := &instanceValidator{root: meta}
+
+	for _, e := range v.validate(meta, l.doc, nil) {
+		l.errorf(e.tokens, "%s", e.message)
+	}
+
+	return nil
+}
+
+// walk checks the schema at the given position, and the subschemas nested in it.
+func (l *linter) walk(schema any, tokens []string, dialect Dialect) error {
+	obj, ok := schema.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	if version, ok := obj["$schema"].(string); ok && len(tokens) > 0 {
+		if d := DialectFromURI(version); d != DialectUnknown {
+			dialect = d
+		}
+	}
+
+	meta, err := metaSchema(dialect)
+	if err != nil {
+		return err
+	}
+
+	l.checkKeywords(obj, tokens, metaSchemaKeywords(meta))
+	l.checkValues(obj, tokens)
+
+	for _, sub := range rawSubschemas(obj) {
+		if err := l.walk(sub.schema, append(tokens[:len(tokens):len(tokens)], sub.path...), dialect); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (l *linter) checkKeywords(obj map[string]any, tokens []string, keywords map[string]bool) {
+	for _, name := range sortedKeys(obj) {
+		if keywords[name] || lintExtensionKeywords[name] || strings.HasPrefix(name, "x-") {
+			continue
+		}
+
+		if suggestion := closestKeyword(name, keywords); suggestion != "" {
+			l.warnf(append(tokens, name), "unknown keyword %q, did you mean %q?", name, suggestion)
+		} else {
+			l.warnf(append(tokens, name), "unknown keyword %q", name)
+		}
+	}
+}
+
+// checkValues looks for keyword values that are valid on their own, but not in combination.
+func (l *linter) checkValues(obj map[string]any, tokens []string) {
+	at := func(path ...string) []string {
+		return append(tokens[:len(tokens):len(tokens)], path...)
+	}
+
+	types := stringList(obj["type"])
+	allowed := func(value any) bool {
+		for _, t := range types {
+			if jsonTypeMatches(t, value) {
+				return true
+			}
+		}
+
+		return len(types) == 0
+	}
+
+	if enum, ok := obj["enum"].([]any); ok {
+		if len(enum) == 0 && !l.hasIssue(at("enum")) {
+			l.errorf(at("enum"), "enum has no values, so no value is valid")
+		}
+
+		for i, value := range enum {
+			if !allowed(value) {
+				l.errorf(at("enum", strconv.Itoa(i)), "enum value %s is not of type %s",
+					formatJSONValues([]any{value}), strings.Join(types, " or "))
+			}
+		}
+	}
+
+	if value, ok := obj["const"]; ok && !allowed(value) {
+		l.errorf(at("const"), "const value %s is not of type %s",
+			formatJSONValues([]any{value}), strings.Join(types, " or "))
+	}
+
+	if pattern, ok := obj["pattern"].(string); ok {
+		if _, err := regexp.Compile(pattern); err != nil {
+			l.errorf(at("pattern"), "pattern is not supported by RE2: %v", err)
+		}
+	}
+
+	patternProperties, _ := obj["patternProperties"].(map[string]any)
+
+	for _, pattern := range sortedKeys(patternProperties) {
+		if _, err := regexp.Compile(pattern); err != nil {
+			l.errorf(at("patternProperties", pattern), "pattern is not supported by RE2: %v", err)
+		}
+	}
+
+	// Properties may also be declared by the subschemas combined with this one.
+	properties, _ := obj["properties"].(map[string]any)
+	if len(properties) == 0 || len(patternProperties) > 0 {
+		return
+	}
+
+	for _, keyword := range []string{"$ref", "allOf", "anyOf", "oneOf", "if", "dependentSchemas", "dependencies"} {
+		if _, ok := obj[keyword]; ok {
+			return
+		}
+	}
+
+	required, _ := obj["required"].([]any)
+
+	for i, name := range required {
+		if s, ok := name.(string); ok {
+			if _, ok := properties[s]; !ok {
+				l.warnf(at("required", strconv.Itoa(i)), "required property %q is not declared in properties", s)
+			}
+		}
+	}
+}
+
+// checkReferences reports references within the document that do not resolve, and definitions
+// that cannot be reached from the root schema.
+func (l *linter) checkReferences(schema *Schema) {
+	registry := NewRegistry()
+	registry.Register(schema, "")
+
+	// References may spell the position of a subschema differently from the model, as with
+	// definitions and $defs, so targets are identified by the subschema they resolve to.
+	pointers := map[*Type]string{}
+	schema.walkSubschemas(func(tokens []string, t *Type) {
+		pointers[t] = FormatJSONPointer(tokens)
+	})
+
+	resolve := func(t *Type) (*Type, bool) {
+		res, ok := registry.Resolve(t.Ref, t)
+		if !ok || res.Document != schema {
+			return nil, false
+		}
+
+		target, err := schema.ResolveJSONPointer(res.Pointer)
+
+		return target, err == nil
+	}
+
+	// The pointers of the subschemas reachable from the root, extended until no reference leads
+	// anywhere new. A definition below a reachable subschema is only reachable through a reference.
+	reachable := map[string]bool{"": true}
+	isReachable := func(tokens []string) bool {
+		for i := len(tokens); i >= 0; i-- {
+			if reachable[FormatJSONPointer(tokens[:i])] {
+				return true
+			}
+
+			if i > 0 && tokens[i-1] == "$defs" {
+				return false
+			}
+		}
+
+		return false
+	}
+
+	for changed := true; changed; {
+		changed = false
+
+		schema.walkSubschemas(func(tokens []string, t *Type) {
+			if t.Ref == "" || !isReachable(tokens) {
+				return
+			}
+
+			target, ok := resolve(t)
+			if !ok {
+				return
+			}
+
+			if pointer := pointers[target]; !reachable[pointer] {
+				reachable[pointer] = true
+				changed = true
+			}
+		})
+	}
+
+	schema.walkSubschemas(func(tokens []string, t *Type) {
+		if _, ok := resolve(t); !ok && strings.HasPrefix(t.Ref, "#") {
+			l.errorf(append(l.documentTokens(tokens), "$ref"), "reference %q does not resolve", t.Ref)
+		}
+	})
+
+	if isDefinitionsLibrary(l.doc) {
+		return
+	}
+
+	schema.walkSubschemas(func(tokens []string, t *Type) {
+		if len(tokens) >= 2 && tokens[len(tokens)-2] == "$defs" && !isReachable(tokens) {
+			l.warnf(l.documentTokens(tokens), "definition %q is never referenced", tokens[len(tokens)-1])
+		}
+	})
+}
+
+// isDefinitionsLibrary reports whether the document holds nothing but definitions,
+// for other schemas to reference.
+func isDefinitionsLibrary(doc any) bool {
+	root, _ := doc.(map[string]any)
+
+	for name := range root {
+		switch name {
+		case "$schema", "$id", "id", "$comment", "title", "description", "$defs", "definitions":
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// documentTokens translates the JSON Pointer of a subschema in the model of the schema
+// into its position in the document.
+func (l *linter) documentTokens(tokens []string) []string {
+	return documentTokens(tokens, func(tokens []string) bool {
+		current := l.doc
+
+		for _, token := range tokens {
+			switch c := current.(type) {
+			case map[string]any:
+				var ok bool
+				if current, ok = c[token]; !ok {
+					return false
+				}
+
+			case []any:
+				n, err := strconv.Atoi(token)
+				if err != nil || n < 0 || n >= len(c) {
+					return false
+				}
+
+				current = c[n]
+
+			default:
+				return false
+			}
+		}
+
+		return true
+	})
+}
+
+// closestKeyword returns the known keyword a misspelled one was most likely meant to be, if any.
+func closestKeyword(name string, keywords map[string]bool) string {
+	best, bestDistance := "", len(name)/3+1
+
+	for _, keyword := range sortedKeys(keywords) {
+		if distance := editDistance(strings.ToLower(name), strings.ToLower(keyword)); distance < bestDistance {
+			best, bestDistance = keyword, distance
+		}
+	}
+
+	return best
+}
+
+// editDistance returns the number of insertions, deletions, substitutions and transpositions
+// of adjacent characters needed to turn one string into the other.
+func editDistance(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+
+	d := make([][]int, len(ar)+1)
+	for i := range d {
+		d[i] = make([]int, len(br)+1)
+		d[i][0] = i
+	}
+
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+
+			if i > 1 && j > 1 && ar[i-1] == br[j-2] && ar[i-2] == br[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(ar)][len(br)]
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,209 @@
+package schemas_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/atombender/go-jsonschema/pkg/schemas"
+)
+
+func TestLint(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		schema  string
+		opts    schemas.LintOptions
+		want    []string
+		wantErr bool
+	}{
+		{
+			desc: "valid schema",
+			schema: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"type": "object",
+				"properties": {
+					"name": {"type": "string", "pattern": "^[a-z]+$"},
+					"kind": {"$ref": "#/$defs/kind"}
+				},
+				"required": ["name"],
+				"$defs": {
+					"kind": {"enum": ["a", "b"]}
+				}
+			}`,
+		},
+		{
+			desc: "meta-schema violations",
+			schema: `{
+				"$schema": "http://json-schema.org/draft-07/schema#",
+				"type": "object",
+				"properties": {
+					"a": {"type": "strin"},
+					"b": {"minLength": -1},
+					"c": {"required": "c"}
+				}
+			}`,
+			want: []string{
+				`/properties/a/type: error: value must be one of "array", "boolean", "integer", "null", "number", "object", "string"`,
+				`/properties/b/minLength: error: must be at least 0`,
+				`/properties/c/required: error: expected array, got string`,
+			},
+		},
+		{
+			desc: "dialect of the meta-schema",
+			schema: `{
+				"$schema": "http://json-schema.org/draft-04/schema#",
+				"properties": {
+					"a": {"exclusiveMinimum": 1}
+				}
+			}`,
+			want: []string{
+				`/properties/a: error: property "exclusiveMinimum" requires property "minimum"`,
+				`/properties/a/exclusiveMinimum: error: expected boolean, got integer`,
+			},
+		},
+		{
+			desc: "tuples in 2020-12",
+			schema: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"items": [{"type": "string"}]
+			}`,
+			want: []string{
+				`/items: error: expected object or boolean, got array`,
+			},
+		},
+		{
+			desc: "unknown keywords",
+			schema: `{
+				"$schema": "http://json-schema.org/draft-07/schema#",
+				"propertes": {},
+				"properties": {
+					"a": {"tpye": "string", "x-extension": true, "goJSONSchema": {"type": "string"}},
+					"b": {"serviceScope": "global"}
+				}
+			}`,
+			want: []string{
+				`/propertes: warning: unknown keyword "propertes", did you mean "properties"?`,
+				`/properties/a/tpye: warning: unknown keyword "tpye", did you mean "type"?`,
+				`/properties/b/serviceScope: warning: unknown keyword "serviceScope"`,
+			},
+		},
+		{
+			desc: "enum and const contradicting type",
+			schema: `{
+				"$schema": "http://json-schema.org/draft-07/schema#",
+				"properties": {
+					"a": {"type": "integer", "enum": [1, 2.5, "three"]},
+					"b": {"type": ["string", "null"], "const": 1},
+					"c": {"type": "number", "enum": [1, 2.5]},
+					"d": {"enum": []}
+				}
+			}`,
+			want: []string{
+				`/properties/a/enum/1: error: enum value 2.5 is not of type integer`,
+				`/properties/a/enum/2: error: enum value "three" is not of type integer`,
+				`/properties/b/const: error: const value 1 is not of type string or null`,
+				`/properties/d/enum: error: enum has no values, so no value is valid`,
+			},
+		},
+		{
+			desc: "regular expressions",
+			schema: `{
+				"properties": {
+					"a": {"type": "string", "pattern": "^(?!x)"}
+				},
+				"patternProperties": {
+					"^\\d+(?<=0)$": {"type": "string"}
+				}
+			}`,
+			want: []string{
+				"/: warning: no $schema declared, checked as 2020-12",
+				"/patternProperties/^\\d+(?<=0)$: error: pattern is not supported by RE2: " +
+					"error parsing regexp: invalid named capture: `(?<=0)$`",
+				"/properties/a/pattern: error: pattern is not supported by RE2: " +
+					"error parsing regexp: invalid or unsupported Perl syntax: `(?!`",
+			},
+		},
+		{
+			desc: "default dialect",
+			schema: `{
+				"items": [{"type": "string"}],
+				"additionalItems": false
+			}`,
+			opts: schemas.LintOptions{DefaultDialect: schemas.DialectDraft07},
+		},
+		{
+			desc: "references and definitions",
+			schema: `{
+				"$schema": "http://json-schema.org/draft-07/schema#",
+				"properties": {
+					"a": {"$ref": "#/definitions/used"},
+					"b": {"$ref": "#/definitions/missing"},
+					"c": {"$ref": "#anchored"}
+				},
+				"required": ["a", "d"],
+				"definitions": {
+					"used": {"$ref": "#/definitions/usedIndirectly"},
+					"usedIndirectly": {"type": "string"},
+					"anchored": {"$id": "#anchored"},
+					"unused": {"$ref": "#/definitions/usedByUnused"},
+					"usedByUnused": {}
+				}
+			}`,
+			want: []string{
+				`/definitions/unused: warning: definition "unused" is never referenced`,
+				`/definitions/usedByUnused: warning: definition "usedByUnused" is never referenced`,
+				`/properties/b/$ref: error: reference "#/definitions/missing" does not resolve`,
+				`/required/1: warning: required property "d" is not declared in properties`,
+			},
+		},
+		{
+			desc: "library of definitions",
+			schema: `{
+				"$schema": "https://json-schema.org/draft/2020-12/schema",
+				"$defs": {
+					"a": {"type": "string"}
+				}
+			}`,
+		},
+		{
+			desc: "YAML",
+			schema: `
+$schema: https://json-schema.org/draft/2020-12/schema
+type: objet
+`,
+			opts: schemas.LintOptions{YAML: true},
+			want: []string{
+				`/type: error: value must be one of "array", "boolean", "integer", "null", "number", "object", "string"`,
+			},
+		},
+		{
+			desc:    "malformed JSON",
+			schema:  `{"type": `,
+			wantErr: true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			issues, err := schemas.Lint([]byte(tC.schema), tC.opts)
+			if tC.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			got := make([]string, 0, len(issues))
+			for _, issue := range issues {
+				got = append(got, issue.String())
+			}
+
+			assert.Equal(t, append([]string{}, tC.want...), got)
+		})
+	}
+}
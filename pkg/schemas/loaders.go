@@ -1,11 +1,11 @@
 package schemas
 
 import (
-	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
-	"net/http"
-	"net/url"
+	"io"
 	"os"
 	"path"
 	"path/filepath"
@@ -18,12 +18,52 @@ var (
 	ErrUnsupportedContentType   = errors.New("unsupported content type")
 	ErrUnsupportedFileExtension = errors.New("unsupported file extension")
 	ErrUnsupportedURL           = errors.New("unsupported URL")
+	ErrUnexpectedHTTPStatus     = errors.New("unexpected HTTP status")
+	ErrNotCached                = errors.New("schema is not in the HTTP cache")
 )
 
 type Loader interface {
 	Load(uri, parentURI string) (*Schema, error)
 }
 
+// LoaderFunc adapts a function to the Loader interface.
+type LoaderFunc func(uri, parentURI string) (*Schema, error)
+
+func (f LoaderFunc) Load(uri, parentURI string) (*Schema, error) {
+	return f(uri, parentURI)
+}
+
+// QualifyingLoader is a loader that knows the qualified name each file it loads is known by,
+// such as a loader reading files from somewhere other than the file system of the OS.
+type QualifyingLoader interface {
+	Loader
+	QualifiedFileName(fileName, parentFileName string) (string, error)
+}
+
+// errNotQualifying is returned by loaders that wrap a loader which is not a QualifyingLoader.
+var errNotQualifying = errors.New("loader does not qualify file names")
+
+// LoaderQualifiedFileName returns the qualified name of a file referenced from the parent file,
+// as given by the loader if it is a QualifyingLoader, or by QualifiedFileName otherwise.
+func LoaderQualifiedFileName(loader Loader, fileName, parentFileName string, resolveExtensions []string) (string, error) {
+	if q, ok := loader.(QualifyingLoader); ok {
+		qualified, err := q.QualifiedFileName(fileName, parentFileName)
+		if !errors.Is(err, errNotQualifying) {
+			return qualified, err
+		}
+	}
+
+	return QualifiedFileName(fileName, parentFileName, resolveExtensions)
+}
+
+func qualifyWith(loader Loader, fileName, parentFileName string) (string, error) {
+	if q, ok := loader.(QualifyingLoader); ok {
+		return q.QualifiedFileName(fileName, parentFileName)
+	}
+
+	return "", errNotQualifying
+}
+
 func NewCachedLoader(loader Loader, cache map[string]*Schema) *CachedLoader {
 	return &CachedLoader{
 		loader: loader,
@@ -37,7 +77,13 @@ type CachedLoader struct {
 }
 
 func (l *CachedLoader) Load(uri, parentURI string) (*Schema, error) {
-	if schema, ok := l.cache[uri]; ok {
+	// The same reference made from different documents may point to different documents.
+	key := uri
+	if qualified, err := LoaderQualifiedFileName(l.loader, uri, parentURI, nil); err == nil {
+		key = qualified
+	}
+
+	if schema, ok := l.cache[key]; ok {
 		return schema, nil
 	}
 
@@ -46,29 +92,107 @@ func (l *CachedLoader) Load(uri, parentURI string) (*Schema, error) {
 		return nil, errors.Join(ErrCannotLoadSchema, err)
 	}
 
-	l.cache[uri] = schema
+	l.cache[key] = schema
 
 	return schema, nil
 }
 
-func NewFileLoader(resolveExtensions, yamlExtensions []string) *FileLoader {
+func (l *CachedLoader) QualifiedFileName(fileName, parentFileName string) (string, error) {
+	return qualifyWith(l.loader, fileName, parentFileName)
+}
+
+// LoaderOption configures the loaders created by NewDefaultMultiLoader and friends.
+type LoaderOption func(*loaderOptions)
+
+type loaderOptions struct {
+	uriMappings     []URIMapping
+	defaultDialect  Dialect
+	http            HTTPLoader
+	lockfile        *Lockfile
+	updateLock      bool
+	policy          Policy
+	schemeLoaders   map[RefType]Loader
+	jsoncExtensions []string
+}
+
+// WithURIMappings makes remote URIs matching one of the mappings load from local files instead.
+func WithURIMappings(mappings ...URIMapping) LoaderOption {
+	return func(o *loaderOptions) {
+		o.uriMappings = append(o.uriMappings, mappings...)
+	}
+}
+
+// WithDefaultDialect sets the dialect of loaded schemas that do not declare one with $schema.
+func WithDefaultDialect(dialect Dialect) LoaderOption {
+	return func(o *loaderOptions) {
+		o.defaultDialect = dialect
+	}
+}
+
+// WithJSONCExtensions makes the loaders created by NewDefaultMultiLoader and friends read the files
+// and URLs with one of the given extensions as JSONC or JSON5, which allow comments, trailing commas
+// and more.
+func WithJSONCExtensions(extensions ...string) LoaderOption {
+	return func(o *loaderOptions) {
+		o.jsoncExtensions = append(o.jsoncExtensions, extensions...)
+	}
+}
+
+// WithSchemeLoader makes the loaders created by NewDefaultMultiLoader and friends load references
+// with the given URI scheme through the given loader. Other loaders are left unaware of the scheme.
+// References relative to a document loaded this way are resolved against its URI, and loaded the same way.
+func WithSchemeLoader(scheme string, loader Loader) LoaderOption {
+	refType := RefType(strings.ToLower(scheme))
+
+	return func(o *loaderOptions) {
+		if o.schemeLoaders == nil {
+			o.schemeLoaders = map[RefType]Loader{}
+		}
+
+		o.schemeLoaders[refType] = loader
+	}
+}
+
+func newLoaderOptions(opts []LoaderOption) loaderOptions {
+	var o loaderOptions
+
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+func NewFileLoader(resolveExtensions, yamlExtensions []string, opts ...LoaderOption) *FileLoader {
+	o := newLoaderOptions(opts)
+
 	return &FileLoader{
 		resolveExtensions: resolveExtensions,
 		yamlExtensions:    toExtensionSet(yamlExtensions),
+		jsoncExtensions:   toExtensionSet(o.jsoncExtensions),
+		defaultDialect:    o.defaultDialect,
+		policy:            o.policy,
 	}
 }
 
 type FileLoader struct {
 	resolveExtensions []string
 	yamlExtensions    map[string]bool
+	jsoncExtensions   map[string]bool
+	defaultDialect    Dialect
+	policy            Policy
 }
 
 func (l *FileLoader) Load(fileName, parentFileName string) (*Schema, error) {
-	qualified, err := QualifiedFileName(fileName, parentFileName, l.resolveExtensions)
+	qualified, err := l.QualifiedFileName(fileName, parentFileName)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := l.policy.checkFile(qualified); err != nil {
+		return nil, err
+	}
+
 	schema, err := l.parseFile(qualified)
 	if err != nil {
 		return nil, err
@@ -77,42 +201,95 @@ func (l *FileLoader) Load(fileName, parentFileName string) (*Schema, error) {
 	return schema, nil
 }
 
+func (l *FileLoader) QualifiedFileName(fileName, parentFileName string) (string, error) {
+	return QualifiedFileName(fileName, parentFileName, l.resolveExtensions)
+}
+
 func (l *FileLoader) parseFile(fileName string) (*Schema, error) {
-	if l.yamlExtensions[path.Ext(fileName)] {
-		sc, err := FromYAMLFile(fileName)
-		if err != nil {
-			return nil, fmt.Errorf("error parsing YAML file %s: %w", fileName, err)
-		}
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
 
-		return sc, nil
+	if info, err := f.Stat(); err == nil {
+		if err := l.policy.checkSize(fileName, info.Size()); err != nil {
+			return nil, err
+		}
 	}
 
-	sc, err := FromJSONFile(fileName)
+	format := extensionFormat(path.Ext(fileName), l.yamlExtensions, l.jsoncExtensions)
+
+	return parseSchemaFile(f, fileName, format, l.defaultDialect)
+}
+
+// parseSchemaFile parses a schema file read from r, in the given format or in the one told by its
+// content, and records its name as the source of the schema and of any parse error.
+func parseSchemaFile(r io.Reader, fileName string, format Format, defaultDialect Dialect) (*Schema, error) {
+	sc, err := FromReaderWithDialect(r, format, defaultDialect)
 	if err != nil {
-		return nil, fmt.Errorf("error parsing JSON file %s: %w", fileName, err)
+		if setErrorSource(err, fileName) {
+			return nil, err
+		}
+
+		if format == FormatUnknown {
+			return nil, fmt.Errorf("error parsing file %s: %w", fileName, err)
+		}
+
+		return nil, fmt.Errorf("error parsing %s file %s: %w", strings.ToUpper(string(format)), fileName, err)
 	}
 
+	sc.setSource(fileName)
+
 	return sc, nil
 }
 
-func NewDefaultCacheLoader(resolveExtensions, yamlExtensions []string) *CachedLoader {
-	return NewCachedLoader(NewDefaultMultiLoader(resolveExtensions, yamlExtensions), map[string]*Schema{})
+func NewDefaultCacheLoader(resolveExtensions, yamlExtensions []string, opts ...LoaderOption) *CachedLoader {
+	var loader Loader = NewDefaultMultiLoader(resolveExtensions, yamlExtensions, opts...)
+
+	o := newLoaderOptions(opts)
+
+	if o.policy.MaxRefDepth > 0 {
+		loader = newDepthLimitedLoader(loader, o.policy.MaxRefDepth)
+	}
+
+	if o.lockfile != nil {
+		loader = NewLockedLoader(loader, o.lockfile, o.updateLock)
+	}
+
+	return NewCachedLoader(loader, map[string]*Schema{})
 }
 
-func NewDefaultMultiLoader(resolveExtensions, yamlExtensions []string) MultiLoader {
-	httpLoader := NewHTTPLoader(yamlExtensions)
+func NewDefaultMultiLoader(resolveExtensions, yamlExtensions []string, opts ...LoaderOption) MultiLoader {
+	fileLoader := NewFileLoader(resolveExtensions, yamlExtensions, opts...)
+
+	var httpLoader Loader = NewHTTPLoader(yamlExtensions, opts...)
+	if mappings := newLoaderOptions(opts).uriMappings; len(mappings) > 0 {
+		httpLoader = NewMappedLoader(mappings, fileLoader, httpLoader)
+	}
 
-	return MultiLoader{
-		RefTypeFile:  NewFileLoader(resolveExtensions, yamlExtensions),
+	loaders := MultiLoader{
+		RefTypeFile:  fileLoader,
 		RefTypeHTTP:  httpLoader,
 		RefTypeHTTPS: httpLoader,
 	}
+
+	for refType, loader := range newLoaderOptions(opts).schemeLoaders {
+		loaders[refType] = loader
+	}
+
+	return loaders
 }
 
 type MultiLoader map[RefType]Loader
 
 func (l MultiLoader) Load(uri, parentURI string) (*Schema, error) {
-	ref, err := GetRefType(uri)
+	uri = refURI(uri, parentURI)
+
+	ref, err := l.refType(uri)
 	if err != nil {
 		return nil, err
 	}
@@ -130,64 +307,115 @@ func (l MultiLoader) Load(uri, parentURI string) (*Schema, error) {
 	return schema, nil
 }
 
-func NewHTTPLoader(yamlExtensions []string) *HTTPLoader {
-	return &HTTPLoader{YAMLExtensions: toExtensionSet(yamlExtensions)}
-}
+func (l MultiLoader) QualifiedFileName(fileName, parentFileName string) (string, error) {
+	fileName = refURI(fileName, parentFileName)
+
+	ref, err := l.refType(fileName)
+	if err != nil {
+		return "", err
+	}
 
-type HTTPLoader struct {
-	YAMLExtensions map[string]bool
+	return qualifyWith(l[ref], fileName, parentFileName)
 }
 
-func (l *HTTPLoader) Load(uri, parentURI string) (*Schema, error) {
-	u, err := url.Parse(uri)
+// refType returns the RefType of a reference, whose scheme is valid if the loader has a loader for it.
+func (l MultiLoader) refType(uri string) (RefType, error) {
+	ref, err := schemeRefType(uri)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse url: %w", err)
+		return RefTypeUnknown, err
 	}
 
-	if u.Scheme == "http" || u.Scheme == "https" {
-		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, u.String(), nil)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create request: %w", err)
-		}
+	if _, ok := l[ref]; ok {
+		return ref, nil
+	}
 
-		resp, err := (&http.Client{}).Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("failed to perform request: %w", err)
+	return GetRefType(uri)
+}
+
+// URIMapping maps every URI starting with Prefix to the file at the same relative path under Dir.
+type URIMapping struct {
+	Prefix string
+	Dir    string
+}
+
+// MapURI rewrites a URI to an absolute local file name using the mapping with the longest matching prefix.
+func MapURI(mappings []URIMapping, uri string) (string, bool) {
+	var best *URIMapping
+
+	for i, m := range mappings {
+		if strings.HasPrefix(uri, m.Prefix) && (best == nil || len(m.Prefix) > len(best.Prefix)) {
+			best = &mappings[i]
 		}
+	}
 
-		defer func() {
-			if resp != nil && resp.Body != nil {
-				_ = resp.Body.Close()
-			}
-		}()
+	if best == nil {
+		return "", false
+	}
 
-		switch resp.Header.Get("Content-Type") {
-		case "application/json":
-			return FromJSONReader(resp.Body)
+	rest, _, _ := strings.Cut(strings.TrimPrefix(uri, best.Prefix), "#")
+	rest, query, _ := strings.Cut(rest, "?")
 
-		case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
-			return FromYAMLReader(resp.Body)
+	fileName := filepath.Join(best.Dir, filepath.FromSlash(queryFilePath(rest, query)))
 
-		default:
-			if l.YAMLExtensions[path.Ext(u.Path)] {
-				return FromYAMLReader(resp.Body)
-			}
+	// The file name must not be mistaken for one relative to the referencing schema.
+	if abs, err := filepath.Abs(fileName); err == nil {
+		fileName = abs
+	}
 
-			return FromJSONReader(resp.Body)
-		}
+	return fileName, true
+}
+
+// queryFilePath returns the relative file path a URL path with the given query string maps to. The query
+// is told apart by a short hash added to the file name, before its extension, so that documents that only
+// differ by their query are kept in files of their own.
+func queryFilePath(filePath, rawQuery string) string {
+	if rawQuery == "" {
+		return filePath
 	}
 
-	return nil, fmt.Errorf("%w: %q", ErrUnsupportedURL, uri)
+	sum := sha256.Sum256([]byte(rawQuery))
+	ext := path.Ext(filePath)
+
+	return strings.TrimSuffix(filePath, ext) + "_" + hex.EncodeToString(sum[:4]) + ext
 }
 
+func NewMappedLoader(mappings []URIMapping, fileLoader, loader Loader) *MappedLoader {
+	return &MappedLoader{
+		mappings:   mappings,
+		fileLoader: fileLoader,
+		loader:     loader,
+	}
+}
+
+// MappedLoader loads URIs that match one of its mappings from local files,
+// and everything else through the wrapped loader.
+type MappedLoader struct {
+	mappings   []URIMapping
+	fileLoader Loader
+	loader     Loader
+}
+
+func (l *MappedLoader) Load(uri, parentURI string) (*Schema, error) {
+	if fileName, ok := MapURI(l.mappings, uri); ok {
+		return l.fileLoader.Load(fileName, "")
+	}
+
+	return l.loader.Load(uri, parentURI)
+}
+
+// QualifiedFileName returns the name of the file a reference made from the parent file points to,
+// trying each of the extensions in turn if it does not exist as it is, with its symlinks resolved.
+// References to other URIs, or made from documents loaded from other URIs, are returned as URIs.
 func QualifiedFileName(fileName, parentFileName string, resolveExtensions []string) (string, error) {
-	r, err := GetRefType(fileName)
+	fileName = refURI(fileName, parentFileName)
+
+	r, err := schemeRefType(fileName)
 	if err != nil {
 		return "", err
 	}
 
 	if r != RefTypeFile {
-		return fileName[strings.Index(fileName, "://")+3:], nil
+		return fileName, nil
 	}
 
 	fileName = strings.TrimPrefix(fileName, "file://")
@@ -0,0 +1,60 @@
+package schemas_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/atombender/go-jsonschema/pkg/schemas"
+)
+
+func TestMapURI(t *testing.T) {
+	t.Parallel()
+
+	mappings := []schemas.URIMapping{
+		{Prefix: "https://example.com/", Dir: "/vendor/example"},
+		{Prefix: "https://example.com/common/", Dir: "/checkout/common"},
+	}
+
+	testCases := []struct {
+		desc  string
+		uri   string
+		want  string
+		found bool
+	}{
+		{
+			desc:  "prefix",
+			uri:   "https://example.com/schema.json",
+			want:  "/vendor/example/schema.json",
+			found: true,
+		},
+		{
+			desc:  "longest prefix wins",
+			uri:   "https://example.com/common/address.json",
+			want:  "/checkout/common/address.json",
+			found: true,
+		},
+		{
+			desc:  "fragment is dropped",
+			uri:   "https://example.com/common/address.json#/$defs/street",
+			want:  "/checkout/common/address.json",
+			found: true,
+		},
+		{
+			desc:  "no match",
+			uri:   "https://example.org/schema.json",
+			found: false,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := schemas.MapURI(mappings, tC.uri)
+			require.Equal(t, tC.found, ok)
+			assert.Equal(t, filepath.FromSlash(tC.want), got)
+		})
+	}
+}
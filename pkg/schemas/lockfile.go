@@ -0,0 +1,159 @@
+package schemas
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var ErrLockMismatch = errors.New("schema does not match the lockfile")
+
+// DefaultLockfileName is the name of the lockfile the command line tool reads and writes by default.
+const DefaultLockfileName = "go-jsonschema.lock"
+
+// Lockfile records the digests of the remote schemas a set of schemas was generated from, so that
+// later runs can tell when one changed.
+type Lockfile struct {
+	// Schemas holds the digest of every remote schema document, by URI.
+	Schemas map[string]string `json:"schemas"`
+
+	changed bool
+}
+
+// ReadLockfile reads a lockfile, or returns an empty one if the file does not exist.
+func ReadLockfile(fileName string) (*Lockfile, error) {
+	lock := &Lockfile{Schemas: map[string]string{}}
+
+	data, err := os.ReadFile(fileName)
+	if errors.Is(err, os.ErrNotExist) {
+		return lock, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lockfile: %w", err)
+	}
+
+	if err := json.Unmarshal(data, lock); err != nil {
+		return nil, fmt.Errorf("failed to parse lockfile %s: %w", fileName, err)
+	}
+
+	if lock.Schemas == nil {
+		lock.Schemas = map[string]string{}
+	}
+
+	return lock, nil
+}
+
+// Changed reports whether digests were added to the lockfile or updated since it was read.
+func (l *Lockfile) Changed() bool {
+	return l.changed
+}
+
+// Write writes the lockfile, with its schemas sorted by URI.
+func (l *Lockfile) Write(fileName string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal lockfile: %w", err)
+	}
+
+	if err := os.WriteFile(fileName, append(data, '\n'), 0o644); err != nil { //nolint:gosec // Meant to be committed.
+		return fmt.Errorf("failed to write lockfile: %w", err)
+	}
+
+	l.changed = false
+
+	return nil
+}
+
+// WithLockfile makes the loaders created by NewDefaultCacheLoader check every remote schema they load
+// against the lockfile, and record those it does not know of yet. A schema whose digest differs from the
+// one recorded fails to load with ErrLockMismatch, unless update is set, in which case it is recorded anew.
+func WithLockfile(lock *Lockfile, update bool) LoaderOption {
+	return func(o *loaderOptions) {
+		o.lockfile = lock
+		o.updateLock = update
+	}
+}
+
+func NewLockedLoader(loader Loader, lock *Lockfile, update bool) *LockedLoader {
+	return &LockedLoader{
+		loader: loader,
+		lock:   lock,
+		update: update,
+	}
+}
+
+// LockedLoader checks the schemas loaded from HTTP and HTTPS URIs through the wrapped loader against a
+// lockfile.
+type LockedLoader struct {
+	loader Loader
+	lock   *Lockfile
+	update bool
+}
+
+func (l *LockedLoader) Load(uri, parentURI string) (*Schema, error) {
+	schema, err := l.loader.Load(uri, parentURI)
+	if err != nil {
+		return nil, err
+	}
+
+	uri = refURI(uri, parentURI)
+
+	if refType, err := GetRefType(uri); err != nil || (refType != RefTypeHTTP && refType != RefTypeHTTPS) {
+		return schema, nil
+	}
+
+	digest, err := schema.contentDigest()
+	if err != nil {
+		return nil, err
+	}
+
+	uri = stripFragment(uri)
+
+	recorded, ok := l.lock.Schemas[uri]
+
+	switch {
+	case !ok:
+		// Recorded from now on.
+
+	case recorded == digest:
+		return schema, nil
+
+	case !l.update:
+		return nil, fmt.Errorf("%w: %s has digest %s, but %s is recorded", ErrLockMismatch, uri, digest, recorded)
+	}
+
+	l.lock.Schemas[uri] = digest
+	l.lock.changed = true
+
+	return schema, nil
+}
+
+func (l *LockedLoader) QualifiedFileName(fileName, parentFileName string) (string, error) {
+	return qualifyWith(l.loader, fileName, parentFileName)
+}
+
+// contentDigest returns the digest of the document the schema was parsed from, or of the schema
+// itself if it was built some other way.
+func (s *Schema) contentDigest() (string, error) {
+	if s.digest != "" {
+		return s.digest, nil
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal schema: %w", err)
+	}
+
+	return contentDigest(data), nil
+}
+
+// contentDigest returns the SHA-256 digest of a document, in the format of Subresource Integrity.
+func contentDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+
+	return "sha256-" + base64.StdEncoding.EncodeToString(sum[:])
+}
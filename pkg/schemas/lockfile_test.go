@@ -0,0 +1,78 @@
+package schemas_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/atombender/go-jsonschema/pkg/schemas"
+)
+
+func TestLockfile(t *testing.T) {
+	t.Parallel()
+
+	var version atomic.Value
+
+	version.Store(`{"type": "string"}`)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(version.Load().(string)))
+	}))
+	t.Cleanup(server.Close)
+
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, schemas.DefaultLockfileName)
+	uri := server.URL + "/a.json"
+
+	writeFiles(t, dir, map[string]string{"local.json": `{"type": "integer"}`})
+
+	load := func(update bool) (*schemas.Lockfile, error) {
+		t.Helper()
+
+		lock, err := schemas.ReadLockfile(fileName)
+		require.NoError(t, err)
+
+		loader := schemas.NewDefaultCacheLoader(nil, nil, schemas.WithLockfile(lock, update))
+
+		if _, err := loader.Load(filepath.Join(dir, "local.json"), ""); err != nil {
+			return nil, err
+		}
+
+		if _, err := loader.Load(uri+"#/$defs/a", ""); err != nil {
+			return nil, err
+		}
+
+		if lock.Changed() {
+			require.NoError(t, lock.Write(fileName))
+		}
+
+		return lock, nil
+	}
+
+	lock, err := load(false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{uri}, keys(lock.Schemas))
+
+	digest := lock.Schemas[uri]
+
+	lock, err = load(false)
+	require.NoError(t, err)
+	assert.Equal(t, digest, lock.Schemas[uri])
+
+	version.Store(`{"type": "number"}`)
+
+	_, err = load(false)
+	require.ErrorIs(t, err, schemas.ErrLockMismatch)
+
+	lock, err = load(true)
+	require.NoError(t, err)
+	assert.NotEqual(t, digest, lock.Schemas[uri])
+
+	_, err = load(false)
+	require.NoError(t, err)
+}
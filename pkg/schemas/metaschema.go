@@ -0,0 +1,523 @@
+package schemas
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// The meta-schemas of the known dialects, so that schemas can be checked without network access.
+// The 2019-09 and 2020-12 meta-schemas are split into vocabularies upstream; they are inlined
+// here into a single document each.
+//
+//go:embed metaschemas/*.json
+var metaSchemaFiles embed.FS
+
+var (
+	metaSchemasOnce sync.Once
+	metaSchemas     map[Dialect]map[string]any
+	errMetaSchemas  error
+)
+
+// metaSchema returns the decoded meta-schema of the dialect.
+func metaSchema(d Dialect) (map[string]any, error) {
+	metaSchemasOnce.Do(func() {
+		metaSchemas = map[Dialect]map[string]any{}
+
+		for _, dialect := range Dialects {
+			data, err := metaSchemaFiles.ReadFile("metaschemas/" + string(dialect) + ".json")
+			if err != nil {
+				errMetaSchemas = fmt.Errorf("failed to read meta-schema of %s: %w", dialect, err)
+
+				return
+			}
+
+			var m map[string]any
+			if err := json.Unmarshal(data, &m); err != nil {
+				errMetaSchemas = fmt.Errorf("failed to unmarshal meta-schema of %s: %w", dialect, err)
+
+				return
+			}
+
+			metaSchemas[dialect] = m
+		}
+	})
+
+	if errMetaSchemas != nil {
+		return nil, errMetaSchemas
+	}
+
+	m, ok := metaSchemas[d]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownDialect, d)
+	}
+
+	return m, nil
+}
+
+// metaSchemaKeywords returns the keywords a meta-schema defines: the properties of its root,
+// and of the vocabularies inlined in its definitions.
+func metaSchemaKeywords(meta map[string]any) map[string]bool {
+	keywords := map[string]bool{}
+
+	collect := func(schema any) {
+		if m, ok := schema.(map[string]any); ok {
+			if props, ok := m["properties"].(map[string]any); ok {
+				for name := range props {
+					keywords[name] = true
+				}
+			}
+		}
+	}
+
+	collect(meta)
+
+	for _, key := range []string{"$defs", "definitions"} {
+		if defs, ok := meta[key].(map[string]any); ok {
+			for _, def := range defs {
+				collect(def)
+			}
+		}
+	}
+
+	return keywords
+}
+
+// instanceError is a reason why a value does not match a schema.
+type instanceError struct {
+	tokens  []string
+	message string
+	// wrongType is set when the value is not of the type the schema expects.
+	wrongType bool
+}
+
+// instanceValidator checks decoded JSON values against a schema, in decoded form as well. It
+// implements the assertions used by the meta-schemas; references may only point within the schema.
+type instanceValidator struct {
+	root map[string]any
+}
+
+func (v *instanceValidator) validate(schema, instance any, tokens []string) []instanceError {
+	switch s := schema.(type) {
+	case bool:
+		if !s {
+			return []instanceError{{tokens: tokens, message: "no value is allowed here"}}
+		}
+
+		return nil
+
+	case map[string]any:
+		return v.validateObject(s, instance, tokens)
+
+	default:
+		return nil
+	}
+}
+
+func (v *instanceValidator) validateObject(schema map[string]any, instance any, tokens []string) []instanceError {
+	var errs []instanceError
+
+	fail := func(format string, args ...any) {
+		errs = append(errs, instanceError{tokens: tokens, message: fmt.Sprintf(format, args...)})
+	}
+
+	if ref, ok := schema["$ref"].(string); ok {
+		target, err := v.resolve(ref)
+		if err != nil {
+			fail("%v", err)
+		} else {
+			errs = append(errs, v.validate(target, instance, tokens)...)
+		}
+	}
+
+	if types := stringList(schema["type"]); len(types) > 0 && !slices.ContainsFunc(types, func(t string) bool {
+		return jsonTypeMatches(t, instance)
+	}) {
+		errs = append(errs, instanceError{
+			tokens:    tokens,
+			message:   fmt.Sprintf("expected %s, got %s", strings.Join(types, " or "), jsonTypeOf(instance)),
+			wrongType: true,
+		})
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && !slices.ContainsFunc(enum, func(e any) bool {
+		return jsonEqual(e, instance)
+	}) {
+		fail("value must be one of %s", formatJSONValues(enum))
+	}
+
+	if c, ok := schema["const"]; ok && !jsonEqual(c, instance) {
+		fail("value must be %s", formatJSONValues([]any{c}))
+	}
+
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		subschemas, ok := schema[key].([]any)
+		if !ok {
+			continue
+		}
+
+		matches := 0
+
+		// The errors of the only alternative that accepts the type of the value, if any, are more
+		// helpful than saying that none of the alternatives matched.
+		var typedErrs []instanceError
+
+		typed := 0
+
+		for _, sub := range subschemas {
+			subErrs := v.validate(sub, instance, tokens)
+			if len(subErrs) == 0 {
+				matches++
+			}
+
+			if key == "allOf" {
+				errs = append(errs, subErrs...)
+			} else if !slices.ContainsFunc(subErrs, func(e instanceError) bool {
+				return e.wrongType && len(e.tokens) == len(tokens)
+			}) {
+				typedErrs = subErrs
+				typed++
+			}
+		}
+
+		switch {
+		case key == "anyOf" && matches == 0 && typed == 1:
+			errs = append(errs, typedErrs...)
+
+		case key == "anyOf" && matches == 0:
+			fail("value does not match any of the allowed forms")
+
+		case key == "oneOf" && matches != 1:
+			fail("value must match exactly one of the allowed forms, but matches %d", matches)
+		}
+	}
+
+	if not, ok := schema["not"]; ok && len(v.validate(not, instance, tokens)) == 0 {
+		fail("value is not allowed here")
+	}
+
+	switch inst := instance.(type) {
+	case float64:
+		errs = append(errs, v.validateNumber(schema, inst, tokens)...)
+
+	case string:
+		if n, ok := jsonInt(schema["minLength"]); ok && len([]rune(inst)) < n {
+			fail("must be at least %d characters long", n)
+		}
+
+		if n, ok := jsonInt(schema["maxLength"]); ok && len([]rune(inst)) > n {
+			fail("must be at most %d characters long", n)
+		}
+
+		if pattern, ok := schema["pattern"].(string); ok {
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(inst) {
+				fail("%q does not match pattern %q", inst, pattern)
+			}
+		}
+
+	case []any:
+		errs = append(errs, v.validateArray(schema, inst, tokens)...)
+
+	case map[string]any:
+		errs = append(errs, v.validateMap(schema, inst, tokens)...)
+	}
+
+	return errs
+}
+
+func (v *instanceValidator) validateNumber(schema map[string]any, instance float64, tokens []string) []instanceError {
+	var errs []instanceError
+
+	fail := func(format string, args ...any) {
+		errs = append(errs, instanceError{tokens: tokens, message: fmt.Sprintf(format, args...)})
+	}
+
+	// Until draft 6, the exclusive bounds are booleans modifying minimum and maximum.
+	if minimum, ok := schema["minimum"].(float64); ok {
+		if exclusive, _ := schema["exclusiveMinimum"].(bool); exclusive && instance <= minimum {
+			fail("must be greater than %v", minimum)
+		} else if instance < minimum {
+			fail("must be at least %v", minimum)
+		}
+	}
+
+	if maximum, ok := schema["maximum"].(float64); ok {
+		if exclusive, _ := schema["exclusiveMaximum"].(bool); exclusive && instance >= maximum {
+			fail("must be less than %v", maximum)
+		} else if instance > maximum {
+			fail("must be at most %v", maximum)
+		}
+	}
+
+	if minimum, ok := schema["exclusiveMinimum"].(float64); ok && instance <= minimum {
+		fail("must be greater than %v", minimum)
+	}
+
+	if maximum, ok := schema["exclusiveMaximum"].(float64); ok && instance >= maximum {
+		fail("must be less than %v", maximum)
+	}
+
+	if m, ok := schema["multipleOf"].(float64); ok && m > 0 {
+		if q := instance / m; q != math.Trunc(q) {
+			fail("must be a multiple of %v", m)
+		}
+	}
+
+	return errs
+}
+
+func (v *instanceValidator) validateArray(schema map[string]any, instance []any, tokens []string) []instanceError {
+	var errs []instanceError
+
+	fail := func(format string, args ...any) {
+		errs = append(errs, instanceError{tokens: tokens, message: fmt.Sprintf(format, args...)})
+	}
+
+	if n, ok := jsonInt(schema["minItems"]); ok && len(instance) < n {
+		fail("must have at least %d items", n)
+	}
+
+	if n, ok := jsonInt(schema["maxItems"]); ok && len(instance) > n {
+		fail("must have at most %d items", n)
+	}
+
+	if unique, _ := schema["uniqueItems"].(bool); unique {
+		for i := range instance {
+			for j := i + 1; j < len(instance); j++ {
+				if jsonEqual(instance[i], instance[j]) {
+					fail("items %d and %d are equal, but items must be unique", i, j)
+				}
+			}
+		}
+	}
+
+	itemSchema := func(i int) (any, bool) {
+		if items, ok := schema["items"].([]any); ok {
+			if i < len(items) {
+				return items[i], true
+			}
+
+			additional, ok := schema["additionalItems"]
+
+			return additional, ok
+		}
+
+		items, ok := schema["items"]
+
+		return items, ok
+	}
+
+	for i, item := range instance {
+		if sub, ok := itemSchema(i); ok {
+			errs = append(errs, v.validate(sub, item, append(tokens[:len(tokens):len(tokens)], strconv.Itoa(i)))...)
+		}
+	}
+
+	return errs
+}
+
+func (v *instanceValidator) validateMap(schema map[string]any, instance map[string]any, tokens []string) []instanceError {
+	var errs []instanceError
+
+	fail := func(format string, args ...any) {
+		errs = append(errs, instanceError{tokens: tokens, message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, name := range stringList(schema["required"]) {
+		if _, ok := instance[name]; !ok {
+			fail("missing required property %q", name)
+		}
+	}
+
+	if n, ok := jsonInt(schema["minProperties"]); ok && len(instance) < n {
+		fail("must have at least %d properties", n)
+	}
+
+	if n, ok := jsonInt(schema["maxProperties"]); ok && len(instance) > n {
+		fail("must have at most %d properties", n)
+	}
+
+	properties, _ := schema["properties"].(map[string]any)
+	patternProperties, _ := schema["patternProperties"].(map[string]any)
+	additional, hasAdditional := schema["additionalProperties"]
+	propertyNames, hasPropertyNames := schema["propertyNames"]
+
+	dependencies, _ := schema["dependencies"].(map[string]any)
+	if dependentRequired, ok := schema["dependentRequired"].(map[string]any); ok {
+		dependencies = dependentRequired
+	}
+
+	names := make([]string, 0, len(instance))
+	for name := range instance {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := instance[name]
+		path := append(tokens[:len(tokens):len(tokens)], name)
+		matched := false
+
+		if hasPropertyNames {
+			for _, e := range v.validate(propertyNames, name, tokens) {
+				errs = append(errs, instanceError{tokens: e.tokens, message: fmt.Sprintf("property name %q: %s", name, e.message)})
+			}
+		}
+
+		if sub, ok := properties[name]; ok {
+			matched = true
+
+			errs = append(errs, v.validate(sub, value, path)...)
+		}
+
+		for pattern, sub := range patternProperties {
+			if re, err := regexp.Compile(pattern); err == nil && re.MatchString(name) {
+				matched = true
+
+				errs = append(errs, v.validate(sub, value, path)...)
+			}
+		}
+
+		if !matched && hasAdditional {
+			errs = append(errs, v.validate(additional, value, path)...)
+		}
+
+		for _, required := range stringList(dependencies[name]) {
+			if _, ok := instance[required]; !ok {
+				fail("property %q requires property %q", name, required)
+			}
+		}
+	}
+
+	return errs
+}
+
+// resolve returns the subschema of the root schema a reference points to.
+func (v *instanceValidator) resolve(ref string) (any, error) {
+	fragment, ok := strings.CutPrefix(ref, "#")
+	if !ok {
+		return nil, fmt.Errorf("%w: cannot resolve %q", ErrJSONPointerNotFound, ref)
+	}
+
+	tokens, err := ParseJSONPointer(fragment)
+	if err != nil {
+		return nil, err
+	}
+
+	var current any = v.root
+
+	for _, token := range tokens {
+		switch c := current.(type) {
+		case map[string]any:
+			current, ok = c[token]
+
+		case []any:
+			i, err := strconv.Atoi(token)
+			ok = err == nil && i >= 0 && i < len(c)
+
+			if ok {
+				current = c[i]
+			}
+
+		default:
+			ok = false
+		}
+
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrJSONPointerNotFound, ref)
+		}
+	}
+
+	return current, nil
+}
+
+func jsonTypeOf(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+
+	case bool:
+		return "boolean"
+
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+
+		return "number"
+
+	case string:
+		return "string"
+
+	case []any:
+		return "array"
+
+	case map[string]any:
+		return "object"
+
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func jsonTypeMatches(t string, value any) bool {
+	actual := jsonTypeOf(value)
+
+	return actual == t || (t == "number" && actual == "integer")
+}
+
+func jsonEqual(a, b any) bool {
+	return reflect.DeepEqual(a, b)
+}
+
+func jsonInt(value any) (int, bool) {
+	f, ok := value.(float64)
+
+	return int(f), ok
+}
+
+// stringList accepts a string or an array of strings.
+func stringList(value any) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+
+	case []any:
+		result := make([]string, 0, len(v))
+
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+
+		return result
+
+	default:
+		return nil
+	}
+}
+
+func formatJSONValues(values []any) string {
+	formatted := make([]string, len(values))
+
+	for i, value := range values {
+		b, err := json.Marshal(value)
+		if err != nil {
+			formatted[i] = fmt.Sprint(value)
+		} else {
+			formatted[i] = string(b)
+		}
+	}
+
+	return strings.Join(formatted, ", ")
+}
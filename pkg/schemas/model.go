@@ -24,11 +24,13 @@
 package schemas
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"reflect"
 	"slices"
 	"strings"
+	"sync"
 
 	"dario.cat/mergo"
 )
@@ -45,42 +47,65 @@ type Schema struct {
 	ID          string      `json:"$id"` // RFC draft-wright-json-schema-01, section-9.2.
 	LegacyID    string      `json:"id"`  // RFC draft-wright-json-schema-00, section 4.5.
 	Definitions Definitions `json:"$defs,omitempty"`
+
+	// OpenAPI is the version of the OpenAPI document the schema was read from, if any. The
+	// schemas of its components are then the definitions of the schema.
+	OpenAPI string `json:"-"`
+
+	// The dialect to interpret the schema with when it does not declare one with $schema.
+	defaultDialect Dialect `json:"-"`
+	// The digest of the document the schema was parsed from.
+	digest string `json:"-"`
+	// The document the schema was decoded from, as JSON, and the positions of its values, which
+	// patches apply to.
+	document          []byte              `json:"-"`
+	documentPositions map[string]Position `json:"-"`
 }
 
 // UnmarshalJSON implements json.Unmarshaler for Schema struct.
 func (s *Schema) UnmarshalJSON(data []byte) error {
-	var unmarshSchema unmarshalerSchema
+	var unmarshSchema struct {
+		ID          string      `json:"$id"`
+		LegacyID    string      `json:"id"`
+		Definitions Definitions `json:"$defs,omitempty"`
+		// Take care of legacy fields.
+		LegacyDefinitions Definitions `json:"definitions,omitempty"`
+	}
+
 	if err := json.Unmarshal(data, &unmarshSchema); err != nil {
 		return fmt.Errorf("failed to unmarshal schema: %w", err)
 	}
 
-	// Fall back to id if $id is not present.
-	if unmarshSchema.ID == "" {
-		unmarshSchema.ID = unmarshSchema.LegacyID
+	var root Type
+	if err := json.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("failed to unmarshal schema: %w", err)
 	}
 
-	// Take care of legacy fields.
-	var legacySchema struct {
-		Definitions Definitions `json:"definitions,omitempty"`
-	}
+	// Top-level definitions belong to the schema rather than its root type.
+	root.Definitions = nil
 
-	if err := json.Unmarshal(data, &legacySchema); err != nil {
-		return fmt.Errorf("failed to unmarshal schema: %w", err)
+	*s = Schema{
+		ObjectAsType:   (*ObjectAsType)(&root),
+		ID:             unmarshSchema.ID,
+		LegacyID:       unmarshSchema.LegacyID,
+		Definitions:    unmarshSchema.Definitions,
+		OpenAPI:        s.OpenAPI,
+		defaultDialect: s.defaultDialect,
 	}
 
-	if unmarshSchema.Definitions == nil && legacySchema.Definitions != nil {
-		unmarshSchema.Definitions = legacySchema.Definitions
+	// Fall back to id if $id is not present.
+	if s.ID == "" {
+		s.ID = s.LegacyID
 	}
 
-	*s = Schema(unmarshSchema)
+	if s.Definitions == nil && unmarshSchema.LegacyDefinitions != nil {
+		s.Definitions = unmarshSchema.LegacyDefinitions
+	}
 
-	return nil
+	return s.applyDialect()
 }
 
-type (
-	unmarshalerSchema Schema
-	ObjectAsType      Type
-)
+type ObjectAsType Type
 
 // TypeList is a list of type names.
 type TypeList []string
@@ -112,6 +137,15 @@ func (t *TypeList) UnmarshalJSON(value []byte) error {
 	return nil
 }
 
+// MarshalJSON implements json.Marshaler, writing a single type as a string.
+func (t TypeList) MarshalJSON() ([]byte, error) {
+	if len(t) == 1 {
+		return json.Marshal(t[0]) //nolint:wrapcheck // Marshaling a string cannot fail.
+	}
+
+	return json.Marshal([]string(t)) //nolint:wrapcheck // Marshaling strings cannot fail.
+}
+
 func (t *TypeList) Equals(b TypeList) bool {
 	if t == nil {
 		return false
@@ -155,6 +189,11 @@ type Type struct {
 	// RFC draft-wright-json-schema-00.
 	Version string `json:"$schema,omitempty"` // Section 6.1.
 	Ref     string `json:"$ref,omitempty"`    // Section 7.
+	// RFC draft-bhutton-json-schema-01, section 8.2.
+	ID            string `json:"$id,omitempty"`            // Section 8.2.1.
+	Anchor        string `json:"$anchor,omitempty"`        // Section 8.2.2.
+	DynamicRef    string `json:"$dynamicRef,omitempty"`    // Section 8.2.3.2.
+	DynamicAnchor string `json:"$dynamicAnchor,omitempty"` // Section 8.2.2.
 	// RFC draft-wright-json-schema-validation-00, section 5.
 	MultipleOf           *float64         `json:"multipleOf,omitempty"`           // Section 5.1.
 	Maximum              *float64         `json:"maximum,omitempty"`              // Section 5.2.
@@ -179,10 +218,14 @@ type Type struct {
 	Type                 TypeList         `json:"type,omitempty"`                 // Section 5.21.
 	Const                any              `json:"const,omitempty"`
 	// RFC draft-bhutton-json-schema-01, section 10.
-	AllOf []*Type `json:"allOf,omitempty"` // Section 10.2.1.1.
-	AnyOf []*Type `json:"anyOf,omitempty"` // Section 10.2.1.2.
-	OneOf []*Type `json:"oneOf,omitempty"` // Section 10.2.1.3.
-	Not   *Type   `json:"not,omitempty"`   // Section 10.2.1.4.
+	AllOf       []*Type `json:"allOf,omitempty"`       // Section 10.2.1.1.
+	AnyOf       []*Type `json:"anyOf,omitempty"`       // Section 10.2.1.2.
+	OneOf       []*Type `json:"oneOf,omitempty"`       // Section 10.2.1.3.
+	Not         *Type   `json:"not,omitempty"`         // Section 10.2.1.4.
+	If          *Type   `json:"if,omitempty"`          // Section 10.2.2.1.
+	Then        *Type   `json:"then,omitempty"`        // Section 10.2.2.2.
+	Else        *Type   `json:"else,omitempty"`        // Section 10.2.2.3.
+	PrefixItems []*Type `json:"prefixItems,omitempty"` // Section 10.3.1.1.
 	// RFC draft-wright-json-schema-validation-00, section 6, 7.
 	Title       string `json:"title,omitempty"`       // Section 6.1.
 	Description string `json:"description,omitempty"` // Section 6.1.
@@ -197,6 +240,16 @@ type Type struct {
 	Definitions      Definitions      `json:"$defs,omitempty"`
 	DependentSchemas map[string]*Type `json:"dependentSchemas,omitempty"`
 
+	// OpenAPI 3.x, section 4.8.25.
+	Discriminator *Discriminator `json:"discriminator,omitempty"`
+
+	// RFC draft-bhutton-json-schema-validation-01, section 9.
+	Deprecated bool  `json:"deprecated,omitempty"` // Section 9.3.
+	WriteOnly  bool  `json:"writeOnly,omitempty"`  // Section 9.4.
+	Examples   []any `json:"examples,omitempty"`   // Section 9.5.
+	// RFC draft-bhutton-json-schema-01, section 8.3.
+	Comment string `json:"$comment,omitempty"`
+
 	// TODO: add correct section where "readOnly" is mentioned in the spec
 	//       I'm not sure which section I should put here, but I did notice in the 2020-12 validation schema changelog,
 	//       under the "draft-handrews-json-schema-validation-00" item it mentions "readOnly" as having been moved
@@ -207,6 +260,17 @@ type Type struct {
 	// to use for the field.
 	GoJSONSchemaExtension *GoJSONSchemaExtension `json:"goJSONSchema,omitempty"` //nolint:tagliatelle // breaking change
 
+	// Extensions holds the keywords of the schema that are not otherwise part of the model, as
+	// written: vendor extensions such as x-*, and keywords of vocabularies the generator does not
+	// use. They are written back when the type is marshaled.
+	Extensions map[string]json.RawMessage `json:"-"`
+
+	// Keywords awaiting the dialect of the schema, see Schema.applyDialect.
+	dialectKeywords *dialectKeywords `json:"-"`
+
+	// Where the type was found in its document, see Schema.setPositions.
+	position Position `json:"-"`
+
 	// SubSchemaType marks the type as being a subschema type.
 	subSchemaType     SubSchemaType `json:"-"`
 	subSchemasCount   int           `json:"-"`
@@ -220,6 +284,19 @@ func (value *Type) IsEmptyObject() bool {
 	return len(value.Properties) == 0 && value.AdditionalProperties == nil
 }
 
+// IsFalse reports whether the type is the boolean schema false, which matches nothing.
+func (value *Type) IsFalse() bool {
+	if value.Not == nil {
+		return false
+	}
+
+	// Where they were found does not change what the schemas are.
+	t, not := *value, *value.Not
+	t.position, not.position = Position{}, Position{}
+
+	return reflect.DeepEqual(not, Type{}) && reflect.DeepEqual(t, Type{Not: value.Not})
+}
+
 func (value *Type) SetSubSchemaType(sst SubSchemaType) {
 	value.subSchemaType = sst
 }
@@ -244,10 +321,16 @@ func (value *Type) SetSubSchemaTypeElem() {
 	value.subSchemaTypeElem = true
 }
 
+// ConvertAllRefs prefixes the references to fragments made by the type and its subschemas with the
+// given path, so they keep pointing into its document once the type is moved out of it.
 func (value *Type) ConvertAllRefs(absolutePath string) error {
-	val := reflect.ValueOf(value).Elem()
+	return Walk(&Schema{ObjectAsType: (*ObjectAsType)(value)}, func(node *WalkNode) error {
+		if strings.HasPrefix(node.Type.Ref, "#") {
+			node.Type.Ref = absolutePath + node.Type.Ref
+		}
 
-	return updateAllRefsValues(&val, absolutePath)
+		return nil
+	})
 }
 
 // UnmarshalJSON accepts booleans as schemas where `true` is equivalent to `{}`
@@ -265,26 +348,48 @@ func (value *Type) UnmarshalJSON(raw []byte) error {
 	}
 
 	var obj ObjectAsType
-	if err := json.Unmarshal(raw, &obj); err != nil {
-		return fmt.Errorf("failed to unmarshal type: %w", err)
-	}
 
-	// Take care of legacy fields from older RFC versions.
-	legacyObj := struct {
+	// Take care of legacy fields from older RFC versions, and of keywords whose
+	// meaning depends on the dialect, which is only known once the whole schema is read.
+	aux := struct {
+		*ObjectAsType
+		// RFC draft-wright-json-schema-00, section 4.5.
+		ID any `json:"id,omitempty"`
 		// RFC draft-wright-json-schema-validation-00, section 5.
-		Dependencies map[string]*Type `json:"dependencies,omitempty"`
-		Definitions  Definitions      `json:"definitions,omitempty"` // Section 5.26.
-	}{}
-	if err := json.Unmarshal(raw, &legacyObj); err != nil {
+		Items        json.RawMessage            `json:"items,omitempty"`        // Section 5.9.
+		Dependencies map[string]json.RawMessage `json:"dependencies,omitempty"` // Section 5.19.
+		Definitions  Definitions                `json:"definitions,omitempty"`  // Section 5.26.
+		// RFC draft-handrews-json-schema-02, section 8.2.4.2.
+		RecursiveRef string `json:"$recursiveRef,omitempty"`
+	}{ObjectAsType: &obj}
+	if err := json.Unmarshal(raw, &aux); err != nil {
 		return fmt.Errorf("failed to unmarshal type: %w", err)
 	}
 
-	if legacyObj.Definitions != nil && obj.Definitions == nil {
-		obj.Definitions = legacyObj.Definitions
+	if aux.Definitions != nil && obj.Definitions == nil {
+		obj.Definitions = aux.Definitions
+	}
+
+	if id, ok := aux.ID.(string); ok && obj.ID == "" {
+		obj.ID = id
+	}
+
+	kw, err := newDialectKeywords(aux.Items, aux.Dependencies)
+	if err != nil {
+		return err
+	}
+
+	if kw.tupleItems == nil && len(aux.Items) > 0 {
+		if err := json.Unmarshal(aux.Items, &obj.Items); err != nil {
+			return fmt.Errorf("failed to unmarshal type: %w", err)
+		}
 	}
 
-	if legacyObj.Dependencies != nil && obj.DependentSchemas == nil {
-		obj.DependentSchemas = legacyObj.Dependencies
+	kw.recursiveRef = aux.RecursiveRef
+	obj.dialectKeywords = kw
+
+	if obj.Extensions, err = unknownKeywords(raw); err != nil {
+		return err
 	}
 
 	if len(obj.Type) == 0 && (len(obj.Properties) > 0 || obj.AdditionalProperties != nil) {
@@ -296,6 +401,87 @@ func (value *Type) UnmarshalJSON(raw []byte) error {
 	return nil
 }
 
+// MarshalJSON implements json.Marshaler, writing the extensions of the type along with its keywords.
+func (value Type) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(ObjectAsType(value))
+	if err != nil || len(value.Extensions) == 0 {
+		return data, err //nolint:wrapcheck // Errors are those of the keywords, to be wrapped by the caller.
+	}
+
+	names := make([]string, 0, len(value.Extensions))
+	for name := range value.Extensions {
+		if !knownKeywords()[name] {
+			names = append(names, name)
+		}
+	}
+
+	slices.Sort(names)
+
+	buf := bytes.NewBuffer(bytes.TrimSuffix(data, []byte("}")))
+
+	for _, name := range names {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+
+		key, _ := json.Marshal(name)
+		buf.Write(key)
+		buf.WriteByte(':')
+
+		if err := json.Compact(buf, value.Extensions[name]); err != nil {
+			return nil, fmt.Errorf("failed to marshal extension %s: %w", name, err)
+		}
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// knownKeywords returns the keywords read into the fields of Type, including the legacy ones.
+var knownKeywords = sync.OnceValue(func() map[string]bool {
+	known := map[string]bool{
+		"id":            true,
+		"items":         true,
+		"dependencies":  true,
+		"definitions":   true,
+		"$recursiveRef": true,
+	}
+
+	typ := reflect.TypeFor[ObjectAsType]()
+	for i := range typ.NumField() {
+		if name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ","); name != "" && name != "-" {
+			known[name] = true
+		}
+	}
+
+	return known
+})
+
+// unknownKeywords returns the keywords of a schema object that knownKeywords does not hold.
+func unknownKeywords(raw []byte) (map[string]json.RawMessage, error) {
+	var keywords map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &keywords); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal type: %w", err)
+	}
+
+	var unknown map[string]json.RawMessage
+
+	for name, value := range keywords {
+		if knownKeywords()[name] {
+			continue
+		}
+
+		if unknown == nil {
+			unknown = map[string]json.RawMessage{}
+		}
+
+		unknown[name] = value
+	}
+
+	return unknown, nil
+}
+
 func AllOf(types []*Type, baseType *Type) (*Type, error) {
 	typ, err := MergeTypes(types, baseType)
 	if err != nil {
@@ -372,57 +558,6 @@ func mergeRequiredUnion(types []*Type, baseType *Type) []string {
 	return required
 }
 
-func updateAllRefsValues(structValue *reflect.Value, refPath string) error {
-	switch structValue.Kind() { //nolint:exhaustive
-	case reflect.Struct:
-		for i := range structValue.NumField() {
-			field := structValue.Field(i)
-			name := structValue.Type().Field(i).Name
-
-			switch field.Kind() { //nolint:exhaustive
-			case reflect.String:
-				fieldVal := field.String()
-				if name == "Ref" && fieldVal != "" && field.CanSet() {
-					if strings.HasPrefix(fieldVal, "#") {
-						field.SetString(refPath + fieldVal)
-					}
-				}
-
-			default:
-				if err := updateAllRefsValues(&field, refPath); err != nil {
-					return fmt.Errorf("struct error: %w", err)
-				}
-			}
-		}
-
-	case reflect.Ptr: //nolint:govet // typical inlining false positive
-		elem := structValue.Elem()
-		if !structValue.IsNil() {
-			if err := updateAllRefsValues(&elem, refPath); err != nil {
-				return fmt.Errorf("ptr error: %w", err)
-			}
-		}
-
-	case reflect.Map:
-		for _, key := range structValue.MapKeys() {
-			val := structValue.MapIndex(key)
-			if err := updateAllRefsValues(&val, refPath); err != nil {
-				return fmt.Errorf("map error: %w", err)
-			}
-		}
-
-	case reflect.Slice, reflect.Array:
-		for i := range structValue.Len() {
-			field := structValue.Index(i)
-			if err := updateAllRefsValues(&field, refPath); err != nil {
-				return fmt.Errorf("slice error: %w", err)
-			}
-		}
-	}
-
-	return nil
-}
-
 type typeListTransformer struct{}
 
 func (t typeListTransformer) Transformer(typ reflect.Type) func(dst, src reflect.Value) error {
@@ -435,6 +570,13 @@ func (t typeListTransformer) Transformer(typ reflect.Type) func(dst, src reflect
 	return nil
 }
 
+// Discriminator is the OpenAPI hint telling which schema of a oneOf or anyOf applies,
+// based on the value of one of its properties.
+type Discriminator struct {
+	PropertyName string            `json:"propertyName"`
+	Mapping      map[string]string `json:"mapping,omitempty"`
+}
+
 type GoJSONSchemaExtension struct {
 	Type       *string           `json:"type,omitempty"`
 	Identifier *string           `json:"identifier,omitempty"`
@@ -0,0 +1,63 @@
+package schemas_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/atombender/go-jsonschema/pkg/schemas"
+)
+
+func TestTypeExtensions(t *testing.T) {
+	t.Parallel()
+
+	schema, err := schemas.FromJSONReader(strings.NewReader(`{
+		"$comment": "A pet.",
+		"x-owner": {"team": "pets"},
+		"type": "object",
+		"properties": {
+			"name": {
+				"type": "string",
+				"deprecated": true,
+				"writeOnly": true,
+				"examples": ["Rex"],
+				"x-go-validate": "alpha"
+			},
+			"tags": {
+				"type": "array",
+				"contains": {"const": "good"},
+				"minContains": 1
+			}
+		}
+	}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "A pet.", schema.Comment)
+	assert.Equal(t, map[string]json.RawMessage{"x-owner": json.RawMessage(`{"team": "pets"}`)}, schema.Extensions)
+
+	name := schema.Properties["name"]
+	assert.True(t, name.Deprecated)
+	assert.True(t, name.WriteOnly)
+	assert.Equal(t, []any{"Rex"}, name.Examples)
+	assert.Equal(t, map[string]json.RawMessage{"x-go-validate": json.RawMessage(`"alpha"`)}, name.Extensions)
+
+	tags := schema.Properties["tags"]
+	assert.ElementsMatch(t, []string{"contains", "minContains"}, keys(tags.Extensions))
+
+	data, err := json.Marshal(name)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "string",
+		"deprecated": true,
+		"writeOnly": true,
+		"examples": ["Rex"],
+		"x-go-validate": "alpha"
+	}`, string(data))
+
+	data, err = json.Marshal(&schemas.Type{Extensions: map[string]json.RawMessage{"x-b": json.RawMessage(`2`), "x-a": json.RawMessage(`1`)}})
+	require.NoError(t, err)
+	assert.Equal(t, `{"x-a":1,"x-b":2}`, string(data))
+}
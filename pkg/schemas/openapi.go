@@ -0,0 +1,228 @@
+package schemas
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var ErrUnsupportedOpenAPIVersion = errors.New("unsupported OpenAPI version")
+
+// Where an OpenAPI document declares its schemas, which the model holds as definitions.
+var openAPISchemasTokens = []string{"components", "schemas"}
+
+// openAPIDocument is the part of an OpenAPI 3.x document that schemas are generated from.
+type openAPIDocument struct {
+	OpenAPI           string `json:"openapi"`
+	JSONSchemaDialect string `json:"jsonSchemaDialect"`
+	Components        struct {
+		Schemas map[string]any `json:"schemas"`
+	} `json:"components"`
+}
+
+// fromOpenAPI turns an OpenAPI 3.x document into a schema document declaring every entry of
+// components/schemas as a definition, with the OpenAPI 3.0 keywords translated into their JSON
+// Schema equivalents. The positions of the values of the document are translated along. It
+// returns nil for documents that are not OpenAPI documents.
+func fromOpenAPI(data []byte, positions map[string]Position) (*openAPIConversion, error) {
+	var probe struct {
+		OpenAPI *string `json:"openapi"`
+	}
+
+	if json.Unmarshal(data, &probe) != nil || probe.OpenAPI == nil {
+		return nil, nil //nolint:nilnil // Not an OpenAPI document.
+	}
+
+	var doc openAPIDocument
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal OpenAPI document: %w", err)
+	}
+
+	var dialect Dialect
+
+	switch {
+	case strings.HasPrefix(doc.OpenAPI, "3.0."):
+		// Schema objects of OpenAPI 3.0 are an extended subset of draft-wright-json-schema-00.
+		dialect = DialectDraft04
+
+		for _, schema := range doc.Components.Schemas {
+			translateOpenAPI30(schema)
+		}
+
+	case strings.HasPrefix(doc.OpenAPI, "3.1."):
+		dialect = DialectDraft2020
+
+		if d, err := ParseDialect(doc.JSONSchemaDialect); err == nil && d != DialectUnknown {
+			dialect = d
+		}
+
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedOpenAPIVersion, doc.OpenAPI)
+	}
+
+	for _, schema := range doc.Components.Schemas {
+		translateOpenAPIExtensions(schema)
+	}
+
+	converted, err := json.Marshal(map[string]any{"$defs": doc.Components.Schemas})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal OpenAPI schemas: %w", err)
+	}
+
+	prefix := FormatJSONPointer(openAPISchemasTokens)
+	convertedPositions := map[string]Position{"": positions[""]}
+
+	for pointer, pos := range positions {
+		if rest, ok := strings.CutPrefix(pointer, prefix); ok && (rest == "" || rest[0] == '/') {
+			// Errors and positions still refer to the document as written.
+			pos.Pointer = pointer
+			convertedPositions["/$defs"+rest] = pos
+		}
+	}
+
+	return &openAPIConversion{
+		version:   doc.OpenAPI,
+		dialect:   dialect,
+		data:      converted,
+		positions: convertedPositions,
+	}, nil
+}
+
+// openAPIConversion is the schema document equivalent to an OpenAPI document.
+type openAPIConversion struct {
+	version   string
+	dialect   Dialect
+	data      []byte
+	positions map[string]Position
+}
+
+// translateOpenAPI30 rewrites the keywords OpenAPI 3.0 adds to JSON Schema, in a schema and all
+// of its subschemas, into their JSON Schema equivalents.
+func translateOpenAPI30(schema any) {
+	obj, ok := schema.(map[string]any)
+	if !ok {
+		return
+	}
+
+	// A type is nullable through a keyword of its own, rather than the null type.
+	if nullable, _ := obj["nullable"].(bool); nullable {
+		switch typ := obj["type"].(type) {
+		case string:
+			obj["type"] = []any{typ, TypeNameNull}
+
+		case []any:
+			if !slices.Contains(typ, any(TypeNameNull)) {
+				obj["type"] = append(typ, TypeNameNull)
+			}
+		}
+	}
+
+	delete(obj, "nullable")
+
+	for _, sub := range rawSubschemas(obj) {
+		translateOpenAPI30(sub.schema)
+	}
+}
+
+// Go specific extensions of OpenAPI documents, as used by OpenAPI code generators, and the
+// goJSONSchema extension keywords they stand for.
+var openAPIGoExtensions = map[string]string{
+	"x-go-type":                       "type",
+	"x-go-type-import":                "imports",
+	"x-go-name":                       "identifier",
+	"x-go-type-skip-optional-pointer": "pointer",
+	"x-go-nillable":                   "nillable",
+	"x-go-extra-tags":                 "extraTags",
+	"x-oapi-codegen-extra-tags":       "extraTags",
+}
+
+// translateOpenAPIExtensions rewrites the Go specific extensions of a schema and all of its
+// subschemas into the goJSONSchema extension, whose own settings take precedence. Discriminator
+// mappings naming a schema of the document are turned into references to it.
+func translateOpenAPIExtensions(schema any) {
+	obj, ok := schema.(map[string]any)
+	if !ok {
+		return
+	}
+
+	ext, _ := obj["goJSONSchema"].(map[string]any)
+
+	for _, name := range sortedKeys(openAPIGoExtensions) {
+		value, ok := obj[name]
+		if !ok {
+			continue
+		}
+
+		keyword := openAPIGoExtensions[name]
+
+		switch name {
+		case "x-go-type-import":
+			value = openAPIGoImports(value)
+
+		case "x-go-type-skip-optional-pointer":
+			skip, _ := value.(bool)
+			value = !skip
+		}
+
+		if ext == nil {
+			ext = map[string]any{}
+		}
+
+		if _, declared := ext[keyword]; !declared && value != nil {
+			ext[keyword] = value
+		}
+	}
+
+	if ext != nil {
+		obj["goJSONSchema"] = ext
+	}
+
+	if discriminator, ok := obj["discriminator"].(map[string]any); ok {
+		mapping, _ := discriminator["mapping"].(map[string]any)
+
+		for value, target := range mapping {
+			if name, ok := target.(string); ok && !strings.ContainsAny(name, "#/.") {
+				mapping[value] = "#" + FormatJSONPointer(append(openAPISchemasTokens[:2:2], name))
+			}
+		}
+	}
+
+	for _, sub := range rawSubschemas(obj) {
+		translateOpenAPIExtensions(sub.schema)
+	}
+}
+
+// openAPIGoImports returns the import paths of an x-go-type-import extension, which is either a
+// path or an object with a path and an optional name.
+func openAPIGoImports(value any) []any {
+	switch v := value.(type) {
+	case string:
+		return []any{v}
+
+	case map[string]any:
+		if p, ok := v["path"].(string); ok {
+			return []any{p}
+		}
+	}
+
+	return nil
+}
+
+// ModelTokens translates the JSON Pointer tokens of a location in the document the schema was
+// read from into those of the model. They only differ for OpenAPI documents, whose schemas are
+// held as definitions.
+func (s *Schema) ModelTokens(tokens []string) []string {
+	if s.OpenAPI == "" || len(tokens) < len(openAPISchemasTokens) ||
+		tokens[0] != openAPISchemasTokens[0] || tokens[1] != openAPISchemasTokens[1] {
+		return tokens
+	}
+
+	return append([]string{"$defs"}, tokens[len(openAPISchemasTokens):]...)
+}
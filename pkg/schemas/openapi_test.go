@@ -0,0 +1,136 @@
+package schemas_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/atombender/go-jsonschema/pkg/schemas"
+)
+
+func TestOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	const document = `openapi: 3.0.3
+info:
+  title: Pets
+  version: 1.0.0
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        tag:
+          type: string
+          nullable: true
+        owner:
+          $ref: '#/components/schemas/Owner'
+        chip:
+          type: string
+          x-go-type: netip.Addr
+          x-go-type-import:
+            path: net/netip
+          x-go-type-skip-optional-pointer: true
+          goJSONSchema:
+            type: string
+    Owner:
+      type: object
+      properties:
+        name:
+          type: string
+    Animal:
+      oneOf:
+        - $ref: '#/components/schemas/Pet'
+      discriminator:
+        propertyName: kind
+        mapping:
+          pet: Pet
+`
+
+	schema, err := schemas.FromYAMLReader(strings.NewReader(document))
+	require.NoError(t, err)
+
+	assert.Equal(t, "3.0.3", schema.OpenAPI)
+	assert.Equal(t, schemas.DialectDraft04, schema.Dialect())
+	assert.ElementsMatch(t, []string{"Animal", "Owner", "Pet"}, keys(schema.Definitions))
+
+	pet := schema.Definitions["Pet"]
+	assert.Equal(t, schemas.TypeList{"string", "null"}, pet.Properties["tag"].Type)
+
+	chip := pet.Properties["chip"].GoJSONSchemaExtension
+	require.NotNil(t, chip)
+	assert.Equal(t, "string", *chip.Type)
+	assert.Equal(t, []string{"net/netip"}, chip.Imports)
+	assert.False(t, *chip.Pointer)
+
+	assert.Equal(t, map[string]string{"pet": "#/components/schemas/Pet"},
+		schema.Definitions["Animal"].Discriminator.Mapping)
+
+	owner, err := schema.ResolveJSONPointer([]string{"components", "schemas", "Owner"})
+	require.NoError(t, err)
+	assert.Same(t, schema.Definitions["Owner"], owner)
+
+	assert.Equal(t, "13:9 (#/components/schemas/Pet/properties/owner)", pet.Properties["owner"].Position().String())
+}
+
+func TestOpenAPIVersions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc     string
+		document string
+		want     schemas.Dialect
+		wantErr  error
+	}{
+		{
+			desc:     "3.1",
+			document: `{"openapi": "3.1.0", "components": {"schemas": {"A": {"type": "string"}}}}`,
+			want:     schemas.DialectDraft2020,
+		},
+		{
+			desc: "3.1 with a JSON Schema dialect",
+			document: `{
+				"openapi": "3.1.0",
+				"jsonSchemaDialect": "http://json-schema.org/draft-07/schema#",
+				"components": {"schemas": {"A": {"type": "string"}}}
+			}`,
+			want: schemas.DialectDraft07,
+		},
+		{
+			desc:     "no schemas",
+			document: `{"openapi": "3.1.0", "paths": {}}`,
+			want:     schemas.DialectDraft2020,
+		},
+		{
+			desc:     "2.0",
+			document: `{"openapi": "2.0", "components": {}}`,
+			wantErr:  schemas.ErrUnsupportedOpenAPIVersion,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			schema, err := schemas.FromJSONReader(strings.NewReader(tC.document))
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tC.want, schema.Dialect())
+		})
+	}
+}
+
+func keys[V any](m map[string]V) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+
+	return result
+}
@@ -1,10 +1,18 @@
 package schemas
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"os"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
 
 	"github.com/goccy/go-yaml"
 
@@ -21,16 +29,111 @@ func FromJSONFile(fileName string) (*Schema, error) {
 		_ = f.Close()
 	}()
 
-	return FromJSONReader(f)
+	schema, err := FromJSONReader(f)
+	if err != nil {
+		setErrorSource(err, fileName)
+
+		return nil, err
+	}
+
+	schema.setSource(fileName)
+
+	return schema, nil
 }
 
 func FromJSONReader(r io.Reader) (*Schema, error) {
-	var schema Schema
-	if err := json.NewDecoder(r).Decode(&schema); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	return FromJSONReaderWithDialect(r, DialectUnknown)
+}
+
+// FromJSONReaderWithDialect is like FromJSONReader, but interprets a schema that does not
+// declare its dialect with $schema according to the given default dialect.
+func FromJSONReaderWithDialect(r io.Reader, defaultDialect Dialect) (*Schema, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSON: %w", err)
 	}
 
-	return &schema, nil
+	// Positions are only needed for errors if the document is not valid JSON to begin with.
+	positions, _ := jsonPositions(data)
+
+	schema, err := decodeSchema(data, positions, defaultDialect)
+	if err != nil {
+		return nil, err
+	}
+
+	schema.digest = contentDigest(data)
+
+	return schema, nil
+}
+
+func FromJSONCFile(fileName string) (*Schema, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+
+	defer func() {
+		_ = f.Close()
+	}()
+
+	schema, err := FromJSONCReader(f)
+	if err != nil {
+		setErrorSource(err, fileName)
+
+		return nil, err
+	}
+
+	schema.setSource(fileName)
+
+	return schema, nil
+}
+
+// FromJSONCReader reads a schema written in JSONC or JSON5: JSON with comments and trailing
+// commas, and for JSON5 also single-quoted strings, unquoted keys and more forms of numbers.
+func FromJSONCReader(r io.Reader) (*Schema, error) {
+	return FromJSONCReaderWithDialect(r, DialectUnknown)
+}
+
+// FromJSONCReaderWithDialect is like FromJSONCReader, but interprets a schema that does not
+// declare its dialect with $schema according to the given default dialect.
+func FromJSONCReaderWithDialect(r io.Reader, defaultDialect Dialect) (*Schema, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSONC: %w", err)
+	}
+
+	value, origins, err := jsoncToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// The positions are those of the JSONC document, while decoding works on its JSON equivalent.
+	lines := newLineIndex(data)
+	position := func(offset int) Position {
+		return lines.position(origins[min(max(offset, 0), len(origins)-1)])
+	}
+
+	if !json.Valid(value) {
+		var syntaxErr *json.SyntaxError
+		if err := json.Unmarshal(value, new(any)); errors.As(err, &syntaxErr) {
+			// The offset is just past the offending character.
+			return nil, &PositionError{
+				Position: position(int(syntaxErr.Offset) - 1),
+				Err:      fmt.Errorf("failed to unmarshal JSONC: %w", err),
+			}
+		}
+	}
+
+	positions, _ := valuePositions(value, position)
+
+	schema, err := decodeSchema(value, positions, defaultDialect)
+	if err != nil {
+		return nil, err
+	}
+
+	schema.digest = contentDigest(data)
+
+	return schema, nil
 }
 
 func FromYAMLFile(fileName string) (*Schema, error) {
@@ -43,14 +146,34 @@ func FromYAMLFile(fileName string) (*Schema, error) {
 		_ = f.Close()
 	}()
 
-	return FromYAMLReader(f)
+	schema, err := FromYAMLReader(f)
+	if err != nil {
+		setErrorSource(err, fileName)
+
+		return nil, err
+	}
+
+	schema.setSource(fileName)
+
+	return schema, nil
 }
 
 func FromYAMLReader(r io.Reader) (*Schema, error) {
+	return FromYAMLReaderWithDialect(r, DialectUnknown)
+}
+
+// FromYAMLReaderWithDialect is like FromYAMLReader, but interprets a schema that does not
+// declare its dialect with $schema according to the given default dialect.
+func FromYAMLReaderWithDialect(r io.Reader, defaultDialect Dialect) (*Schema, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read YAML: %w", err)
+	}
+
 	// Marshal to JSON first because YAML decoder doesn't understand JSON tags.
 	var m map[string]any
 
-	if err := yaml.NewDecoder(r).Decode(&m); err != nil {
+	if err := yaml.NewDecoder(bytes.NewReader(data)).Decode(&m); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal YAML: %w", err)
 	}
 
@@ -61,11 +184,438 @@ func FromYAMLReader(r io.Reader) (*Schema, error) {
 		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
 	}
 
-	var schema Schema
+	// The positions are those of the YAML document, while decoding works on its JSON equivalent.
+	positions, _ := yamlPositions(data)
+
+	schema, err := decodeSchema(value, positions, defaultDialect)
+	if err != nil {
+		return nil, err
+	}
+
+	schema.digest = contentDigest(data)
+
+	return schema, nil
+}
+
+// decodeSchema decodes a schema document given as JSON, or an OpenAPI document holding schemas,
+// given the positions of the values of the document.
+func decodeSchema(data []byte, positions map[string]Position, defaultDialect Dialect) (*Schema, error) {
+	document, documentPositions := data, positions
+
+	openAPI, err := fromOpenAPI(data, positions)
+	if err != nil {
+		return nil, err
+	}
+
+	if openAPI != nil {
+		data, positions, defaultDialect = openAPI.data, openAPI.positions, openAPI.dialect
+	}
+
+	schema := Schema{defaultDialect: defaultDialect}
+	if err := json.NewDecoder(bytes.NewReader(data)).Decode(&schema); err != nil {
+		return nil, locateParseError(data, positions, fmt.Errorf("failed to unmarshal JSON: %w", err))
+	}
 
-	if err = json.Unmarshal(value, &schema); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	if openAPI != nil {
+		schema.OpenAPI = openAPI.version
 	}
 
+	schema.document, schema.documentPositions = document, documentPositions
+	schema.setPositions(positions)
+
 	return &schema, nil
 }
+
+var ErrUnknownFormat = errors.New("unknown format")
+
+// Format is the syntax a schema document is written in.
+type Format string
+
+const (
+	// FormatUnknown is told from the document itself, with SniffFormat.
+	FormatUnknown Format = ""
+	FormatJSON    Format = "json"
+	FormatYAML    Format = "yaml"
+	// FormatJSONC is JSONC or JSON5, both read by FromJSONCReader.
+	FormatJSONC Format = "jsonc"
+)
+
+// ParseFormat accepts a format name: "json", "yaml", "jsonc" or "json5", and "auto" or nothing
+// for FormatUnknown.
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "", "auto":
+		return FormatUnknown, nil
+
+	case "json":
+		return FormatJSON, nil
+
+	case "yaml", "yml":
+		return FormatYAML, nil
+
+	case "jsonc", "json5":
+		return FormatJSONC, nil
+
+	default:
+		return FormatUnknown, fmt.Errorf("%w: %q", ErrUnknownFormat, name)
+	}
+}
+
+// SniffFormat tells the format of a document from its content: JSON if it is valid JSON, JSONC if
+// it starts as JSON or with a comment, and YAML otherwise.
+func SniffFormat(data []byte) Format {
+	if json.Valid(data) {
+		return FormatJSON
+	}
+
+	data = bytes.TrimLeftFunc(data, isJSONCSpace)
+
+	for _, prefix := range []string{"{", "[", "//", "/*"} {
+		if bytes.HasPrefix(data, []byte(prefix)) {
+			return FormatJSONC
+		}
+	}
+
+	return FormatYAML
+}
+
+// extensionFormat returns the format of a file by its extension, given the extensions of YAML
+// and JSONC files. The format of files with other extensions than .json is unknown.
+func extensionFormat(ext string, yamlExtensions, jsoncExtensions map[string]bool) Format {
+	switch {
+	case yamlExtensions[ext]:
+		return FormatYAML
+
+	case jsoncExtensions[ext]:
+		return FormatJSONC
+
+	case ext == ".json":
+		return FormatJSON
+
+	default:
+		return FormatUnknown
+	}
+}
+
+// FromReaderWithDialect reads a schema document written in the given format, or in the format
+// told by SniffFormat if it is FormatUnknown. A schema that does not declare its dialect with
+// $schema is interpreted according to the given default dialect.
+func FromReaderWithDialect(r io.Reader, format Format, defaultDialect Dialect) (*Schema, error) {
+	if format == FormatUnknown {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read schema: %w", err)
+		}
+
+		r, format = bytes.NewReader(data), SniffFormat(data)
+	}
+
+	switch format {
+	case FormatYAML:
+		return FromYAMLReaderWithDialect(r, defaultDialect)
+
+	case FormatJSONC:
+		return FromJSONCReaderWithDialect(r, defaultDialect)
+
+	default:
+		return FromJSONReaderWithDialect(r, defaultDialect)
+	}
+}
+
+var errInvalidJSONC = errors.New("invalid JSONC")
+
+// jsoncToJSON translates a JSONC or JSON5 document into JSON. Comments and trailing commas are
+// dropped, while single-quoted strings, unquoted keys and numbers that JSON does not allow are
+// rewritten. Anything else is left for the JSON decoder to reject. It also returns, for every
+// byte of the JSON, the offset in the document of the token it comes from, followed by the
+// length of the document.
+func jsoncToJSON(data []byte) ([]byte, []int, error) {
+	var (
+		out     = make([]byte, 0, len(data))
+		origins = make([]int, 0, len(data)+1)
+		// comma is the offset of a comma not yet written, as it is dropped if it is trailing.
+		comma = -1
+	)
+
+	emit := func(s string, at int) {
+		out = append(out, s...)
+		for range len(s) {
+			origins = append(origins, at)
+		}
+	}
+
+	flush := func() {
+		if comma >= 0 {
+			emit(",", comma)
+			comma = -1
+		}
+	}
+
+	fail := func(at int, format string, args ...any) error {
+		return &PositionError{
+			Position: newLineIndex(data).position(at),
+			Err:      fmt.Errorf("%w: %s", errInvalidJSONC, fmt.Sprintf(format, args...)),
+		}
+	}
+
+	for i := 0; i < len(data); {
+		c := data[i]
+
+		switch {
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' && data[i] != '\r' {
+				i++
+			}
+
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			end := bytes.Index(data[i+2:], []byte("*/"))
+			if end < 0 {
+				return nil, nil, fail(i, "unterminated comment")
+			}
+
+			i += end + 4
+
+		case c == ',':
+			flush()
+
+			comma = i
+			i++
+
+		case c == '}' || c == ']':
+			comma = -1
+
+			emit(string(c), i)
+			i++
+
+		case c == '{' || c == '[' || c == ':':
+			flush()
+			emit(string(c), i)
+			i++
+
+		case c == '"' || c == '\'':
+			flush()
+
+			s, n, err := jsoncString(data[i:])
+			if err != nil {
+				return nil, nil, fail(i, "%v", err)
+			}
+
+			emit(s, i)
+			i += n
+
+		default:
+			if r, size := utf8.DecodeRune(data[i:]); isJSONCSpace(r) {
+				i += size
+
+				continue
+			}
+
+			flush()
+
+			n := jsoncWordLength(data[i:])
+			if n == 0 {
+				// Not valid anywhere, for the JSON decoder to report.
+				emit(string(c), i)
+				i++
+
+				continue
+			}
+
+			s, err := jsoncWord(string(data[i : i+n]))
+			if err != nil {
+				return nil, nil, fail(i, "%v", err)
+			}
+
+			emit(s, i)
+			i += n
+		}
+	}
+
+	flush()
+
+	return out, append(origins, len(data)), nil
+}
+
+// isJSONCSpace tells whether a character is white space in JSON5, which has more than JSON.
+func isJSONCSpace(r rune) bool {
+	return unicode.IsSpace(r) || r == '\uFEFF'
+}
+
+// jsoncWordLength returns the length of the number, literal or unquoted key data starts with.
+func jsoncWordLength(data []byte) int {
+	n := 0
+
+	for n < len(data) {
+		r, size := utf8.DecodeRune(data[n:])
+		if isJSONCSpace(r) || strings.ContainsRune(",:{}[]\"'/", r) {
+			break
+		}
+
+		n += size
+	}
+
+	return n
+}
+
+var errNotRepresentable = errors.New("not representable in JSON")
+
+// jsoncWord translates a number, a literal or an unquoted key to JSON.
+func jsoncWord(word string) (string, error) {
+	switch word {
+	case "true", "false", "null":
+		return word, nil
+	}
+
+	if strings.ContainsAny(word[:1], "0123456789.+-") {
+		return jsoncNumber(word)
+	}
+
+	if word == "Infinity" || word == "NaN" {
+		return "", fmt.Errorf("%w: %s", errNotRepresentable, word)
+	}
+
+	for i, r := range word {
+		if !(r == '$' || r == '_' || unicode.IsLetter(r) || i > 0 && (unicode.IsDigit(r) || unicode.Is(unicode.Mn, r))) {
+			// Not an identifier, for the JSON decoder to report.
+			return word, nil
+		}
+	}
+
+	return strconv.Quote(word), nil
+}
+
+// jsoncNumber translates a JSON5 number to JSON, which has no hexadecimal numbers, no leading
+// plus sign, and no leading or trailing decimal point.
+func jsoncNumber(word string) (string, error) {
+	sign := ""
+
+	switch word[0] {
+	case '-':
+		sign, word = "-", word[1:]
+
+	case '+':
+		word = word[1:]
+	}
+
+	if word == "Infinity" || word == "NaN" {
+		return "", fmt.Errorf("%w: %s%s", errNotRepresentable, sign, word)
+	}
+
+	if hex, ok := strings.CutPrefix(strings.ToLower(word), "0x"); ok {
+		if n, ok := new(big.Int).SetString(hex, 16); ok {
+			return sign + n.String(), nil
+		}
+
+		return sign + word, nil
+	}
+
+	mantissa, exponent := word, ""
+	if i := strings.IndexAny(word, "eE"); i >= 0 {
+		mantissa, exponent = word[:i], word[i:]
+	}
+
+	if strings.HasPrefix(mantissa, ".") {
+		mantissa = "0" + mantissa
+	}
+
+	return sign + strings.TrimSuffix(mantissa, ".") + exponent, nil
+}
+
+var errUnterminatedString = errors.New("unterminated string")
+
+// jsoncString translates the single- or double-quoted string data starts with to JSON, and
+// returns the length of the string in data.
+func jsoncString(data []byte) (string, int, error) {
+	quote := data[0]
+
+	var s []rune
+
+	for i := 1; i < len(data); {
+		c := data[i]
+
+		switch {
+		case c == quote:
+			return quoteJSON(string(s)), i + 1, nil
+
+		case c == '\n' || c == '\r':
+			return "", 0, errUnterminatedString
+
+		case c != '\\':
+			r, size := utf8.DecodeRune(data[i:])
+			s = append(s, r)
+			i += size
+
+			continue
+		}
+
+		if i+1 >= len(data) {
+			return "", 0, errUnterminatedString
+		}
+
+		r, size := utf8.DecodeRune(data[i+1:])
+		i += 1 + size
+
+		switch r {
+		case 'b':
+			s = append(s, '\b')
+		case 'f':
+			s = append(s, '\f')
+		case 'n':
+			s = append(s, '\n')
+		case 'r':
+			s = append(s, '\r')
+		case 't':
+			s = append(s, '\t')
+		case 'v':
+			s = append(s, '\v')
+		case '0':
+			s = append(s, 0)
+		case 'x', 'u':
+			digits := 2
+			if r == 'u' {
+				digits = 4
+			}
+
+			if i+digits > len(data) {
+				return "", 0, errUnterminatedString
+			}
+
+			code, err := strconv.ParseUint(string(data[i:i+digits]), 16, 32)
+			if err != nil {
+				return "", 0, fmt.Errorf("invalid escape sequence: %w", err)
+			}
+
+			i += digits
+
+			// A surrogate pair is written as two escape sequences.
+			if n := len(s); n > 0 && utf16.IsSurrogate(s[n-1]) && utf16.IsSurrogate(rune(code)) {
+				s[n-1] = utf16.DecodeRune(s[n-1], rune(code))
+			} else {
+				s = append(s, rune(code))
+			}
+		case '\r':
+			// A line continuation, of a line ending with CRLF or CR.
+			if i < len(data) && data[i] == '\n' {
+				i++
+			}
+		case '\n', '\u2028', '\u2029':
+			// A line continuation.
+		default:
+			// Any other character stands for itself, quotes and backslashes included.
+			s = append(s, r)
+		}
+	}
+
+	return "", 0, errUnterminatedString
+}
+
+// quoteJSON quotes a string as JSON, leaving the characters that are special in HTML alone.
+func quoteJSON(s string) string {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
@@ -0,0 +1,192 @@
+package schemas_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/atombender/go-jsonschema/pkg/schemas"
+)
+
+func TestFromJSONCReader(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc   string
+		schema string
+		want   string
+	}{
+		{
+			desc: "comments",
+			schema: `// A schema.
+{
+  /* The type, */ "type": "string" // of strings.
+}`,
+			want: `{"type": "string"}`,
+		},
+		{
+			desc:   "trailing commas",
+			schema: `{"type": "string", "enum": ["a", "b",],}`,
+			want:   `{"type": "string", "enum": ["a", "b"]}`,
+		},
+		{
+			desc:   "unquoted keys and single-quoted strings",
+			schema: `{type: 'string', $comment: 'It\'s "quoted"', pattern: '^\x41é\\d+$'}`,
+			want:   `{"type": "string", "$comment": "It's \"quoted\"", "pattern": "^Aé\\d+$"}`,
+		},
+		{
+			desc:   "line continuations",
+			schema: "{\"description\": 'one \\\ntwo'}",
+			want:   `{"description": "one two"}`,
+		},
+		{
+			desc:   "numbers",
+			schema: `{"type": "number", "enum": [0x1F, -0XfF, +1, .5, 5., 1.e3, -.5e-1]}`,
+			want:   `{"type": "number", "enum": [31, -255, 1, 0.5, 5, 1e3, -0.5e-1]}`,
+		},
+		{
+			desc:   "strings are left alone",
+			schema: `{"description": "// not a comment, /* nor this */ ,}"}`,
+			want:   `{"description": "// not a comment, /* nor this */ ,}"}`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := schemas.FromJSONCReader(strings.NewReader(tC.schema))
+			require.NoError(t, err)
+
+			want, err := schemas.FromJSONReader(strings.NewReader(tC.want))
+			require.NoError(t, err)
+
+			gotJSON, err := json.Marshal(got)
+			require.NoError(t, err)
+
+			wantJSON, err := json.Marshal(want)
+			require.NoError(t, err)
+
+			assert.JSONEq(t, string(wantJSON), string(gotJSON))
+		})
+	}
+}
+
+func TestFromJSONCReaderPositions(t *testing.T) {
+	t.Parallel()
+
+	schema, err := schemas.FromJSONCReader(strings.NewReader(`{
+  // The properties.
+  properties: {
+    /* A name. */ name: {type: 'string'},
+  },
+}`))
+	require.NoError(t, err)
+	assert.Equal(t, "4:25 (#/properties/name)", schema.Properties["name"].Position().String())
+
+	testCases := []struct {
+		desc   string
+		schema string
+		want   string
+	}{
+		{desc: "unterminated comment", schema: "{\n  /* type: 'string'\n}", want: "2:3 (#): invalid JSONC: unterminated comment"},
+		{desc: "unterminated string", schema: "{\n  type: 'string\n}", want: "2:9 (#): invalid JSONC: unterminated string"},
+		{desc: "infinity", schema: "{\n  maximum: -Infinity\n}", want: "2:12 (#): invalid JSONC: not representable in JSON: -Infinity"},
+		{desc: "missing comma", schema: "{\n  type: 'string'\n  format: 'uri'\n}", want: "3:3 (#): failed to unmarshal JSONC"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := schemas.FromJSONCReader(strings.NewReader(tC.schema))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tC.want)
+		})
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name    string
+		want    schemas.Format
+		wantErr error
+	}{
+		{name: "", want: schemas.FormatUnknown},
+		{name: "auto", want: schemas.FormatUnknown},
+		{name: "JSON", want: schemas.FormatJSON},
+		{name: "yml", want: schemas.FormatYAML},
+		{name: "json5", want: schemas.FormatJSONC},
+		{name: "xml", wantErr: schemas.ErrUnknownFormat},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := schemas.ParseFormat(tC.name)
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tC.want, got)
+		})
+	}
+}
+
+func TestSniffFormat(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc     string
+		document string
+		want     schemas.Format
+	}{
+		{desc: "JSON", document: `{"type": "string"}`, want: schemas.FormatJSON},
+		{desc: "JSON with BOM", document: "\uFEFF{\"type\": \"string\"}", want: schemas.FormatJSONC},
+		{desc: "JSONC", document: `{"type": "string",}`, want: schemas.FormatJSONC},
+		{desc: "JSON5", document: "  // A string.\n{type: 'string'}", want: schemas.FormatJSONC},
+		{desc: "YAML", document: "type: string\n", want: schemas.FormatYAML},
+		{desc: "YAML with comment", document: "# A string.\ntype: string\n", want: schemas.FormatYAML},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tC.want, schemas.SniffFormat([]byte(tC.document)))
+		})
+	}
+}
+
+func TestFileLoaderSniffsFormat(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writeFiles(t, dir, map[string]string{
+		"yaml":    "type: string\n",
+		"jsonc":   `{"type": "string", /* trailing comma */}`,
+		"a.json":  `{"type": "string"}`,
+		"b.json":  "type: string\n",
+		"c.yaml":  "type: string\n",
+		"d.jsonc": `{type: 'string'}`,
+	})
+
+	loader := schemas.NewFileLoader(nil, []string{".yaml"}, schemas.WithJSONCExtensions(".jsonc"))
+
+	for _, fileName := range []string{"yaml", "jsonc", "a.json", "c.yaml", "d.jsonc"} {
+		schema, err := loader.Load(filepath.Join(dir, fileName), "")
+		require.NoError(t, err, fileName)
+		assert.Equal(t, schemas.TypeList{"string"}, schema.Type, fileName)
+	}
+
+	// The extension of JSON files is not second-guessed.
+	_, err := loader.Load(filepath.Join(dir, "b.json"), "")
+	require.ErrorContains(t, err, "failed to unmarshal JSON")
+}
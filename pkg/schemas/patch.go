@@ -0,0 +1,682 @@
+package schemas
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+)
+
+var (
+	ErrInvalidPatch = errors.New("invalid patch")
+	ErrPatchFailed  = errors.New("patch does not apply")
+)
+
+// Patch is a JSON Patch (RFC 6902) or an Overlay document, which changes a schema document without
+// forking it.
+type Patch struct {
+	operations []patchOperation
+	actions    []overlayAction
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	From  string          `json:"from"`
+	Value json.RawMessage `json:"value"`
+
+	path, from []string
+	value      any
+}
+
+// overlayAction is an action of an Overlay document, which updates or removes the nodes its
+// JSONPath target selects.
+type overlayAction struct {
+	Target string          `json:"target"`
+	Update json.RawMessage `json:"update"`
+	Remove bool            `json:"remove"`
+
+	target []jsonPathSegment
+	update any
+}
+
+// ParsePatch reads a JSON Patch, which is an array of operations, or an Overlay document, which is an
+// object with an overlay version and actions, written in JSON or YAML. The JSONPath targets of the
+// actions may select members by name, array elements by index, wildcards and descendants, but not
+// filter expressions.
+func ParsePatch(data []byte) (*Patch, error) {
+	data, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+	}
+
+	if data = bytes.TrimSpace(data); len(data) > 0 && data[0] == '[' {
+		return parseJSONPatch(data)
+	}
+
+	return parseOverlay(data)
+}
+
+func parseJSONPatch(data []byte) (*Patch, error) {
+	var p Patch
+	if err := json.Unmarshal(data, &p.operations); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+	}
+
+	for i := range p.operations {
+		op := &p.operations[i]
+
+		var err error
+
+		if op.path, err = ParseJSONPointer(op.Path); err != nil {
+			return nil, fmt.Errorf("%w: operation %d: %w", ErrInvalidPatch, i, err)
+		}
+
+		switch op.Op {
+		case "add", "replace", "test":
+			if len(op.Value) == 0 {
+				return nil, fmt.Errorf("%w: operation %d: %s without a value", ErrInvalidPatch, i, op.Op)
+			}
+
+			if err := json.Unmarshal(op.Value, &op.value); err != nil {
+				return nil, fmt.Errorf("%w: operation %d: %w", ErrInvalidPatch, i, err)
+			}
+
+		case "move", "copy":
+			if op.from, err = ParseJSONPointer(op.From); err != nil {
+				return nil, fmt.Errorf("%w: operation %d: %w", ErrInvalidPatch, i, err)
+			}
+
+			if op.Op == "move" && len(op.from) < len(op.path) && slices.Equal(op.from, op.path[:len(op.from)]) {
+				return nil, fmt.Errorf("%w: operation %d: cannot move %s into itself", ErrInvalidPatch, i, op.From)
+			}
+
+		case "remove":
+
+		default:
+			return nil, fmt.Errorf("%w: operation %d: unknown op %q", ErrInvalidPatch, i, op.Op)
+		}
+	}
+
+	return &p, nil
+}
+
+func parseOverlay(data []byte) (*Patch, error) {
+	var doc struct {
+		Overlay string          `json:"overlay"`
+		Actions []overlayAction `json:"actions"`
+	}
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidPatch, err)
+	}
+
+	if doc.Overlay == "" {
+		return nil, fmt.Errorf("%w: neither an array of JSON Patch operations nor an Overlay document", ErrInvalidPatch)
+	}
+
+	for i := range doc.Actions {
+		action := &doc.Actions[i]
+
+		var err error
+
+		if action.target, err = parseJSONPath(action.Target); err != nil {
+			return nil, fmt.Errorf("%w: action %d: %w", ErrInvalidPatch, i, err)
+		}
+
+		if !action.Remove {
+			if len(action.Update) == 0 {
+				return nil, fmt.Errorf("%w: action %d: neither update nor remove", ErrInvalidPatch, i)
+			}
+
+			if err := json.Unmarshal(action.Update, &action.update); err != nil {
+				return nil, fmt.Errorf("%w: action %d: %w", ErrInvalidPatch, i, err)
+			}
+		}
+	}
+
+	return &Patch{actions: doc.Actions}, nil
+}
+
+// Apply patches the schema in place. The patch applies to the document the schema was parsed from,
+// as it is written: definitions are found under definitions or $defs, whichever the document uses, and
+// the schemas of an OpenAPI document under components/schemas. Subschemas of the patched schema keep
+// the positions of the values found at the same JSON Pointer in the document.
+func (p *Patch) Apply(schema *Schema) error {
+	if schema.document == nil {
+		return fmt.Errorf("%w: the schema was not parsed from a document", ErrPatchFailed)
+	}
+
+	var doc any
+	if err := json.Unmarshal(schema.document, &doc); err != nil {
+		return fmt.Errorf("failed to unmarshal schema: %w", err)
+	}
+
+	doc, err := p.apply(doc)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("failed to marshal schema: %w", err)
+	}
+
+	patched, err := decodeSchema(data, schema.documentPositions, schema.defaultDialect)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrPatchFailed, err)
+	}
+
+	if schema.ObjectAsType != nil {
+		if source := schema.ObjectAsType.position.File; source != "" {
+			patched.setSource(source)
+		}
+	}
+
+	patched.digest = schema.digest
+	*schema = *patched
+
+	return nil
+}
+
+func (p *Patch) apply(doc any) (any, error) {
+	var err error
+
+	for i, op := range p.operations {
+		if doc, err = op.apply(doc); err != nil {
+			return nil, fmt.Errorf("%w: operation %d: %w", ErrPatchFailed, i, err)
+		}
+	}
+
+	for i, action := range p.actions {
+		if doc, err = action.apply(doc); err != nil {
+			return nil, fmt.Errorf("%w: action %d: %w", ErrPatchFailed, i, err)
+		}
+	}
+
+	return doc, nil
+}
+
+func (op patchOperation) apply(doc any) (any, error) {
+	switch op.Op {
+	case "add":
+		return addValue(doc, op.path, deepCopyValue(op.value))
+
+	case "remove":
+		return removeValue(doc, op.path)
+
+	case "replace":
+		doc, err := removeValue(doc, op.path)
+		if err != nil {
+			return nil, err
+		}
+
+		return addValue(doc, op.path, deepCopyValue(op.value))
+
+	case "move", "copy":
+		value, err := valueAt(doc, op.from)
+		if err != nil {
+			return nil, err
+		}
+
+		if op.Op == "move" {
+			if doc, err = removeValue(doc, op.from); err != nil {
+				return nil, err
+			}
+		} else {
+			value = deepCopyValue(value)
+		}
+
+		return addValue(doc, op.path, value)
+
+	default:
+		value, err := valueAt(doc, op.path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !reflect.DeepEqual(value, op.value) {
+			return nil, fmt.Errorf("%w: %s", errPatchTest, op.Path)
+		}
+
+		return doc, nil
+	}
+}
+
+func (action overlayAction) apply(doc any) (any, error) {
+	paths := selectJSONPath(doc, action.target)
+
+	// Removing array elements last to first keeps the indices of the others.
+	for i := len(paths) - 1; i >= 0; i-- {
+		var err error
+
+		if action.Remove {
+			if len(paths[i]) == 0 {
+				return nil, errRemoveDocument
+			}
+
+			doc, err = removeValue(doc, paths[i])
+		} else {
+			doc, err = updateValue(doc, paths[i], action.update)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return doc, nil
+}
+
+// updateValue merges an Overlay update into the value at the tokens: members of objects are merged
+// recursively, arrays are appended to and other values are replaced.
+func updateValue(doc any, tokens []string, update any) (any, error) {
+	value, err := valueAt(doc, tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	if array, ok := value.([]any); ok {
+		return setValue(doc, tokens, append(array, deepCopyValue(update)))
+	}
+
+	return setValue(doc, tokens, mergeValues(value, update))
+}
+
+func mergeValues(value, update any) any {
+	switch update := update.(type) {
+	case map[string]any:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return deepCopyValue(update)
+		}
+
+		for name, v := range update {
+			object[name] = mergeValues(object[name], v)
+		}
+
+		return object
+
+	case []any:
+		array, ok := value.([]any)
+		if !ok {
+			return deepCopyValue(update)
+		}
+
+		return append(array, deepCopyValue(update).([]any)...)
+
+	default:
+		return update
+	}
+}
+
+func deepCopyValue(value any) any {
+	switch value := value.(type) {
+	case map[string]any:
+		cp := make(map[string]any, len(value))
+		for name, v := range value {
+			cp[name] = deepCopyValue(v)
+		}
+
+		return cp
+
+	case []any:
+		cp := make([]any, len(value))
+		for i, v := range value {
+			cp[i] = deepCopyValue(v)
+		}
+
+		return cp
+
+	default:
+		return value
+	}
+}
+
+// valueAt returns the value the JSON Pointer tokens point to in a document.
+func valueAt(doc any, tokens []string) (any, error) {
+	for i, token := range tokens {
+		var ok bool
+
+		switch container := doc.(type) {
+		case map[string]any:
+			doc, ok = container[token]
+
+		case []any:
+			var j int
+			if j, ok = arrayIndex(token, len(container)); ok {
+				doc = container[j]
+			}
+		}
+
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrJSONPointerNotFound, FormatJSONPointer(tokens[:i+1]))
+		}
+	}
+
+	return doc, nil
+}
+
+// changeValue returns the document with the container holding the value the tokens point to
+// replaced by what fn returns for it and the last token.
+func changeValue(doc any, tokens []string, fn func(container any, token string) (any, error)) (any, error) {
+	if len(tokens) == 1 {
+		return fn(doc, tokens[0])
+	}
+
+	child, err := valueAt(doc, tokens[:1])
+	if err != nil {
+		return nil, err
+	}
+
+	if child, err = changeValue(child, tokens[1:], fn); err != nil {
+		return nil, err
+	}
+
+	switch container := doc.(type) {
+	case map[string]any:
+		container[tokens[0]] = child
+
+	case []any:
+		j, _ := arrayIndex(tokens[0], len(container))
+		container[j] = child
+	}
+
+	return doc, nil
+}
+
+func addValue(doc any, tokens []string, value any) (any, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	return changeValue(doc, tokens, func(container any, token string) (any, error) {
+		switch container := container.(type) {
+		case map[string]any:
+			container[token] = value
+
+			return container, nil
+
+		case []any:
+			if token == "-" {
+				return append(container, value), nil
+			}
+
+			// An element may be inserted at the end of the array.
+			j, ok := arrayIndex(token, len(container)+1)
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrJSONPointerNotFound, FormatJSONPointer(tokens))
+			}
+
+			return slices.Insert(container, j, value), nil
+
+		default:
+			return nil, fmt.Errorf("%w: %s", ErrJSONPointerNotFound, FormatJSONPointer(tokens))
+		}
+	})
+}
+
+func setValue(doc any, tokens []string, value any) (any, error) {
+	if len(tokens) == 0 {
+		return value, nil
+	}
+
+	return changeValue(doc, tokens, func(container any, token string) (any, error) {
+		switch container := container.(type) {
+		case map[string]any:
+			container[token] = value
+
+		case []any:
+			j, _ := arrayIndex(token, len(container))
+			container[j] = value
+		}
+
+		return container, nil
+	})
+}
+
+func removeValue(doc any, tokens []string) (any, error) {
+	if _, err := valueAt(doc, tokens); err != nil {
+		return nil, err
+	}
+
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	return changeValue(doc, tokens, func(container any, token string) (any, error) {
+		switch container := container.(type) {
+		case map[string]any:
+			delete(container, token)
+
+			return container, nil
+
+		case []any:
+			j, _ := arrayIndex(token, len(container))
+
+			return slices.Delete(container, j, j+1), nil
+
+		default:
+			return container, nil
+		}
+	})
+}
+
+// arrayIndex parses a JSON Pointer token indexing an array of the given length.
+func arrayIndex(token string, length int) (int, bool) {
+	if token == "" || (len(token) > 1 && token[0] == '0') {
+		return 0, false
+	}
+
+	j, err := strconv.Atoi(token)
+	if err != nil || j < 0 || j >= length {
+		return 0, false
+	}
+
+	return j, true
+}
+
+var (
+	errPatchTest           = errors.New("test failed")
+	errRemoveDocument      = errors.New("cannot remove the whole document")
+	errUnsupportedJSONPath = errors.New("unsupported JSONPath")
+)
+
+// jsonPathSegment selects the members or elements of the nodes selected so far, or of their
+// descendants too.
+type jsonPathSegment struct {
+	descendants bool
+	wildcard    bool
+	name        string
+	index       *int
+}
+
+func parseJSONPath(path string) ([]jsonPathSegment, error) {
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("%w: %q must start with $", errUnsupportedJSONPath, path)
+	}
+
+	var segments []jsonPathSegment
+
+	for rest := path[1:]; rest != ""; {
+		var seg jsonPathSegment
+
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			seg.descendants = true
+			rest = rest[2:]
+
+		case rest[0] == '.':
+			rest = rest[1:]
+
+		case rest[0] != '[':
+			return nil, fmt.Errorf("%w: %q", errUnsupportedJSONPath, path)
+		}
+
+		switch {
+		case strings.HasPrefix(rest, "*"):
+			seg.wildcard = true
+			rest = rest[1:]
+
+		case strings.HasPrefix(rest, "["):
+			end := jsonPathBracketEnd(rest)
+			if end < 0 {
+				return nil, fmt.Errorf("%w: %q", errUnsupportedJSONPath, path)
+			}
+
+			if err := seg.parseSelector(strings.TrimSpace(rest[1:end])); err != nil {
+				return nil, fmt.Errorf("%w: %q", err, path)
+			}
+
+			rest = rest[end+1:]
+
+		default:
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+
+			if end == 0 {
+				return nil, fmt.Errorf("%w: %q", errUnsupportedJSONPath, path)
+			}
+
+			seg.name, rest = rest[:end], rest[end:]
+		}
+
+		segments = append(segments, seg)
+	}
+
+	return segments, nil
+}
+
+// jsonPathBracketEnd returns the offset of the bracket closing the selector the path starts with.
+func jsonPathBracketEnd(path string) int {
+	var quote byte
+
+	for i := 1; i < len(path); i++ {
+		switch c := path[i]; {
+		case quote != 0 && c == '\\':
+			i++
+
+		case quote != 0 && c == quote:
+			quote = 0
+
+		case quote == 0 && (c == '\'' || c == '"'):
+			quote = c
+
+		case quote == 0 && c == ']':
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (seg *jsonPathSegment) parseSelector(selector string) error {
+	switch {
+	case selector == "*":
+		seg.wildcard = true
+
+	case len(selector) >= 2 && (selector[0] == '\'' || selector[0] == '"') && selector[len(selector)-1] == selector[0]:
+		quoted := selector
+		if selector[0] == '\'' {
+			quoted = `"` + strings.ReplaceAll(strings.ReplaceAll(selector[1:len(selector)-1], `\'`, `'`), `"`, `\"`) + `"`
+		}
+
+		if err := json.Unmarshal([]byte(quoted), &seg.name); err != nil {
+			return errUnsupportedJSONPath
+		}
+
+	default:
+		i, err := strconv.Atoi(selector)
+		if err != nil {
+			return errUnsupportedJSONPath
+		}
+
+		seg.index = &i
+	}
+
+	return nil
+}
+
+// selectJSONPath returns the JSON Pointer tokens of the nodes of the document the path selects, in
+// document order.
+func selectJSONPath(doc any, segments []jsonPathSegment) [][]string {
+	type node struct {
+		value  any
+		tokens []string
+	}
+
+	nodes := []node{{value: doc}}
+
+	for _, seg := range segments {
+		var next []node
+
+		var visit func(n node)
+		visit = func(n node) {
+			var children []node
+
+			switch value := n.value.(type) {
+			case map[string]any:
+				names := make([]string, 0, len(value))
+				for name := range value {
+					names = append(names, name)
+				}
+
+				sort.Strings(names)
+
+				for _, name := range names {
+					children = append(children, node{value[name], append(n.tokens[:len(n.tokens):len(n.tokens)], name)})
+				}
+
+			case []any:
+				for i, v := range value {
+					children = append(children, node{v, append(n.tokens[:len(n.tokens):len(n.tokens)], strconv.Itoa(i))})
+				}
+			}
+
+			for i, child := range children {
+				switch {
+				case seg.wildcard:
+					next = append(next, child)
+
+				case seg.index != nil:
+					if _, ok := n.value.([]any); ok && (i == *seg.index || i == len(children)+*seg.index) {
+						next = append(next, child)
+					}
+
+				default:
+					if _, ok := n.value.(map[string]any); ok && child.tokens[len(child.tokens)-1] == seg.name {
+						next = append(next, child)
+					}
+				}
+			}
+
+			if seg.descendants {
+				for _, child := range children {
+					visit(child)
+				}
+			}
+		}
+
+		for _, n := range nodes {
+			visit(n)
+		}
+
+		nodes = next
+	}
+
+	paths := make([][]string, len(nodes))
+	for i, n := range nodes {
+		paths[i] = n.tokens
+	}
+
+	return paths
+}
@@ -0,0 +1,237 @@
+package schemas_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/atombender/go-jsonschema/pkg/schemas"
+)
+
+const patchSchema = `{
+	"$schema": "http://json-schema.org/draft-07/schema#",
+	"$id": "https://example.com/pet.json",
+	"type": "object",
+	"properties": {
+		"name": {"type": "string"},
+		"tags": {"type": "array", "items": [{"type": "string"}], "additionalItems": {"type": "integer"}}
+	},
+	"required": ["name"],
+	"definitions": {
+		"tag": {"type": "string", "properties": {"id": {"type": "integer"}}}
+	}
+}`
+
+func TestPatchApply(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		patch   string
+		want    string
+		wantErr error
+	}{
+		{
+			desc: "JSON Patch",
+			patch: `[
+				{"op": "test", "path": "/definitions/tag/type", "value": "string"},
+				{"op": "remove", "path": "/properties/tags"},
+				{"op": "add", "path": "/required/-", "value": "id"},
+				{"op": "add", "path": "/required/0", "value": "owner"},
+				{"op": "replace", "path": "/properties/name/type", "value": "integer"},
+				{"op": "copy", "from": "/properties/name", "path": "/properties/id"},
+				{"op": "move", "from": "/definitions/tag", "path": "/definitions/label"}
+			]`,
+			want: `{
+				"$schema": "http://json-schema.org/draft-07/schema#",
+				"$id": "https://example.com/pet.json",
+				"type": "object",
+				"properties": {
+					"id": {"type": "integer"},
+					"name": {"type": "integer"}
+				},
+				"required": ["owner", "name", "id"],
+				"$defs": {
+					"label": {"type": "string", "properties": {"id": {"type": "integer"}}}
+				}
+			}`,
+		},
+		{
+			desc: "tuple in the keywords of draft-07",
+			patch: `[
+				{"op": "test", "path": "/properties/tags/items/0/type", "value": "string"},
+				{"op": "replace", "path": "/properties/tags/additionalItems/type", "value": "boolean"}
+			]`,
+			want: `{
+				"$schema": "http://json-schema.org/draft-07/schema#",
+				"$id": "https://example.com/pet.json",
+				"type": "object",
+				"properties": {
+					"name": {"type": "string"},
+					"tags": {
+						"type": "array",
+						"prefixItems": [{"type": "string"}],
+						"items": {"type": "boolean"},
+						"additionalItems": {"type": "boolean"}
+					}
+				},
+				"required": ["name"],
+				"$defs": {
+					"tag": {"type": "string", "properties": {"id": {"type": "integer"}}}
+				}
+			}`,
+		},
+		{
+			desc: "Overlay",
+			patch: `
+overlay: 1.0.0
+info: {title: Pets, version: 1.0.0}
+actions:
+  - target: $..properties[*]
+    update: {description: A property.}
+  - target: $.required
+    update: tags
+  - target: $.definitions.tag.properties.id
+    remove: true
+  - target: $.properties.tags.items[-1]
+    update: {minLength: 1}
+`,
+			want: `{
+				"$schema": "http://json-schema.org/draft-07/schema#",
+				"$id": "https://example.com/pet.json",
+				"type": "object",
+				"properties": {
+					"name": {"type": "string", "description": "A property."},
+					"tags": {
+						"type": "array",
+						"description": "A property.",
+						"prefixItems": [{"type": "string", "minLength": 1}],
+						"items": {"type": "integer"},
+						"additionalItems": {"type": "integer"}
+					}
+				},
+				"required": ["name", "tags"],
+				"$defs": {
+					"tag": {"type": "string"}
+				}
+			}`,
+		},
+		{
+			desc:    "failed test",
+			patch:   `[{"op": "test", "path": "/type", "value": "array"}]`,
+			wantErr: schemas.ErrPatchFailed,
+		},
+		{
+			desc:    "missing value",
+			patch:   `[{"op": "remove", "path": "/properties/age"}]`,
+			wantErr: schemas.ErrJSONPointerNotFound,
+		},
+		{
+			desc:    "index out of range",
+			patch:   `[{"op": "add", "path": "/required/2", "value": "id"}]`,
+			wantErr: schemas.ErrPatchFailed,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			schema, err := schemas.FromJSONReader(strings.NewReader(patchSchema))
+			require.NoError(t, err)
+
+			patch, err := schemas.ParsePatch([]byte(tC.patch))
+			require.NoError(t, err)
+
+			err = patch.Apply(schema)
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+
+			root := *(*schemas.Type)(schema.ObjectAsType)
+			root.Definitions = schema.Definitions
+
+			got, err := json.Marshal(root)
+			require.NoError(t, err)
+			assert.JSONEq(t, tC.want, string(got))
+		})
+	}
+}
+
+func TestPatchApplyOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	schema, err := schemas.FromYAMLReader(strings.NewReader(`
+openapi: 3.0.3
+info: {title: Pets, version: 1.0.0}
+paths: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name: {type: string}
+`))
+	require.NoError(t, err)
+
+	patch, err := schemas.ParsePatch([]byte(`[
+		{"op": "add", "path": "/components/schemas/Pet/required", "value": ["name"]}
+	]`))
+	require.NoError(t, err)
+	require.NoError(t, patch.Apply(schema))
+
+	assert.Equal(t, "3.0.3", schema.OpenAPI)
+	assert.Equal(t, []string{"name"}, schema.Definitions["Pet"].Required)
+}
+
+func TestParsePatch(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc  string
+		patch string
+	}{
+		{
+			desc:  "neither JSON Patch nor Overlay",
+			patch: `{"op": "remove", "path": "/type"}`,
+		},
+		{
+			desc:  "unknown op",
+			patch: `[{"op": "delete", "path": "/type"}]`,
+		},
+		{
+			desc:  "add without a value",
+			patch: `[{"op": "add", "path": "/type"}]`,
+		},
+		{
+			desc:  "invalid pointer",
+			patch: `[{"op": "remove", "path": "type"}]`,
+		},
+		{
+			desc:  "move into itself",
+			patch: `[{"op": "move", "from": "/properties", "path": "/properties/name"}]`,
+		},
+		{
+			desc:  "filter expression",
+			patch: "overlay: 1.0.0\nactions:\n  - target: $.properties[?(@.type == 'string')]\n    remove: true\n",
+		},
+		{
+			desc:  "action without update",
+			patch: "overlay: 1.0.0\nactions:\n  - target: $.properties\n",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := schemas.ParsePatch([]byte(tC.patch))
+			require.ErrorIs(t, err, schemas.ErrInvalidPatch)
+		})
+	}
+}
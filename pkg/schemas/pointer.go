@@ -0,0 +1,297 @@
+package schemas
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidJSONPointer  = errors.New("invalid JSON pointer")
+	ErrJSONPointerNotFound = errors.New("JSON pointer does not point to a schema")
+)
+
+// Keywords whose JSON names differ from the ones the model is decoded into.
+var legacyKeywords = map[string]string{
+	"definitions":  "$defs",
+	"dependencies": "dependentSchemas",
+}
+
+// ParseJSONPointer splits an RFC 6901 JSON Pointer into its unescaped reference tokens.
+// The empty pointer refers to the whole document and has no tokens.
+func ParseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: %q must start with a slash", ErrInvalidJSONPointer, pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+
+	for i, token := range tokens {
+		if strings.Contains(strings.NewReplacer("~0", "", "~1", "").Replace(token), "~") {
+			return nil, fmt.Errorf("%w: %q has an invalid escape sequence", ErrInvalidJSONPointer, pointer)
+		}
+
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(token)
+	}
+
+	return tokens, nil
+}
+
+// FormatJSONPointer is the inverse of ParseJSONPointer.
+func FormatJSONPointer(tokens []string) string {
+	var b strings.Builder
+
+	for _, token := range tokens {
+		b.WriteString("/")
+		b.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(token))
+	}
+
+	return b.String()
+}
+
+// ResolveJSONPointer returns the subschema the reference tokens point to.
+func (s *Schema) ResolveJSONPointer(tokens []string) (*Type, error) {
+	tokens = s.ModelTokens(tokens)
+
+	if len(tokens) >= 2 && (tokens[0] == "$defs" || tokens[0] == "definitions") {
+		def, ok := s.Definitions[tokens[1]]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrJSONPointerNotFound, FormatJSONPointer(tokens))
+		}
+
+		return def.resolveJSONPointer(tokens, 2)
+	}
+
+	if s.ObjectAsType == nil {
+		return nil, fmt.Errorf("%w: %s", ErrJSONPointerNotFound, FormatJSONPointer(tokens))
+	}
+
+	return (*Type)(s.ObjectAsType).resolveJSONPointer(tokens, 0)
+}
+
+// NestedDefinitions returns the definitions declared anywhere below the top level of
+// the schema, keyed by their JSON Pointer.
+func (s *Schema) NestedDefinitions() map[string]*Type {
+	defs := map[string]*Type{}
+
+	collect := func(tokens []string, t *Type) {
+		for name, def := range t.Definitions {
+			defs[FormatJSONPointer(append(tokens, "$defs", name))] = def
+		}
+	}
+
+	s.walkSubschemas(collect)
+
+	return defs
+}
+
+// walkSubschemas calls fn for the root type and every subschema of the document, parents first.
+func (s *Schema) walkSubschemas(fn func(tokens []string, t *Type)) {
+	_ = Walk(s, func(node *WalkNode) error {
+		fn(node.Path, node.Type)
+
+		return nil
+	})
+}
+
+func (value *Type) resolveJSONPointer(tokens []string, pos int) (*Type, error) {
+	if pos == len(tokens) {
+		return value, nil
+	}
+
+	field, ok := value.subschemaField(tokens[pos])
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrJSONPointerNotFound, FormatJSONPointer(tokens[:pos+1]))
+	}
+
+	if next, ok := field.Interface().(*Type); ok {
+		if next == nil {
+			return nil, fmt.Errorf("%w: %s", ErrJSONPointerNotFound, FormatJSONPointer(tokens[:pos+1]))
+		}
+
+		return next.resolveJSONPointer(tokens, pos+1)
+	}
+
+	if pos+1 == len(tokens) {
+		return nil, fmt.Errorf("%w: %s", ErrJSONPointerNotFound, FormatJSONPointer(tokens))
+	}
+
+	var next *Type
+
+	switch field.Kind() { //nolint:exhaustive
+	case reflect.Slice:
+		i, err := strconv.Atoi(tokens[pos+1])
+		if err == nil && i >= 0 && i < field.Len() {
+			next, _ = field.Index(i).Interface().(*Type)
+		}
+
+	case reflect.Map:
+		if v := field.MapIndex(reflect.ValueOf(tokens[pos+1])); v.IsValid() {
+			next, _ = v.Interface().(*Type)
+		}
+	}
+
+	if next == nil {
+		return nil, fmt.Errorf("%w: %s", ErrJSONPointerNotFound, FormatJSONPointer(tokens[:pos+2]))
+	}
+
+	return next.resolveJSONPointer(tokens, pos+2)
+}
+
+// subschemaField returns the field holding the subschemas of the given keyword.
+func (value *Type) subschemaField(keyword string) (reflect.Value, bool) {
+	if k, ok := legacyKeywords[keyword]; ok {
+		keyword = k
+	}
+
+	val := reflect.ValueOf(value).Elem()
+
+	for i := range val.NumField() {
+		if strings.Split(val.Type().Field(i).Tag.Get("json"), ",")[0] != keyword {
+			continue
+		}
+
+		if field := val.Field(i); isSubschemaKind(field.Type()) {
+			return field, true
+		}
+
+		return reflect.Value{}, false
+	}
+
+	return reflect.Value{}, false
+}
+
+var typePointerType = reflect.TypeFor[*Type]()
+
+func isSubschemaKind(t reflect.Type) bool {
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.Pointer:
+		return t == typePointerType
+
+	case reflect.Slice, reflect.Map:
+		return t.Elem() == typePointerType && (t.Kind() == reflect.Slice || t.Key().Kind() == reflect.String)
+
+	default:
+		return false
+	}
+}
+
+func sortedDefinitionNames(defs Definitions) []string {
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// Keywords holding a subschema, an array of subschemas or a map of subschemas, in documents.
+var (
+	rawSchemaKeywords = []string{
+		"additionalItems", "additionalProperties", "contains", "contentSchema", "else", "if", "items", "not",
+		"propertyNames", "then", "unevaluatedItems", "unevaluatedProperties",
+	}
+	rawSchemaArrayKeywords = []string{"allOf", "anyOf", "items", "oneOf", "prefixItems"}
+	rawSchemaMapKeywords   = []string{
+		"$defs", "definitions", "dependencies", "dependentSchemas", "patternProperties", "properties",
+	}
+)
+
+// rawSubschema is a subschema of a schema decoded as plain JSON values.
+type rawSubschema struct {
+	// path holds the JSON Pointer tokens of the subschema, relative to the schema.
+	path   []string
+	schema any
+}
+
+// rawSubschemas returns the subschemas nested directly in a schema decoded as plain JSON values,
+// whatever the dialect, in a stable order.
+func rawSubschemas(obj map[string]any) []rawSubschema {
+	var subs []rawSubschema
+
+	for _, keyword := range rawSchemaKeywords {
+		if sub, ok := obj[keyword]; ok {
+			if _, isArray := sub.([]any); !isArray || keyword != "items" {
+				subs = append(subs, rawSubschema{path: []string{keyword}, schema: sub})
+			}
+		}
+	}
+
+	for _, keyword := range rawSchemaArrayKeywords {
+		items, _ := obj[keyword].([]any)
+
+		for i, sub := range items {
+			subs = append(subs, rawSubschema{path: []string{keyword, strconv.Itoa(i)}, schema: sub})
+		}
+	}
+
+	for _, keyword := range rawSchemaMapKeywords {
+		entries, _ := obj[keyword].(map[string]any)
+
+		names := make([]string, 0, len(entries))
+		for name := range entries {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		for _, name := range names {
+			// Dependencies may also list the properties that a property requires.
+			if _, isArray := entries[name].([]any); isArray && keyword == "dependencies" {
+				continue
+			}
+
+			subs = append(subs, rawSubschema{path: []string{keyword, name}, schema: entries[name]})
+		}
+	}
+
+	return subs
+}
+
+// Names that a keyword of the model may have in documents, depending on their dialect.
+var documentKeywords = map[string][]string{
+	"$defs":            {"definitions"},
+	"dependentSchemas": {"dependencies"},
+	"prefixItems":      {"items"},
+	"items":            {"additionalItems"},
+}
+
+// documentTokens translates the JSON Pointer tokens of a subschema in the model of a schema into
+// its position in the document, given a function telling which positions the document has.
+func documentTokens(tokens []string, has func(tokens []string) bool) []string {
+	result := make([]string, 0, len(tokens))
+
+	for _, token := range tokens {
+		at := func(token string, more ...string) []string {
+			return append(append(result[:len(result):len(result)], token), more...)
+		}
+
+		switch {
+		case token == "items" && has(at("items", "0")) && has(at("additionalItems")):
+			// The items following a tuple given as an array in items.
+			token = "additionalItems"
+
+		case !has(at(token)):
+			for _, alt := range documentKeywords[token] {
+				if has(at(alt)) {
+					token = alt
+
+					break
+				}
+			}
+		}
+
+		result = append(result, token)
+	}
+
+	return result
+}
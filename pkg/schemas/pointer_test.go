@@ -0,0 +1,143 @@
+package schemas_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/atombender/go-jsonschema/pkg/schemas"
+)
+
+func TestParseJSONPointer(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		pointer string
+		want    []string
+		wantErr error
+	}{
+		{
+			desc:    "whole document",
+			pointer: "",
+			want:    nil,
+		},
+		{
+			desc:    "nested definitions",
+			pointer: "/$defs/a/$defs/b",
+			want:    []string{"$defs", "a", "$defs", "b"},
+		},
+		{
+			desc:    "escaped tokens",
+			pointer: "/$defs/a~1b~0c/~01",
+			want:    []string{"$defs", "a/b~c", "~1"},
+		},
+		{
+			desc:    "empty token",
+			pointer: "/properties/",
+			want:    []string{"properties", ""},
+		},
+		{
+			desc:    "missing leading slash",
+			pointer: "$defs/a",
+			wantErr: schemas.ErrInvalidJSONPointer,
+		},
+		{
+			desc:    "invalid escape",
+			pointer: "/$defs/a~2",
+			wantErr: schemas.ErrInvalidJSONPointer,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := schemas.ParseJSONPointer(tC.pointer)
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tC.want, got)
+			assert.Equal(t, tC.pointer, schemas.FormatJSONPointer(got))
+		})
+	}
+}
+
+func TestResolveJSONPointer(t *testing.T) {
+	t.Parallel()
+
+	var schema schemas.Schema
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"definitions": {
+			"a": {
+				"definitions": {"b": {"type": "string"}}
+			}
+		},
+		"properties": {
+			"list": {"type": "array", "items": {"type": "integer"}},
+			"choice": {"oneOf": [{"type": "null"}, {"type": "boolean"}]}
+		}
+	}`), &schema))
+
+	testCases := []struct {
+		desc    string
+		pointer string
+		want    string
+		wantErr error
+	}{
+		{
+			desc:    "nested legacy definitions",
+			pointer: "/definitions/a/definitions/b",
+			want:    schemas.TypeNameString,
+		},
+		{
+			desc:    "nested definitions",
+			pointer: "/$defs/a/$defs/b",
+			want:    schemas.TypeNameString,
+		},
+		{
+			desc:    "array items",
+			pointer: "/properties/list/items",
+			want:    schemas.TypeNameInteger,
+		},
+		{
+			desc:    "array index",
+			pointer: "/properties/choice/oneOf/1",
+			want:    schemas.TypeNameBoolean,
+		},
+		{
+			desc:    "index out of range",
+			pointer: "/properties/choice/oneOf/2",
+			wantErr: schemas.ErrJSONPointerNotFound,
+		},
+		{
+			desc:    "not a subschema",
+			pointer: "/properties/list/type",
+			wantErr: schemas.ErrJSONPointerNotFound,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			tokens, err := schemas.ParseJSONPointer(tC.pointer)
+			require.NoError(t, err)
+
+			got, err := schema.ResolveJSONPointer(tokens)
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, schemas.TypeList{tC.want}, got.Type)
+		})
+	}
+}
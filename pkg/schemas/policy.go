@@ -0,0 +1,178 @@
+package schemas
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+var ErrPolicyViolation = errors.New("schema reference violates the loading policy")
+
+// Policy restricts the schemas that loaders may load, for schemas that are not trusted. Its zero
+// value allows everything.
+type Policy struct {
+	// RootDir is the directory that files must be in, after symlinks are resolved, if not empty.
+	RootDir string
+	// AllowedHosts are the hosts that schemas may be retrieved from over HTTP or HTTPS, if not empty.
+	// A host starting with "*." allows the domain that follows and any of its subdomains.
+	AllowedHosts []string
+	// MaxRefDepth is the number of references that may be followed from one document to another,
+	// starting from the schemas loaded first, if positive.
+	MaxRefDepth int
+	// MaxDocumentSize is the size in bytes that documents may not exceed, if positive.
+	MaxDocumentSize int64
+}
+
+// WithPolicy makes the loaders created by NewDefaultCacheLoader and friends enforce a policy.
+func WithPolicy(policy Policy) LoaderOption {
+	return func(o *loaderOptions) {
+		o.policy = policy
+		o.http.Policy = policy
+	}
+}
+
+// checkFile fails if a file, given with its symlinks resolved, is outside of the root directory.
+func (p Policy) checkFile(fileName string) error {
+	if p.RootDir == "" {
+		return nil
+	}
+
+	root, err := filepath.Abs(p.RootDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve root directory: %w", err)
+	}
+
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+
+	abs, err := filepath.Abs(fileName)
+	if err != nil {
+		return fmt.Errorf("failed to resolve file name: %w", err)
+	}
+
+	if rel, err := filepath.Rel(root, abs); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return fmt.Errorf("%w: %s is outside of the root directory %s", ErrPolicyViolation, abs, root)
+	}
+
+	return nil
+}
+
+// checkURL fails if a URL is on a host that is not allowed.
+func (p Policy) checkURL(u *url.URL) error {
+	if len(p.AllowedHosts) == 0 {
+		return nil
+	}
+
+	host := strings.ToLower(u.Hostname())
+
+	for _, allowed := range p.AllowedHosts {
+		allowed = strings.ToLower(allowed)
+
+		if domain, ok := strings.CutPrefix(allowed, "*."); ok && (host == domain || strings.HasSuffix(host, "."+domain)) {
+			return nil
+		}
+
+		if allowed == host || allowed == strings.ToLower(u.Host) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: host %q of %s is not allowed", ErrPolicyViolation, u.Host, u.Redacted())
+}
+
+// maxRedirects is the number of redirects followed, as by the default policy of http.Client.
+const maxRedirects = 10
+
+var errTooManyRedirects = errors.New("stopped after too many redirects")
+
+// redirectChecking returns a copy of the client that also checks the hosts it is redirected to.
+func (p Policy) redirectChecking(client *http.Client) *http.Client {
+	c := *client
+	checkRedirect := client.CheckRedirect
+
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if err := p.checkURL(req.URL); err != nil {
+			return err
+		}
+
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+
+		if len(via) >= maxRedirects {
+			return errTooManyRedirects
+		}
+
+		return nil
+	}
+
+	return &c
+}
+
+// checkSize fails if a document is larger than allowed.
+func (p Policy) checkSize(name string, size int64) error {
+	if p.MaxDocumentSize > 0 && size > p.MaxDocumentSize {
+		return fmt.Errorf("%w: %s is larger than %d bytes", ErrPolicyViolation, name, p.MaxDocumentSize)
+	}
+
+	return nil
+}
+
+func newDepthLimitedLoader(loader Loader, maxDepth int) *depthLimitedLoader {
+	return &depthLimitedLoader{
+		loader:   loader,
+		maxDepth: maxDepth,
+		depths:   map[string]int{},
+	}
+}
+
+// depthLimitedLoader fails to load documents that are referenced through too many other documents,
+// counting from those loaded without a parent.
+type depthLimitedLoader struct {
+	loader   Loader
+	maxDepth int
+	// depths holds the smallest number of references each document was reached through, by URI.
+	depths map[string]int
+}
+
+func (l *depthLimitedLoader) Load(uri, parentURI string) (*Schema, error) {
+	depth := 0
+	if parentURI != "" {
+		depth = l.depths[l.key(parentURI, "")] + 1
+	}
+
+	if depth > l.maxDepth {
+		return nil, fmt.Errorf("%w: %s is referenced through more than %d documents", ErrPolicyViolation, uri, l.maxDepth)
+	}
+
+	schema, err := l.loader.Load(uri, parentURI)
+	if err != nil {
+		return nil, err
+	}
+
+	key := l.key(uri, parentURI)
+	if d, ok := l.depths[key]; !ok || depth < d {
+		l.depths[key] = depth
+	}
+
+	return schema, nil
+}
+
+func (l *depthLimitedLoader) QualifiedFileName(fileName, parentFileName string) (string, error) {
+	return qualifyWith(l.loader, fileName, parentFileName)
+}
+
+// key returns the URI that identifies the document loaded from a reference.
+func (l *depthLimitedLoader) key(uri, parentURI string) string {
+	if refType, err := GetRefType(uri); err == nil && refType == RefTypeFile {
+		if qualified, err := LoaderQualifiedFileName(l.loader, uri, parentURI, nil); err == nil {
+			uri = qualified
+		}
+	}
+
+	return locationURI(uri)
+}
@@ -0,0 +1,138 @@
+package schemas_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/atombender/go-jsonschema/pkg/schemas"
+)
+
+func TestPolicyFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writeFiles(t, dir, map[string]string{
+		"root/a.json":     `{"type": "string"}`,
+		"root/big.json":   `{"type": "string", "description": "` + strings.Repeat("x", 100) + `"}`,
+		"secret/b.json":   `{"type": "string"}`,
+		"root/sub/c.json": `{"type": "string"}`,
+	})
+	require.NoError(t, os.Symlink(filepath.Join(dir, "secret", "b.json"), filepath.Join(dir, "root", "link.json")))
+
+	policy := schemas.Policy{RootDir: filepath.Join(dir, "root"), MaxDocumentSize: 64}
+	parent := filepath.Join(dir, "root", "sub", "parent.json")
+
+	testCases := []struct {
+		desc     string
+		fileName string
+		wantErr  bool
+	}{
+		{desc: "within the root", fileName: "../a.json"},
+		{desc: "in a subdirectory", fileName: "c.json"},
+		{desc: "escaping the root", fileName: "../../secret/b.json", wantErr: true},
+		{desc: "absolute", fileName: filepath.Join(dir, "secret", "b.json"), wantErr: true},
+		{desc: "symlink escaping the root", fileName: "../link.json", wantErr: true},
+		{desc: "too large", fileName: "../big.json", wantErr: true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := schemas.NewFileLoader(nil, nil, schemas.WithPolicy(policy)).Load(tC.fileName, parent)
+			if tC.wantErr {
+				require.ErrorIs(t, err, schemas.ErrPolicyViolation)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPolicyHosts(t *testing.T) {
+	t.Parallel()
+
+	allowed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/redirect" {
+			// Redirected to the same server under another host name.
+			http.Redirect(w, r, strings.Replace(r.Host, "127.0.0.1", "http://localhost", 1)+"/a.json", http.StatusFound)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"type": "string"}`))
+	}))
+	t.Cleanup(allowed.Close)
+
+	u, err := url.Parse(allowed.URL)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		desc    string
+		hosts   []string
+		uri     string
+		wantErr bool
+	}{
+		{desc: "allowed", hosts: []string{"127.0.0.1"}, uri: allowed.URL + "/a.json"},
+		{desc: "allowed with port", hosts: []string{u.Host}, uri: allowed.URL + "/a.json"},
+		{desc: "not allowed", hosts: []string{"example.com"}, uri: allowed.URL + "/a.json", wantErr: true},
+		{desc: "domain", hosts: []string{"*.localhost"}, uri: strings.Replace(allowed.URL, "127.0.0.1", "localhost", 1) + "/a.json"},
+		{desc: "lookalike domain", hosts: []string{"*.example.com"}, uri: "https://evilexample.com/a.json", wantErr: true},
+		{desc: "wildcard without dot", hosts: []string{"*example.com"}, uri: "https://evilexample.com/a.json", wantErr: true},
+		{desc: "redirect", hosts: []string{"127.0.0.1"}, uri: allowed.URL + "/redirect", wantErr: true},
+		{desc: "allowed redirect", hosts: []string{"127.0.0.1", "localhost"}, uri: allowed.URL + "/redirect"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := schemas.NewHTTPLoader(nil, schemas.WithPolicy(schemas.Policy{AllowedHosts: tC.hosts})).
+				Load(tC.uri, "")
+			if tC.wantErr {
+				require.ErrorIs(t, err, schemas.ErrPolicyViolation)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+
+	_, err = schemas.NewHTTPLoader(nil, schemas.WithPolicy(schemas.Policy{MaxDocumentSize: 8})).
+		Load(allowed.URL+"/a.json", "")
+	require.ErrorIs(t, err, schemas.ErrPolicyViolation)
+}
+
+func TestPolicyMaxRefDepth(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	writeFiles(t, dir, map[string]string{
+		"root.json": `{"properties": {"a": {"$ref": "a.json"}, "c": {"$ref": "c.json"}}}`,
+		"a.json":    `{"properties": {"b": {"$ref": "b.json"}}}`,
+		"b.json":    `{"type": "string"}`,
+		"c.json":    `{"type": "string"}`,
+	})
+
+	bundle := func(maxDepth int) error {
+		_, err := schemas.Bundle(filepath.Join(dir, "root.json"), schemas.BundleOptions{
+			Loader: schemas.NewDefaultCacheLoader(nil, nil, schemas.WithPolicy(schemas.Policy{MaxRefDepth: maxDepth})),
+		})
+
+		return err
+	}
+
+	require.NoError(t, bundle(2))
+	assert.ErrorIs(t, bundle(1), schemas.ErrPolicyViolation)
+}
@@ -0,0 +1,369 @@
+package schemas
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+)
+
+// Position is the location of a subschema in the document it was parsed from.
+type Position struct {
+	// File is the file name or URL of the document, if known.
+	File string
+	// Line and Column start at 1; they are 0 if the position is unknown.
+	Line   int
+	Column int
+	// Pointer is the JSON Pointer of the subschema within the document.
+	Pointer string
+}
+
+// IsValid reports whether the position is known.
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// String formats the position as "file:line:column (#pointer)".
+func (p Position) String() string {
+	location := fmt.Sprintf("%d:%d", p.Line, p.Column)
+	if p.File != "" {
+		location = p.File + ":" + location
+	}
+
+	if !p.IsValid() {
+		return "#" + p.Pointer
+	}
+
+	return location + " (#" + p.Pointer + ")"
+}
+
+// PositionError is an error about a subschema, located in the document it comes from.
+type PositionError struct {
+	Position Position
+	Err      error
+}
+
+func (e *PositionError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Position, e.Err)
+}
+
+func (e *PositionError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorAt locates an error at the given subschema. Errors that are already located, most
+// precisely by whatever found them, and subschemas of unknown position are left alone.
+func ErrorAt(t *Type, err error) error {
+	var perr *PositionError
+	if err == nil || t == nil || !t.position.IsValid() || errors.As(err, &perr) {
+		return err
+	}
+
+	return &PositionError{Position: t.position, Err: err}
+}
+
+// Position returns where the subschema was found in its document. It is unknown for
+// subschemas that were not parsed from a document, or were merged from several.
+func (value *Type) Position() Position {
+	return value.position
+}
+
+// setPositions records the position of every subschema, given the positions of the values
+// of the document by JSON Pointer. Positions may name the pointer of the value in the document
+// as written, if it differs from the one it is found at.
+func (s *Schema) setPositions(positions map[string]Position) {
+	has := func(tokens []string) bool {
+		_, ok := positions[FormatJSONPointer(tokens)]
+
+		return ok
+	}
+
+	s.walkSubschemas(func(tokens []string, t *Type) {
+		pointer := FormatJSONPointer(documentTokens(tokens, has))
+
+		if pos, ok := positions[pointer]; ok {
+			if pos.Pointer == "" {
+				pos.Pointer = pointer
+			}
+
+			t.position = pos
+		}
+	})
+}
+
+// setSource records the file name or URL the schema was parsed from.
+func (s *Schema) setSource(source string) {
+	s.walkSubschemas(func(_ []string, t *Type) {
+		t.position.File = source
+	})
+}
+
+// setErrorSource records the file name or URL of the document in the position of a parse
+// error, and reports whether the error had one.
+func setErrorSource(err error, source string) bool {
+	var perr *PositionError
+	if !errors.As(err, &perr) {
+		return false
+	}
+
+	perr.Position.File = source
+
+	return true
+}
+
+// locateParseError turns an error decoding a schema document into an error located at the
+// offending subschema, using the positions of the values of the document if they are known.
+// The document is given as JSON, even when it was read from YAML.
+func locateParseError(data []byte, positions map[string]Position, err error) error {
+	var (
+		perr      *PositionError
+		syntaxErr *json.SyntaxError
+		tokens    []string
+	)
+
+	has := func(tokens []string) bool {
+		_, ok := positions[FormatJSONPointer(tokens)]
+
+		return ok
+	}
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		// The offset is just past the offending character.
+		pos := newLineIndex(data).position(int(syntaxErr.Offset) - 1)
+
+		return &PositionError{Position: pos, Err: err}
+
+	case errors.As(err, &perr):
+		// Located by pointer only, while the document was being decoded.
+		tokens, _ = ParseJSONPointer(perr.Position.Pointer)
+		tokens = documentTokens(tokens, has)
+		err = perr.Err
+
+	default:
+		var doc any
+		if json.Unmarshal(data, &doc) != nil {
+			return err
+		}
+
+		tokens = invalidSubschema(doc, nil)
+
+		// The innermost error names the field of the subschema it failed on.
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			if field := append(tokens[:len(tokens):len(tokens)], strings.Split(typeErr.Field, ".")...); has(field) {
+				tokens = field
+			}
+		}
+	}
+
+	pos, ok := positions[FormatJSONPointer(tokens)]
+	if !ok {
+		return err
+	}
+
+	if pos.Pointer == "" {
+		pos.Pointer = FormatJSONPointer(tokens)
+	}
+
+	return &PositionError{Position: pos, Err: err}
+}
+
+// invalidSubschema returns the tokens of the innermost subschema of a decoded document that
+// cannot be decoded on its own.
+func invalidSubschema(schema any, tokens []string) []string {
+	obj, ok := schema.(map[string]any)
+	if !ok {
+		return tokens
+	}
+
+	for _, sub := range rawSubschemas(obj) {
+		data, err := json.Marshal(sub.schema)
+		if err != nil {
+			continue
+		}
+
+		var t Type
+		if json.Unmarshal(data, &t) != nil {
+			return invalidSubschema(sub.schema, append(tokens[:len(tokens):len(tokens)], sub.path...))
+		}
+	}
+
+	return tokens
+}
+
+// jsonPositions returns the position of every value of a JSON document, by JSON Pointer.
+func jsonPositions(data []byte) (map[string]Position, error) {
+	return valuePositions(data, newLineIndex(data).position)
+}
+
+// valuePositions returns the position of every value of a JSON document, by JSON Pointer, given
+// the position of each byte offset in the document it was translated from.
+func valuePositions(data []byte, position func(offset int) Position) (map[string]Position, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	positions := map[string]Position{}
+
+	var walk func(tokens []string) error
+
+	walk = func(tokens []string) error {
+		// The decoder stops right after the previous token, before any separator.
+		start := int(dec.InputOffset())
+		for start < len(data) && strings.IndexByte(" \t\r\n:,", data[start]) >= 0 {
+			start++
+		}
+
+		positions[FormatJSONPointer(tokens)] = position(start)
+
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("failed to read JSON: %w", err)
+		}
+
+		switch tok {
+		case json.Delim('{'):
+			for dec.More() {
+				key, err := dec.Token()
+				if err != nil {
+					return fmt.Errorf("failed to read JSON: %w", err)
+				}
+
+				name, _ := key.(string)
+				if err := walk(append(tokens[:len(tokens):len(tokens)], name)); err != nil {
+					return err
+				}
+			}
+
+		case json.Delim('['):
+			for i := 0; dec.More(); i++ {
+				if err := walk(append(tokens[:len(tokens):len(tokens)], strconv.Itoa(i))); err != nil {
+					return err
+				}
+			}
+
+		default:
+			return nil
+		}
+
+		// The closing delimiter.
+		if _, err := dec.Token(); err != nil {
+			return fmt.Errorf("failed to read JSON: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := walk(nil); err != nil {
+		return nil, err
+	}
+
+	return positions, nil
+}
+
+// yamlPositions returns the position of every value of a YAML document, by JSON Pointer. The
+// value of a mapping key is located at the key, the way an editor shows the entry.
+func yamlPositions(data []byte) (map[string]Position, error) {
+	file, err := parser.ParseBytes(data, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	positions := map[string]Position{}
+
+	// A mapping is located at its first key rather than at the token the parser keeps for it.
+	at := func(n ast.Node) Position {
+		switch node := n.(type) {
+		case *ast.MappingNode:
+			if len(node.Values) > 0 {
+				n = node.Values[0].Key
+			}
+
+		case *ast.MappingValueNode:
+			n = node.Key
+		}
+
+		if tok := n.GetToken(); tok != nil && tok.Position != nil {
+			return Position{Line: tok.Position.Line, Column: tok.Position.Column}
+		}
+
+		return Position{}
+	}
+
+	var walk func(n ast.Node, tokens []string, pos Position)
+
+	walk = func(n ast.Node, tokens []string, pos Position) {
+		positions[FormatJSONPointer(tokens)] = pos
+
+		switch node := n.(type) {
+		case *ast.DocumentNode:
+			walk(node.Body, tokens, pos)
+
+		case *ast.AnchorNode:
+			walk(node.Value, tokens, pos)
+
+		case *ast.TagNode:
+			walk(node.Value, tokens, pos)
+
+		case *ast.MappingNode:
+			for _, value := range node.Values {
+				walk(value, tokens, pos)
+			}
+
+		case *ast.MappingValueNode:
+			key := node.Key.GetToken()
+			if key == nil {
+				return
+			}
+
+			walk(node.Value, append(tokens[:len(tokens):len(tokens)], key.Value), at(node.Key))
+
+		case *ast.SequenceNode:
+			for i, value := range node.Values {
+				walk(value, append(tokens[:len(tokens):len(tokens)], strconv.Itoa(i)), at(value))
+			}
+		}
+	}
+
+	if len(file.Docs) > 0 && file.Docs[0].Body != nil {
+		root := file.Docs[0].Body
+		walk(root, nil, at(root))
+	}
+
+	return positions, nil
+}
+
+// lineIndex converts byte offsets into lines and columns.
+type lineIndex struct {
+	data   []byte
+	starts []int
+}
+
+func newLineIndex(data []byte) *lineIndex {
+	starts := []int{0}
+
+	for i, b := range data {
+		if b == '\n' {
+			starts = append(starts, i+1)
+		}
+	}
+
+	return &lineIndex{data: data, starts: starts}
+}
+
+func (l *lineIndex) position(offset int) Position {
+	offset = min(max(offset, 0), len(l.data))
+	line := sort.Search(len(l.starts), func(i int) bool {
+		return l.starts[i] > offset
+	})
+
+	return Position{
+		Line:   line,
+		Column: utf8.RuneCount(l.data[l.starts[line-1]:offset]) + 1,
+	}
+}
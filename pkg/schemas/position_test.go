@@ -0,0 +1,176 @@
+package schemas_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/atombender/go-jsonschema/pkg/schemas"
+)
+
+func TestPositions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		schema  string
+		yaml    bool
+		pointer []string
+		want    string
+	}{
+		{
+			desc: "JSON root",
+			schema: `{
+  "type": "object"
+}`,
+			want: "1:1 (#)",
+		},
+		{
+			desc: "JSON property",
+			schema: `{
+  "properties": {
+    "name": {"type": "string"}
+  }
+}`,
+			pointer: []string{"properties", "name"},
+			want:    "3:13 (#/properties/name)",
+		},
+		{
+			desc: "JSON definitions",
+			schema: `{
+  "definitions": {
+    "a": {"type": "string"}
+  }
+}`,
+			pointer: []string{"$defs", "a"},
+			want:    "3:10 (#/definitions/a)",
+		},
+		{
+			desc: "JSON tuple",
+			schema: `{
+  "prefixItems": [
+    {"type": "string"},
+    {"type": "integer"}
+  ]
+}`,
+			pointer: []string{"prefixItems", "1"},
+			want:    "4:5 (#/prefixItems/1)",
+		},
+		{
+			desc: "YAML root",
+			yaml: true,
+			schema: `
+type: object
+`,
+			want: "2:1 (#)",
+		},
+		{
+			desc: "YAML property",
+			yaml: true,
+			schema: `type: object
+properties:
+  name:
+    type: string
+  tags:
+    items:
+      - type: string
+`,
+			pointer: []string{"properties", "tags", "prefixItems", "0"},
+			want:    "7:9 (#/properties/tags/items/0)",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				schema *schemas.Schema
+				err    error
+			)
+
+			if tC.yaml {
+				schema, err = schemas.FromYAMLReader(strings.NewReader(tC.schema))
+			} else {
+				schema, err = schemas.FromJSONReader(strings.NewReader(tC.schema))
+			}
+
+			require.NoError(t, err)
+
+			typ, err := schema.ResolveJSONPointer(tC.pointer)
+			require.NoError(t, err)
+
+			assert.Equal(t, tC.want, typ.Position().String())
+		})
+	}
+}
+
+func TestParseErrorPositions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc   string
+		file   string
+		schema string
+		want   string
+	}{
+		{
+			desc:   "JSON syntax",
+			file:   "schema.json",
+			schema: "{\n  \"type\": \"object\",\n}",
+			want:   "schema.json:3:1 (#): failed to unmarshal JSON: invalid character '}'",
+		},
+		{
+			desc: "JSON type",
+			file: "schema.json",
+			schema: `{
+  "properties": {
+    "a": {"minLength": "1"}
+  }
+}`,
+			want: "schema.json:3:24 (#/properties/a/minLength): failed to unmarshal JSON:",
+		},
+		{
+			desc: "JSON dialect",
+			file: "schema.json",
+			schema: `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "properties": {
+    "a": {"items": [{"type": "string"}]}
+  }
+}`,
+			want: "schema.json:4:10 (#/properties/a): invalid keyword for dialect:",
+		},
+		{
+			desc: "YAML type",
+			file: "schema.yaml",
+			schema: `properties:
+  a:
+    required: yes
+`,
+			want: "schema.yaml:3:5 (#/properties/a/required): failed to unmarshal JSON:",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			fileName := filepath.Join(t.TempDir(), tC.file)
+			require.NoError(t, os.WriteFile(fileName, []byte(tC.schema), 0o600))
+
+			var err error
+			if strings.HasSuffix(tC.file, ".yaml") {
+				_, err = schemas.FromYAMLFile(fileName)
+			} else {
+				_, err = schemas.FromJSONFile(fileName)
+			}
+
+			var perr *schemas.PositionError
+			require.ErrorAs(t, err, &perr)
+			assert.Contains(t, err.Error(), filepath.Dir(fileName)+string(filepath.Separator)+tC.want)
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
+	"sync"
 )
 
 var (
@@ -23,23 +25,66 @@ const (
 	RefTypeUnknown RefType = "unknown"
 )
 
+// Schemes registered with RegisterScheme.
+var schemes sync.Map
+
+// RegisterScheme makes GetRefType accept references with the given URI scheme, such as "embed", "git"
+// or "urn", and returns the RefType that MultiLoader dispatches them by. Loaders for a scheme, given
+// with WithSchemeLoader or added to a MultiLoader directly, load its references without it.
+func RegisterScheme(scheme string) RefType {
+	scheme = strings.ToLower(scheme)
+	schemes.Store(scheme, true)
+
+	return RefType(scheme)
+}
+
 func GetRefType(ref string) (RefType, error) {
+	refType, err := schemeRefType(ref)
+	if err != nil {
+		return RefTypeUnknown, err
+	}
+
+	switch refType {
+	case RefTypeFile, RefTypeHTTP, RefTypeHTTPS:
+		return refType, nil
+	}
+
+	if _, ok := schemes.Load(string(refType)); ok {
+		return refType, nil
+	}
+
+	return RefTypeUnknown, fmt.Errorf("%w: %w", ErrGetRefType, ErrUnsupportedRefSchema)
+}
+
+// schemeRefType returns the RefType of a reference by its URI scheme, whether the scheme is registered or not.
+func schemeRefType(ref string) (RefType, error) {
 	urlRef, err := url.Parse(ref)
 	if err != nil {
 		return RefTypeUnknown, fmt.Errorf("%w: %w", ErrGetRefType, err)
 	}
 
-	switch urlRef.Scheme {
-	case "http":
-		return RefTypeHTTP, nil
+	if urlRef.Scheme == "file" || urlRef.Scheme == "" {
+		return RefTypeFile, nil
+	}
+
+	return RefType(urlRef.Scheme), nil
+}
 
-	case "https":
-		return RefTypeHTTPS, nil
+// refURI returns the URI that a reference made from the parent document points to, resolved
+// against the URI of the parent if it was not loaded from a file. References to files from files
+// are left to file loaders to resolve, as they may need extensions added.
+func refURI(uri, parentURI string) string {
+	if parentURI == "" {
+		return uri
+	}
 
-	case "file", "":
-		return RefTypeFile, nil
+	if refType, err := schemeRefType(uri); err != nil || refType != RefTypeFile || strings.HasPrefix(uri, "file:") {
+		return uri
+	}
 
-	default:
-		return RefTypeUnknown, fmt.Errorf("%w: %w", ErrGetRefType, ErrUnsupportedRefSchema)
+	if parentType, err := schemeRefType(parentURI); err != nil || parentType == RefTypeFile {
+		return uri
 	}
+
+	return resolveURI(parentURI, uri)
 }
@@ -0,0 +1,130 @@
+package schemas_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/atombender/go-jsonschema/pkg/schemas"
+)
+
+func TestGetRefType(t *testing.T) {
+	t.Parallel()
+
+	git := schemas.RegisterScheme("git")
+
+	testCases := []struct {
+		ref     string
+		want    schemas.RefType
+		wantErr error
+	}{
+		{ref: "schema.json", want: schemas.RefTypeFile},
+		{ref: "file:///schemas/schema.json", want: schemas.RefTypeFile},
+		{ref: "https://example.com/schema.json", want: schemas.RefTypeHTTPS},
+		{ref: "git://repo@v1.0.0/schema.json", want: git},
+		{ref: "ftp://example.com/schema.json", wantErr: schemas.ErrUnsupportedRefSchema},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.ref, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := schemas.GetRefType(tC.ref)
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tC.want, got)
+		})
+	}
+}
+
+func TestQualifiedFileNameOfURIs(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		fileName string
+		parent   string
+		want     string
+	}{
+		{fileName: "https://example.com/a.json", parent: "root.json", want: "https://example.com/a.json"},
+		{fileName: "b.json", parent: "https://example.com/schemas/a.json", want: "https://example.com/schemas/b.json"},
+		{fileName: "../b.json#/$defs/x", parent: "embed://common/a/a.json", want: "embed://common/b.json#/$defs/x"},
+		{fileName: "urn:example:a", parent: "embed://common/a.json", want: "urn:example:a"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.fileName, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := schemas.QualifiedFileName(tC.fileName, tC.parent, nil)
+			require.NoError(t, err)
+			assert.Equal(t, tC.want, got)
+		})
+	}
+}
+
+func TestWithSchemeLoader(t *testing.T) {
+	t.Parallel()
+
+	documents := map[string]string{
+		"mem://schemas/a.json":        `{"properties": {"b": {"$ref": "common/b.json"}}}`,
+		"mem://schemas/common/b.json": `{"type": "string"}`,
+	}
+
+	var loaded []string
+
+	loader := schemas.NewDefaultCacheLoader(nil, nil, schemas.WithSchemeLoader("mem",
+		schemas.LoaderFunc(func(uri, _ string) (*schemas.Schema, error) {
+			loaded = append(loaded, uri)
+
+			document, ok := documents[uri]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", schemas.ErrCannotResolveSchema, uri)
+			}
+
+			return schemas.FromJSONReader(strings.NewReader(document))
+		})))
+
+	a, err := loader.Load("mem://schemas/a.json", "")
+	require.NoError(t, err)
+	assert.Contains(t, a.Properties, "b")
+
+	b, err := loader.Load("common/b.json", "mem://schemas/a.json")
+	require.NoError(t, err)
+	assert.Equal(t, schemas.TypeList{"string"}, b.Type)
+
+	_, err = loader.Load("mem://schemas/common/b.json", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"mem://schemas/a.json", "mem://schemas/common/b.json"}, loaded)
+}
+
+func TestWithSchemeLoaderPerLoader(t *testing.T) {
+	t.Parallel()
+
+	loaderOf := func(typ string) schemas.Loader {
+		return schemas.NewDefaultCacheLoader(nil, nil, schemas.WithSchemeLoader("scoped",
+			schemas.LoaderFunc(func(string, string) (*schemas.Schema, error) {
+				return schemas.FromJSONReader(strings.NewReader(`{"type": "` + typ + `"}`))
+			})))
+	}
+
+	s, err := loaderOf("string").Load("scoped://a.json", "")
+	require.NoError(t, err)
+	assert.Equal(t, schemas.TypeList{"string"}, s.Type)
+
+	i, err := loaderOf("integer").Load("scoped://a.json", "")
+	require.NoError(t, err)
+	assert.Equal(t, schemas.TypeList{"integer"}, i.Type)
+
+	_, err = schemas.NewDefaultCacheLoader(nil, nil).Load("scoped://a.json", "")
+	require.ErrorIs(t, err, schemas.ErrUnsupportedRefSchema)
+
+	_, err = schemas.GetRefType("scoped://a.json")
+	require.ErrorIs(t, err, schemas.ErrUnsupportedRefSchema)
+}
@@ -0,0 +1,312 @@
+package schemas
+
+import (
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// Registry indexes schema resources by the absolute URIs that identify them: the URI a
+// document was retrieved from, every $id and every $anchor. Base URIs are established as
+// defined by RFC draft-bhutton-json-schema-01, section 8.2: an $id is resolved against
+// the base URI of its parent resource, and in turn becomes the base URI of its subschemas.
+type Registry struct {
+	resources map[string]*Resource
+	documents map[string]*Schema
+	baseURIs  map[*Type]string
+	// retrievalURIs holds the URI of the document each subschema was retrieved from.
+	retrievalURIs map[*Type]string
+	locations     map[*Schema]string
+}
+
+// Resource is a schema, or a subschema identified by its own $id or $anchor.
+type Resource struct {
+	// Document is the schema document the resource was found in.
+	Document *Schema
+	// Location is the file name or URL the document was loaded from.
+	Location string
+	// Pointer holds the JSON Pointer tokens of the resource within the document.
+	Pointer []string
+}
+
+func NewRegistry() *Registry {
+	return &Registry{
+		resources:     map[string]*Resource{},
+		documents:     map[string]*Schema{},
+		baseURIs:      map[*Type]string{},
+		retrievalURIs: map[*Type]string{},
+		locations:     map[*Schema]string{},
+	}
+}
+
+// Register indexes a schema document loaded from the given file name or URL.
+// Registering the same document again has no effect.
+func (r *Registry) Register(schema *Schema, location string) {
+	if _, ok := r.locations[schema]; ok {
+		return
+	}
+
+	retrievalURI := locationURI(location)
+
+	// Keep file names absolute, so they remain valid when handed out to other files.
+	if u, err := url.Parse(retrievalURI); err == nil && u.Scheme == "file" {
+		location = filepath.FromSlash(u.Path)
+	}
+
+	r.locations[schema] = location
+	if _, ok := r.documents[retrievalURI]; !ok {
+		r.documents[retrievalURI] = schema
+	}
+
+	root := &Resource{Document: schema, Location: location}
+	r.add(retrievalURI, root)
+
+	rootBase := retrievalURI
+	if schema.ID != "" {
+		rootBase = stripFragment(resolveURI(retrievalURI, schema.ID))
+		r.add(rootBase, root)
+	}
+
+	// Base URIs established along the way, by JSON Pointer.
+	bases := map[string]string{"": rootBase}
+
+	schema.walkSubschemas(func(tokens []string, t *Type) {
+		base := rootBase
+
+		for i := len(tokens) - 1; i >= 0; i-- {
+			if b, ok := bases[FormatJSONPointer(tokens[:i])]; ok {
+				base = b
+
+				break
+			}
+		}
+
+		resource := &Resource{
+			Document: schema,
+			Location: location,
+			Pointer:  tokens,
+		}
+
+		if id := t.ID; id != "" && len(tokens) > 0 {
+			if anchor, ok := strings.CutPrefix(id, "#"); ok {
+				// Plain name fragments were used as anchors before $anchor was introduced.
+				r.add(base+"#"+anchor, resource)
+			} else {
+				base = stripFragment(resolveURI(base, id))
+				bases[FormatJSONPointer(tokens)] = base
+
+				r.add(base, resource)
+			}
+		}
+
+		for _, anchor := range []string{t.Anchor, t.DynamicAnchor} {
+			if anchor == "" {
+				continue
+			}
+
+			r.add(base+"#"+anchor, resource)
+
+			if base == rootBase && base != retrievalURI {
+				r.add(retrievalURI+"#"+anchor, resource)
+			}
+		}
+
+		r.baseURIs[t] = base
+		r.retrievalURIs[t] = retrievalURI
+	})
+}
+
+// Resolve looks up the resource that a reference made from the given subschema points to.
+// The reference is resolved against the base URI in effect for that subschema, and any JSON
+// Pointer in its fragment is followed from the resource it is relative to.
+func (r *Registry) Resolve(ref string, from *Type) (*Resource, bool) {
+	abs := resolveURI(r.baseURIs[from], ref)
+
+	if res, ok := r.resources[abs]; ok {
+		return res, true
+	}
+
+	uri, fragment, ok := strings.Cut(abs, "#")
+	if !ok || !strings.HasPrefix(fragment, "/") {
+		return nil, false
+	}
+
+	res, ok := r.resources[uri]
+	if !ok {
+		return nil, false
+	}
+
+	unescaped, err := url.PathUnescape(fragment)
+	if err != nil {
+		return nil, false
+	}
+
+	tokens, err := ParseJSONPointer(unescaped)
+	if err != nil {
+		return nil, false
+	}
+
+	return &Resource{
+		Document: res.Document,
+		Location: res.Location,
+		Pointer:  res.Document.ModelTokens(append(res.Pointer[:len(res.Pointer):len(res.Pointer)], tokens...)),
+	}, true
+}
+
+// RefLocations returns the file names or URLs that the document a reference made from the given
+// subschema points into may be loaded from, in order of preference, and the fragment of the
+// reference. The reference is resolved against the base URI of the subschema, as set by the
+// nearest $id, as well as against the location of its document when that differs. A document
+// retrieved over the network is taken at its word, whereas one read from a file most likely
+// refers to the files next to it, whatever its $id says.
+func (r *Registry) RefLocations(ref string, from *Type) ([]string, string) {
+	base, retrieval := r.baseURIs[from], r.retrievalURIs[from]
+
+	// File URIs with relative paths are accepted too, though they are no URIs at all.
+	if fileName, ok := strings.CutPrefix(ref, "file://"); ok && !filepath.IsAbs(fileName) {
+		ref = filepath.ToSlash(fileName)
+	}
+
+	uri, fragment, _ := strings.Cut(resolveURI(base, ref), "#")
+	uris := []string{uri}
+
+	if retrieval != "" && retrieval != base {
+		if byRetrieval := stripFragment(resolveURI(retrieval, ref)); byRetrieval != uri {
+			if strings.HasPrefix(retrieval, "file:") {
+				uris = []string{byRetrieval, uri}
+			} else {
+				uris = append(uris, byRetrieval)
+			}
+		}
+	}
+
+	locations := make([]string, 0, len(uris))
+
+	for _, uri := range uris {
+		if u, err := url.Parse(uri); err == nil && u.Scheme == "file" {
+			uri = filepath.FromSlash(u.Path)
+		}
+
+		locations = append(locations, uri)
+	}
+
+	return locations, fragment
+}
+
+// loadRef loads the document that a reference made from the given subschema points into, from
+// the first of its locations that can be loaded, and returns it with the fragment of the reference.
+func (r *Registry) loadRef(loader Loader, ref string, from *Type, parentURI string) (*Schema, string, error) {
+	locations, fragment := r.RefLocations(ref, from)
+
+	var firstErr error
+
+	for _, location := range locations {
+		document, err := loader.Load(location, parentURI)
+		if err == nil {
+			return document, fragment, nil
+		}
+
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return nil, "", firstErr
+}
+
+// Location returns the file name or URL a registered schema document was loaded from.
+func (r *Registry) Location(schema *Schema) (string, bool) {
+	location, ok := r.locations[schema]
+
+	return location, ok
+}
+
+// Document returns the schema document previously registered for the given file name or URL.
+func (r *Registry) Document(location string) (*Schema, bool) {
+	schema, ok := r.documents[locationURI(location)]
+
+	return schema, ok
+}
+
+func (r *Registry) add(uri string, res *Resource) {
+	if _, ok := r.resources[uri]; !ok {
+		r.resources[uri] = res
+	}
+}
+
+// NewRegistryLoader returns a loader that serves documents already known to the registry,
+// and registers every document it loads through the given loader.
+func NewRegistryLoader(loader Loader, registry *Registry, resolveExtensions []string) *RegistryLoader {
+	return &RegistryLoader{
+		loader:            loader,
+		registry:          registry,
+		resolveExtensions: resolveExtensions,
+	}
+}
+
+type RegistryLoader struct {
+	loader            Loader
+	registry          *Registry
+	resolveExtensions []string
+}
+
+func (l *RegistryLoader) Load(uri, parentURI string) (*Schema, error) {
+	location := uri
+
+	if refType, err := GetRefType(uri); err == nil && refType == RefTypeFile {
+		if qualified, err := LoaderQualifiedFileName(l.loader, uri, parentURI, l.resolveExtensions); err == nil {
+			location = qualified
+		}
+	}
+
+	if schema, ok := l.registry.Document(location); ok {
+		return schema, nil
+	}
+
+	schema, err := l.loader.Load(uri, parentURI)
+	if err != nil {
+		return nil, err
+	}
+
+	l.registry.Register(schema, location)
+
+	return schema, nil
+}
+
+func (l *RegistryLoader) QualifiedFileName(fileName, parentFileName string) (string, error) {
+	return LoaderQualifiedFileName(l.loader, fileName, parentFileName, l.resolveExtensions)
+}
+
+// locationURI turns a file name or URL into an absolute URI.
+func locationURI(location string) string {
+	if u, err := url.Parse(location); err == nil && u.Scheme != "" && u.Scheme != "file" {
+		return stripFragment(u.String())
+	}
+
+	fileName := strings.TrimPrefix(location, "file://")
+	if abs, err := filepath.Abs(fileName); err == nil {
+		fileName = abs
+	}
+
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(fileName)}).String()
+}
+
+func resolveURI(base, ref string) string {
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return ref
+	}
+
+	baseURL, err := url.Parse(base)
+	if err != nil || base == "" {
+		return refURL.String()
+	}
+
+	return baseURL.ResolveReference(refURL).String()
+}
+
+func stripFragment(uri string) string {
+	uri, _, _ = strings.Cut(uri, "#")
+
+	return uri
+}
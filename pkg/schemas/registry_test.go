@@ -0,0 +1,183 @@
+package schemas_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/atombender/go-jsonschema/pkg/schemas"
+)
+
+func TestRegistryResolve(t *testing.T) {
+	t.Parallel()
+
+	var schema schemas.Schema
+
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"$id": "https://example.com/root/schema.json",
+		"$defs": {
+			"a": {"$anchor": "anchored"},
+			"b": {
+				"$id": "nested/b.json",
+				"$defs": {"c": {"$anchor": "inner"}},
+				"properties": {"c": {"$ref": "#/$defs/c"}}
+			},
+			"d": {"$id": "#legacy"}
+		},
+		"properties": {"x": {"$ref": "#anchored"}}
+	}`), &schema))
+
+	registry := schemas.NewRegistry()
+	registry.Register(&schema, "/schemas/schema.json")
+
+	root := (*schemas.Type)(schema.ObjectAsType)
+	b := schema.Definitions["b"]
+
+	testCases := []struct {
+		desc  string
+		ref   string
+		from  *schemas.Type
+		want  []string
+		found bool
+	}{
+		{
+			desc:  "anchor",
+			ref:   "#anchored",
+			from:  root.Properties["x"],
+			want:  []string{"$defs", "a"},
+			found: true,
+		},
+		{
+			desc:  "absolute anchor",
+			ref:   "https://example.com/root/schema.json#anchored",
+			from:  nil,
+			want:  []string{"$defs", "a"},
+			found: true,
+		},
+		{
+			desc:  "legacy plain name fragment",
+			ref:   "#legacy",
+			from:  root,
+			want:  []string{"$defs", "d"},
+			found: true,
+		},
+		{
+			desc:  "relative $id",
+			ref:   "nested/b.json",
+			from:  root,
+			want:  []string{"$defs", "b"},
+			found: true,
+		},
+		{
+			desc:  "pointer relative to embedded resource",
+			ref:   "#/$defs/c",
+			from:  b.Properties["c"],
+			want:  []string{"$defs", "b", "$defs", "c"},
+			found: true,
+		},
+		{
+			desc:  "anchor scoped to embedded resource",
+			ref:   "https://example.com/root/nested/b.json#inner",
+			from:  nil,
+			want:  []string{"$defs", "b", "$defs", "c"},
+			found: true,
+		},
+		{
+			desc:  "anchor of embedded resource is not visible from root",
+			ref:   "#inner",
+			from:  root,
+			found: false,
+		},
+		{
+			desc:  "retrieval URI",
+			ref:   "file:///schemas/schema.json#/properties/x",
+			from:  nil,
+			want:  []string{"properties", "x"},
+			found: true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			res, ok := registry.Resolve(tC.ref, tC.from)
+			require.Equal(t, tC.found, ok)
+
+			if !ok {
+				return
+			}
+
+			assert.Same(t, &schema, res.Document)
+			assert.Equal(t, tC.want, res.Pointer)
+		})
+	}
+}
+
+func TestRegistryRefLocations(t *testing.T) {
+	t.Parallel()
+
+	parse := func(document string) *schemas.Schema {
+		var schema schemas.Schema
+
+		require.NoError(t, json.Unmarshal([]byte(document), &schema))
+
+		return &schema
+	}
+
+	registry := schemas.NewRegistry()
+
+	local := parse(`{"properties": {"a": {"$ref": "a.json#/$defs/x"}}}`)
+	registry.Register(local, "/schemas/local.json")
+
+	identified := parse(`{"$id": "https://example.com/v1/schema.json", "properties": {"a": {"$ref": "a.json"}}}`)
+	registry.Register(identified, "/schemas/identified.json")
+
+	remote := parse(`{
+		"$id": "https://example.com/v2/schema.json",
+		"properties": {
+			"a": {"$ref": "a.json"},
+			"b": {"$id": "nested/", "$ref": "b.json"}
+		}
+	}`)
+	registry.Register(remote, "https://mirror.example.com/schemas/schema.json")
+
+	testCases := []struct {
+		desc         string
+		from         *schemas.Type
+		want         []string
+		wantFragment string
+	}{
+		{
+			desc:         "file",
+			from:         local.Properties["a"],
+			want:         []string{"/schemas/a.json"},
+			wantFragment: "/$defs/x",
+		},
+		{
+			desc: "file identified by $id",
+			from: identified.Properties["a"],
+			want: []string{"/schemas/a.json", "https://example.com/v1/a.json"},
+		},
+		{
+			desc: "remote document identified by $id",
+			from: remote.Properties["a"],
+			want: []string{"https://example.com/v2/a.json", "https://mirror.example.com/schemas/a.json"},
+		},
+		{
+			desc: "nested $id",
+			from: remote.Properties["b"],
+			want: []string{"https://example.com/v2/nested/b.json", "https://mirror.example.com/schemas/b.json"},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			got, fragment := registry.RefLocations(tC.from.Ref, tC.from)
+			assert.Equal(t, tC.want, got)
+			assert.Equal(t, tC.wantFragment, fragment)
+		})
+	}
+}
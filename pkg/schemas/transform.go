@@ -0,0 +1,108 @@
+package schemas
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// Transformer changes a schema document once it is loaded, such as to drop a property, name a type
+// or tighten a type without forking the document. It is given the file name or URI the document was
+// loaded from.
+type Transformer func(location string, schema *Schema) error
+
+// PatchTransformer returns a transformer applying a patch to the document with the given $id, or
+// loaded from the given file or URI.
+func PatchTransformer(idOrLocation string, patch *Patch) Transformer {
+	return func(location string, schema *Schema) error {
+		if idOrLocation != schema.ID && !sameLocation(idOrLocation, location) {
+			return nil
+		}
+
+		return patch.Apply(schema)
+	}
+}
+
+// sameLocation reports whether two file names or URIs refer to the same document.
+func sameLocation(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	return absoluteLocation(a) == absoluteLocation(b)
+}
+
+func absoluteLocation(location string) string {
+	qualified, err := QualifiedFileName(location, "", nil)
+	if err != nil {
+		return location
+	}
+
+	if refType, err := GetRefType(qualified); err == nil && refType == RefTypeFile {
+		if abs, err := filepath.Abs(qualified); err == nil {
+			return abs
+		}
+	}
+
+	return qualified
+}
+
+func NewTransformingLoader(loader Loader, resolveExtensions []string, transformers ...Transformer) *TransformingLoader {
+	return &TransformingLoader{
+		loader:            loader,
+		resolveExtensions: resolveExtensions,
+		transformers:      transformers,
+		transformed:       map[*Schema]bool{},
+	}
+}
+
+// TransformingLoader applies transformers to the documents it loads, in turn. Each document is
+// transformed once, however many times it is loaded.
+type TransformingLoader struct {
+	loader            Loader
+	resolveExtensions []string
+	transformers      []Transformer
+	transformed       map[*Schema]bool
+}
+
+func (l *TransformingLoader) Load(uri, parentURI string) (*Schema, error) {
+	schema, err := l.loader.Load(uri, parentURI)
+	if err != nil || len(l.transformers) == 0 {
+		return schema, err
+	}
+
+	location := uri
+
+	if refType, err := GetRefType(uri); err == nil && refType == RefTypeFile {
+		if qualified, err := LoaderQualifiedFileName(l.loader, uri, parentURI, l.resolveExtensions); err == nil {
+			location = qualified
+		}
+	}
+
+	if err := l.Transform(location, schema); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// Transform applies the transformers to a document loaded from elsewhere, unless it is already
+// transformed.
+func (l *TransformingLoader) Transform(location string, schema *Schema) error {
+	if l.transformed[schema] {
+		return nil
+	}
+
+	l.transformed[schema] = true
+
+	for _, transform := range l.transformers {
+		if err := transform(location, schema); err != nil {
+			return fmt.Errorf("failed to transform schema %s: %w", location, err)
+		}
+	}
+
+	return nil
+}
+
+func (l *TransformingLoader) QualifiedFileName(fileName, parentFileName string) (string, error) {
+	return qualifyWith(l.loader, fileName, parentFileName)
+}
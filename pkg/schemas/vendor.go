@@ -0,0 +1,148 @@
+package schemas
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type VendorOptions struct {
+	// Dir is the directory the documents are saved under, in a directory for each host.
+	Dir string
+	// ResolveExtensions are tried in turn on file names that do not exist as they are.
+	ResolveExtensions []string
+	// YAMLExtensions are the file extensions of YAML schemas.
+	YAMLExtensions []string
+	// JSONCExtensions are the file extensions of JSONC or JSON5 schemas.
+	JSONCExtensions []string
+	// LoaderOptions configure the loader the documents are retrieved with.
+	LoaderOptions []LoaderOption
+}
+
+// Vendor retrieves every schema document referenced over HTTP or HTTPS from the given schemas,
+// directly or through other documents, and saves it as is under the directory of the options, at
+// the path of its URL in a directory named after its host, with a short hash of its query string, if
+// any, added to the file name. It returns the mappings that make those URLs load from the saved
+// documents, for WithURIMappings or generator.Config.URIMappings.
+func Vendor(fileNames []string, opts VendorOptions) ([]URIMapping, error) {
+	fetched := map[string][]byte{}
+
+	loaderOpts := append(opts.LoaderOptions[:len(opts.LoaderOptions):len(opts.LoaderOptions)],
+		WithJSONCExtensions(opts.JSONCExtensions...),
+		withFetched(func(uri string, body []byte) {
+			fetched[uri] = body
+		}))
+
+	registry := NewRegistry()
+	v := &vendorer{
+		loader: NewRegistryLoader(
+			NewDefaultCacheLoader(opts.ResolveExtensions, opts.YAMLExtensions, loaderOpts...),
+			registry,
+			opts.ResolveExtensions,
+		),
+		registry: registry,
+		seen:     map[*Schema]bool{},
+	}
+
+	for _, fileName := range fileNames {
+		if err := v.follow(fileName); err != nil {
+			return nil, err
+		}
+	}
+
+	uris := make([]string, 0, len(fetched))
+	for uri := range fetched {
+		uris = append(uris, uri)
+	}
+
+	sort.Strings(uris)
+
+	var mappings []URIMapping
+
+	dirs := map[string]bool{}
+
+	for _, uri := range uris {
+		u, err := url.Parse(uri)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse url: %w", err)
+		}
+
+		filePath := strings.TrimPrefix(path.Clean("/"+u.Path), "/")
+		if filePath == "" || strings.HasSuffix(u.Path, "/") {
+			return nil, fmt.Errorf("%w: %q has no file name to be saved under", ErrUnsupportedURL, uri)
+		}
+
+		// Ports are kept apart from host names, as colons are not allowed in file names everywhere.
+		dir := filepath.Join(opts.Dir, strings.ReplaceAll(u.Host, ":", "_"))
+		fileName := filepath.Join(dir, filepath.FromSlash(queryFilePath(filePath, u.RawQuery)))
+
+		if err := os.MkdirAll(filepath.Dir(fileName), 0o755); err != nil {
+			return nil, fmt.Errorf("failed to create directory: %w", err)
+		}
+
+		if err := os.WriteFile(fileName, fetched[uri], 0o644); err != nil { //nolint:gosec // Meant to be committed.
+			return nil, fmt.Errorf("failed to write vendored schema: %w", err)
+		}
+
+		if prefix := u.Scheme + "://" + u.Host + "/"; !dirs[prefix] {
+			dirs[prefix] = true
+			mappings = append(mappings, URIMapping{Prefix: prefix, Dir: dir})
+		}
+	}
+
+	return mappings, nil
+}
+
+type vendorer struct {
+	loader   Loader
+	registry *Registry
+	seen     map[*Schema]bool
+}
+
+// follow loads a schema document and every document its references lead to.
+func (v *vendorer) follow(fileName string) error {
+	schema, err := v.loader.Load(fileName, "")
+	if err != nil {
+		return err
+	}
+
+	queue := []*Schema{schema}
+	v.seen[schema] = true
+
+	for len(queue) > 0 {
+		document := queue[0]
+		queue = queue[1:]
+
+		var refs []*Type
+
+		document.walkSubschemas(func(_ []string, t *Type) {
+			if t.Ref != "" {
+				refs = append(refs, t)
+			}
+		})
+
+		for _, t := range refs {
+			if _, ok := v.registry.Resolve(t.Ref, t); ok {
+				continue
+			}
+
+			parent, _ := v.registry.Location(document)
+
+			referenced, _, err := v.registry.loadRef(v.loader, t.Ref, t, parent)
+			if err != nil {
+				return ErrorAt(t, fmt.Errorf("could not follow $ref %q: %w", t.Ref, err))
+			}
+
+			if !v.seen[referenced] {
+				v.seen[referenced] = true
+				queue = append(queue, referenced)
+			}
+		}
+	}
+
+	return nil
+}
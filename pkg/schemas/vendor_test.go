@@ -0,0 +1,110 @@
+package schemas_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/atombender/go-jsonschema/pkg/schemas"
+)
+
+func TestVendor(t *testing.T) {
+	t.Parallel()
+
+	documents := map[string]string{
+		"/schemas/a.json":        `{"properties": {"b": {"$ref": "common/b.yaml#/$defs/B"}, "self": {"$ref": "#"}}}`,
+		"/schemas/common/b.yaml": "$defs:\n  B:\n    $ref: '../a.json'\n",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		document, ok := documents[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+
+			return
+		}
+
+		_, _ = w.Write([]byte(document))
+	}))
+
+	dir := t.TempDir()
+
+	writeFiles(t, dir, map[string]string{
+		"root.json":  `{"properties": {"a": {"$ref": "` + server.URL + `/schemas/a.json"}, "local": {"$ref": "local.json"}}}`,
+		"local.json": `{"type": "string"}`,
+	})
+
+	mappings, err := schemas.Vendor([]string{filepath.Join(dir, "root.json")}, schemas.VendorOptions{
+		Dir:            filepath.Join(dir, "vendor"),
+		YAMLExtensions: []string{".yaml"},
+	})
+	require.NoError(t, err)
+
+	host := strings.ReplaceAll(strings.TrimPrefix(server.URL, "http://"), ":", "_")
+	assert.Equal(t, []schemas.URIMapping{
+		{Prefix: server.URL + "/", Dir: filepath.Join(dir, "vendor", host)},
+	}, mappings)
+
+	for name, document := range documents {
+		data, err := os.ReadFile(filepath.Join(dir, "vendor", host, filepath.FromSlash(name)))
+		require.NoError(t, err)
+		assert.Equal(t, document, string(data))
+	}
+
+	// The vendored documents are enough to bundle the schema.
+	server.Close()
+
+	bundle, err := schemas.Bundle(filepath.Join(dir, "root.json"), schemas.BundleOptions{
+		Loader: schemas.NewDefaultCacheLoader(nil, []string{".yaml"}, schemas.WithURIMappings(mappings...)),
+	})
+	require.NoError(t, err)
+	assert.Contains(t, string(bundle), `"B"`)
+}
+
+func TestVendorQuery(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"title": "version ` + r.URL.Query().Get("version") + `"}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	versions := map[string]string{
+		server.URL + "/schema.json?version=1": "1",
+		server.URL + "/schema.json?version=2": "2",
+	}
+
+	writeFiles(t, dir, map[string]string{
+		"root.json": `{"properties": {` +
+			`"a": {"$ref": "` + server.URL + `/schema.json?version=1"}, ` +
+			`"b": {"$ref": "` + server.URL + `/schema.json?version=2"}}}`,
+	})
+
+	mappings, err := schemas.Vendor([]string{filepath.Join(dir, "root.json")}, schemas.VendorOptions{
+		Dir: filepath.Join(dir, "vendor"),
+	})
+	require.NoError(t, err)
+
+	fileNames := map[string]bool{}
+
+	for uri, version := range versions {
+		fileName, ok := schemas.MapURI(mappings, uri)
+		require.True(t, ok)
+		assert.Equal(t, ".json", filepath.Ext(fileName))
+
+		fileNames[fileName] = true
+
+		data, err := os.ReadFile(fileName)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"title": "version `+version+`"}`, string(data))
+	}
+
+	assert.Len(t, fileNames, 2)
+}
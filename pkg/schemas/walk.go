@@ -0,0 +1,180 @@
+package schemas
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SkipSubschemas is returned by a WalkFunc to skip the subschemas of the schema it is called for.
+var SkipSubschemas = errors.New("skip subschemas") //nolint:errname,revive,staticcheck // Named like fs.SkipDir.
+
+// WalkNode is a subschema visited by Walk.
+type WalkNode struct {
+	// Type is the subschema.
+	Type *Type
+	// Parent is the schema the subschema is found in, or nil for the root of the document.
+	Parent *Type
+	// Keyword is the keyword of the parent that holds the subschema, such as "properties" or
+	// "allOf", or empty for the root of the document.
+	Keyword string
+	// Path holds the JSON Pointer tokens of the subschema in the document, such as
+	// ["properties", "name"] or ["allOf", "0"].
+	Path []string
+
+	set func(t *Type)
+}
+
+// Replace puts another schema in the place of the subschema, whose own subschemas are visited
+// instead. Replacing it with nil removes it from its parent; subschemas removed from an array,
+// such as allOf, are dropped once all the others have been visited.
+func (n *WalkNode) Replace(t *Type) {
+	n.set(t)
+	n.Type = t
+}
+
+// WalkFunc is called by Walk for each subschema. It may modify the subschema in place, or replace
+// it with WalkNode.Replace. Returning SkipSubschemas skips the subschemas nested in it, and any
+// other error stops the walk.
+type WalkFunc func(node *WalkNode) error
+
+// Walk calls fn for the root of a schema document and every subschema nested in it, parents
+// first, and then for its top-level definitions and theirs. Subschemas are found under every
+// keyword holding schemas: properties, patternProperties, additionalProperties, items, prefixItems,
+// allOf, anyOf, oneOf, not, if, then, else, $defs, dependentSchemas and the like, in a stable order.
+// It returns the first error of fn other than SkipSubschemas.
+func Walk(root *Schema, fn WalkFunc) error {
+	if root.ObjectAsType != nil {
+		node := &WalkNode{
+			Type: (*Type)(root.ObjectAsType),
+			set: func(t *Type) {
+				root.ObjectAsType = (*ObjectAsType)(t)
+			},
+		}
+
+		if err := walkNode(node, fn); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range sortedDefinitionNames(root.Definitions) {
+		node := &WalkNode{
+			Type:    root.Definitions[name],
+			Parent:  (*Type)(root.ObjectAsType),
+			Keyword: "$defs",
+			Path:    []string{"$defs", name},
+			set: func(t *Type) {
+				if t == nil {
+					delete(root.Definitions, name)
+				} else {
+					root.Definitions[name] = t
+				}
+			},
+		}
+
+		if err := walkNode(node, fn); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func walkNode(node *WalkNode, fn WalkFunc) error {
+	if err := fn(node); err != nil {
+		if errors.Is(err, SkipSubschemas) {
+			return nil
+		}
+
+		return err
+	}
+
+	if node.Type == nil {
+		return nil
+	}
+
+	parent := node.Type
+	val := reflect.ValueOf(parent).Elem()
+
+	for i := range val.NumField() {
+		field := val.Field(i)
+		if !val.Type().Field(i).IsExported() || !isSubschemaKind(field.Type()) {
+			continue
+		}
+
+		keyword := strings.Split(val.Type().Field(i).Tag.Get("json"), ",")[0]
+		path := append(node.Path[:len(node.Path):len(node.Path)], keyword)
+
+		child := func(t *Type, path []string, set func(t *Type)) error {
+			return walkNode(&WalkNode{Type: t, Parent: parent, Keyword: keyword, Path: path, set: set}, fn)
+		}
+
+		switch field.Kind() { //nolint:exhaustive
+		case reflect.Pointer:
+			t, _ := field.Interface().(*Type)
+			if t == nil {
+				continue
+			}
+
+			if err := child(t, path, func(t *Type) { field.Set(reflect.ValueOf(t)) }); err != nil {
+				return err
+			}
+
+		case reflect.Slice:
+			removed := false
+
+			for j := range field.Len() {
+				t, _ := field.Index(j).Interface().(*Type)
+				if t == nil {
+					continue
+				}
+
+				set := func(t *Type) {
+					field.Index(j).Set(reflect.ValueOf(t))
+					removed = removed || t == nil
+				}
+
+				if err := child(t, append(path[:len(path):len(path)], strconv.Itoa(j)), set); err != nil {
+					return err
+				}
+			}
+
+			if removed {
+				items, _ := field.Interface().([]*Type)
+				field.Set(reflect.ValueOf(slices.DeleteFunc(items, func(t *Type) bool { return t == nil })))
+			}
+
+		case reflect.Map:
+			keys := make([]string, 0, field.Len())
+			for _, k := range field.MapKeys() {
+				keys = append(keys, k.String())
+			}
+
+			sort.Strings(keys)
+
+			for _, k := range keys {
+				t, _ := field.MapIndex(reflect.ValueOf(k)).Interface().(*Type)
+				if t == nil {
+					continue
+				}
+
+				set := func(t *Type) {
+					if t == nil {
+						field.SetMapIndex(reflect.ValueOf(k), reflect.Value{})
+					} else {
+						field.SetMapIndex(reflect.ValueOf(k), reflect.ValueOf(t))
+					}
+				}
+
+				if err := child(t, append(path[:len(path):len(path)], k), set); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,230 @@
+package schemas_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/atombender/go-jsonschema/pkg/schemas"
+)
+
+const walkSchema = `{
+	"$id": "https://example.com/pet.json",
+	"type": "object",
+	"properties": {
+		"name": {"type": "string"},
+		"tags": {"type": "array", "items": {"$ref": "#/$defs/tag"}}
+	},
+	"patternProperties": {"^x-": {"type": "string"}},
+	"allOf": [{"required": ["name"]}, {"not": {"required": ["id"]}}],
+	"dependentSchemas": {"owner": {"required": ["since"]}},
+	"$defs": {
+		"tag": {"type": "string", "$defs": {"inner": {"type": "integer"}}}
+	}
+}`
+
+var errStopWalk = errors.New("stop")
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc string
+		fn   func(visited *[]string) schemas.WalkFunc
+		want []string
+		// wantSchema is the JSON the schema marshals to after the walk, if set.
+		wantSchema string
+		wantErr    error
+	}{
+		{
+			desc: "every subschema",
+			fn: func(visited *[]string) schemas.WalkFunc {
+				return func(node *schemas.WalkNode) error {
+					*visited = append(*visited, schemas.FormatJSONPointer(node.Path))
+
+					return nil
+				}
+			},
+			want: []string{
+				"",
+				"/properties/name",
+				"/properties/tags",
+				"/properties/tags/items",
+				"/patternProperties/^x-",
+				"/allOf/0",
+				"/allOf/1",
+				"/allOf/1/not",
+				"/dependentSchemas/owner",
+				"/$defs/tag",
+				"/$defs/tag/$defs/inner",
+			},
+		},
+		{
+			desc: "parents and keywords",
+			fn: func(visited *[]string) schemas.WalkFunc {
+				return func(node *schemas.WalkNode) error {
+					if node.Parent != nil && len(node.Parent.Type) > 0 {
+						*visited = append(*visited, node.Keyword+" of "+node.Parent.Type[0])
+					}
+
+					return nil
+				}
+			},
+			want: []string{
+				"properties of object",
+				"properties of object",
+				"items of array",
+				"patternProperties of object",
+				"allOf of object",
+				"allOf of object",
+				"dependentSchemas of object",
+				"$defs of object",
+				"$defs of string",
+			},
+		},
+		{
+			desc: "skipped subschemas",
+			fn: func(visited *[]string) schemas.WalkFunc {
+				return func(node *schemas.WalkNode) error {
+					*visited = append(*visited, schemas.FormatJSONPointer(node.Path))
+
+					if node.Keyword == "properties" || node.Keyword == "allOf" || node.Keyword == "$defs" {
+						return schemas.SkipSubschemas
+					}
+
+					return nil
+				}
+			},
+			want: []string{
+				"",
+				"/properties/name",
+				"/properties/tags",
+				"/patternProperties/^x-",
+				"/allOf/0",
+				"/allOf/1",
+				"/dependentSchemas/owner",
+				"/$defs/tag",
+			},
+		},
+		{
+			desc: "stopped walk",
+			fn: func(visited *[]string) schemas.WalkFunc {
+				return func(node *schemas.WalkNode) error {
+					*visited = append(*visited, schemas.FormatJSONPointer(node.Path))
+
+					if node.Keyword == "items" {
+						return errStopWalk
+					}
+
+					return nil
+				}
+			},
+			want: []string{
+				"",
+				"/properties/name",
+				"/properties/tags",
+				"/properties/tags/items",
+			},
+			wantErr: errStopWalk,
+		},
+		{
+			desc: "rewritten subschemas",
+			fn: func(visited *[]string) schemas.WalkFunc {
+				return func(node *schemas.WalkNode) error {
+					*visited = append(*visited, schemas.FormatJSONPointer(node.Path))
+
+					switch {
+					case node.Keyword == "patternProperties", node.Keyword == "dependentSchemas":
+						node.Replace(nil)
+
+					case node.Keyword == "allOf" && node.Path[1] == "0":
+						node.Replace(nil)
+
+					case node.Keyword == "items":
+						node.Replace(&schemas.Type{Type: schemas.TypeList{"string"}, Not: &schemas.Type{}})
+
+					case node.Keyword == "properties":
+						node.Type.Description = strings.ToUpper(node.Path[1])
+					}
+
+					return nil
+				}
+			},
+			want: []string{
+				"",
+				"/properties/name",
+				"/properties/tags",
+				"/properties/tags/items",
+				"/properties/tags/items/not",
+				"/patternProperties/^x-",
+				"/allOf/0",
+				"/allOf/1",
+				"/allOf/1/not",
+				"/dependentSchemas/owner",
+				"/$defs/tag",
+				"/$defs/tag/$defs/inner",
+			},
+			wantSchema: `{
+				"$id": "https://example.com/pet.json",
+				"type": "object",
+				"properties": {
+					"name": {"type": "string", "description": "NAME"},
+					"tags": {"type": "array", "description": "TAGS", "items": {"type": "string", "not": {}}}
+				},
+				"allOf": [{"not": {"required": ["id"]}}]
+			}`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			schema, err := schemas.FromJSONReader(strings.NewReader(walkSchema))
+			require.NoError(t, err)
+
+			var visited []string
+
+			err = schemas.Walk(schema, tC.fn(&visited))
+			if tC.wantErr != nil {
+				require.ErrorIs(t, err, tC.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, tC.want, visited)
+
+			if tC.wantSchema != "" {
+				got, err := json.Marshal(schema.ObjectAsType)
+				require.NoError(t, err)
+				assert.JSONEq(t, tC.wantSchema, string(got))
+			}
+		})
+	}
+}
+
+func TestWalkReplaceRoot(t *testing.T) {
+	t.Parallel()
+
+	schema, err := schemas.FromJSONReader(strings.NewReader(walkSchema))
+	require.NoError(t, err)
+
+	err = schemas.Walk(schema, func(node *schemas.WalkNode) error {
+		switch {
+		case node.Path == nil:
+			node.Replace(&schemas.Type{Ref: "#/$defs/tag"})
+
+		case node.Keyword == "$defs" && node.Path[1] == "tag":
+			node.Replace(&schemas.Type{Type: schemas.TypeList{"string"}})
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "#/$defs/tag", schema.Ref)
+	assert.Equal(t, schemas.TypeList{"string"}, schema.Definitions["tag"].Type)
+}
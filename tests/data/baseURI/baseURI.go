@@ -0,0 +1,21 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+type BaseURI struct {
+	// Pet corresponds to the JSON schema field "pet".
+	Pet *Pet `json:"pet,omitempty,omitzero" yaml:"pet,omitempty" mapstructure:"pet,omitempty"`
+}
+
+type Owner struct {
+	// Name corresponds to the JSON schema field "name".
+	Name *string `json:"name,omitempty,omitzero" yaml:"name,omitempty" mapstructure:"name,omitempty"`
+}
+
+type Pet struct {
+	// Name corresponds to the JSON schema field "name".
+	Name *string `json:"name,omitempty,omitzero" yaml:"name,omitempty" mapstructure:"name,omitempty"`
+
+	// Owner corresponds to the JSON schema field "owner".
+	Owner *Owner `json:"owner,omitempty,omitzero" yaml:"owner,omitempty" mapstructure:"owner,omitempty"`
+}
@@ -3,6 +3,7 @@
 package test
 
 import "encoding/json"
+import "errors"
 import "fmt"
 import "github.com/go-viper/mapstructure/v2"
 import yaml "gopkg.in/yaml.v3"
@@ -56,7 +57,7 @@ type AutoinstallSchema struct {
 	Locale *string `json:"locale,omitempty,omitzero" yaml:"locale,omitempty" mapstructure:"locale,omitempty"`
 
 	// Network corresponds to the JSON schema field "network".
-	Network interface{} `json:"network,omitempty,omitzero" yaml:"network,omitempty" mapstructure:"network,omitempty"`
+	Network *AutoinstallSchemaNetwork `json:"network,omitempty,omitzero" yaml:"network,omitempty" mapstructure:"network,omitempty"`
 
 	// Oem corresponds to the JSON schema field "oem".
 	Oem *AutoinstallSchemaOem `json:"oem,omitempty,omitzero" yaml:"oem,omitempty" mapstructure:"oem,omitempty"`
@@ -92,6 +93,8 @@ type AutoinstallSchema struct {
 	Timezone *string `json:"timezone,omitempty,omitzero" yaml:"timezone,omitempty" mapstructure:"timezone,omitempty"`
 
 	// Compatibility only - use ubuntu-pro instead
+	//
+	// Deprecated: marked as deprecated in the JSON schema.
 	UbuntuAdvantage *AutoinstallSchemaUbuntuAdvantage `json:"ubuntu-advantage,omitempty,omitzero" yaml:"ubuntu-advantage,omitempty" mapstructure:"ubuntu-advantage,omitempty"`
 
 	// UbuntuPro corresponds to the JSON schema field "ubuntu-pro".
@@ -163,10 +166,10 @@ var enumValues_AutoinstallSchemaAptDisableComponentsElem = []interface{}{
 	"non-free",
 }
 
-// UnmarshalJSON implements json.Unmarshaler.
-func (j *AutoinstallSchemaAptDisableComponentsElem) UnmarshalJSON(value []byte) error {
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *AutoinstallSchemaAptDisableComponentsElem) UnmarshalYAML(value *yaml.Node) error {
 	var v string
-	if err := json.Unmarshal(value, &v); err != nil {
+	if err := value.Decode(&v); err != nil {
 		return err
 	}
 	var ok bool
@@ -183,10 +186,10 @@ func (j *AutoinstallSchemaAptDisableComponentsElem) UnmarshalJSON(value []byte)
 	return nil
 }
 
-// UnmarshalYAML implements yaml.Unmarshaler.
-func (j *AutoinstallSchemaAptDisableComponentsElem) UnmarshalYAML(value *yaml.Node) error {
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *AutoinstallSchemaAptDisableComponentsElem) UnmarshalJSON(value []byte) error {
 	var v string
-	if err := value.Decode(&v); err != nil {
+	if err := json.Unmarshal(value, &v); err != nil {
 		return err
 	}
 	var ok bool
@@ -215,10 +218,10 @@ var enumValues_AutoinstallSchemaAptFallback = []interface{}{
 	"offline-install",
 }
 
-// UnmarshalYAML implements yaml.Unmarshaler.
-func (j *AutoinstallSchemaAptFallback) UnmarshalYAML(value *yaml.Node) error {
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *AutoinstallSchemaAptFallback) UnmarshalJSON(value []byte) error {
 	var v string
-	if err := value.Decode(&v); err != nil {
+	if err := json.Unmarshal(value, &v); err != nil {
 		return err
 	}
 	var ok bool
@@ -235,10 +238,10 @@ func (j *AutoinstallSchemaAptFallback) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
-// UnmarshalJSON implements json.Unmarshaler.
-func (j *AutoinstallSchemaAptFallback) UnmarshalJSON(value []byte) error {
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *AutoinstallSchemaAptFallback) UnmarshalYAML(value *yaml.Node) error {
 	var v string
-	if err := json.Unmarshal(value, &v); err != nil {
+	if err := value.Decode(&v); err != nil {
 		return err
 	}
 	var ok bool
@@ -315,10 +318,10 @@ type AutoinstallSchemaAptPreferencesElem struct {
 	PinPriority int `json:"pin-priority" yaml:"pin-priority" mapstructure:"pin-priority"`
 }
 
-// UnmarshalYAML implements yaml.Unmarshaler.
-func (j *AutoinstallSchemaAptPreferencesElem) UnmarshalYAML(value *yaml.Node) error {
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *AutoinstallSchemaAptPreferencesElem) UnmarshalJSON(value []byte) error {
 	var raw map[string]interface{}
-	if err := value.Decode(&raw); err != nil {
+	if err := json.Unmarshal(value, &raw); err != nil {
 		return err
 	}
 	if _, ok := raw["package"]; raw != nil && !ok {
@@ -332,17 +335,17 @@ func (j *AutoinstallSchemaAptPreferencesElem) UnmarshalYAML(value *yaml.Node) er
 	}
 	type Plain AutoinstallSchemaAptPreferencesElem
 	var plain Plain
-	if err := value.Decode(&plain); err != nil {
+	if err := json.Unmarshal(value, &plain); err != nil {
 		return err
 	}
 	*j = AutoinstallSchemaAptPreferencesElem(plain)
 	return nil
 }
 
-// UnmarshalJSON implements json.Unmarshaler.
-func (j *AutoinstallSchemaAptPreferencesElem) UnmarshalJSON(value []byte) error {
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *AutoinstallSchemaAptPreferencesElem) UnmarshalYAML(value *yaml.Node) error {
 	var raw map[string]interface{}
-	if err := json.Unmarshal(value, &raw); err != nil {
+	if err := value.Decode(&raw); err != nil {
 		return err
 	}
 	if _, ok := raw["package"]; raw != nil && !ok {
@@ -356,7 +359,7 @@ func (j *AutoinstallSchemaAptPreferencesElem) UnmarshalJSON(value []byte) error
 	}
 	type Plain AutoinstallSchemaAptPreferencesElem
 	var plain Plain
-	if err := json.Unmarshal(value, &plain); err != nil {
+	if err := value.Decode(&plain); err != nil {
 		return err
 	}
 	*j = AutoinstallSchemaAptPreferencesElem(plain)
@@ -389,10 +392,10 @@ type AutoinstallSchemaIdentity struct {
 	Username string `json:"username" yaml:"username" mapstructure:"username"`
 }
 
-// UnmarshalYAML implements yaml.Unmarshaler.
-func (j *AutoinstallSchemaIdentity) UnmarshalYAML(value *yaml.Node) error {
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *AutoinstallSchemaIdentity) UnmarshalJSON(value []byte) error {
 	var raw map[string]interface{}
-	if err := value.Decode(&raw); err != nil {
+	if err := json.Unmarshal(value, &raw); err != nil {
 		return err
 	}
 	if _, ok := raw["hostname"]; raw != nil && !ok {
@@ -406,17 +409,17 @@ func (j *AutoinstallSchemaIdentity) UnmarshalYAML(value *yaml.Node) error {
 	}
 	type Plain AutoinstallSchemaIdentity
 	var plain Plain
-	if err := value.Decode(&plain); err != nil {
+	if err := json.Unmarshal(value, &plain); err != nil {
 		return err
 	}
 	*j = AutoinstallSchemaIdentity(plain)
 	return nil
 }
 
-// UnmarshalJSON implements json.Unmarshaler.
-func (j *AutoinstallSchemaIdentity) UnmarshalJSON(value []byte) error {
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *AutoinstallSchemaIdentity) UnmarshalYAML(value *yaml.Node) error {
 	var raw map[string]interface{}
-	if err := json.Unmarshal(value, &raw); err != nil {
+	if err := value.Decode(&raw); err != nil {
 		return err
 	}
 	if _, ok := raw["hostname"]; raw != nil && !ok {
@@ -430,7 +433,7 @@ func (j *AutoinstallSchemaIdentity) UnmarshalJSON(value []byte) error {
 	}
 	type Plain AutoinstallSchemaIdentity
 	var plain Plain
-	if err := json.Unmarshal(value, &plain); err != nil {
+	if err := value.Decode(&plain); err != nil {
 		return err
 	}
 	*j = AutoinstallSchemaIdentity(plain)
@@ -438,11 +441,9 @@ func (j *AutoinstallSchemaIdentity) UnmarshalJSON(value []byte) error {
 }
 
 type AutoinstallSchemaKernel struct {
-	// Flavor corresponds to the JSON schema field "flavor".
-	Flavor *string `json:"flavor,omitempty,omitzero" yaml:"flavor,omitempty" mapstructure:"flavor,omitempty"`
-
-	// Package corresponds to the JSON schema field "package".
-	Package *string `json:"package,omitempty,omitzero" yaml:"package,omitempty" mapstructure:"package,omitempty"`
+	// Value holds exactly one of the types implementing
+	// AutoinstallSchemaKernelVariant.
+	Value AutoinstallSchemaKernelVariant
 }
 
 type AutoinstallSchemaKernelCrashDumps struct {
@@ -488,6 +489,186 @@ func (j *AutoinstallSchemaKernelCrashDumps) UnmarshalYAML(value *yaml.Node) erro
 	return nil
 }
 
+// AutoinstallSchemaKernelVariant is implemented by every type that
+// AutoinstallSchemaKernel can hold.
+type AutoinstallSchemaKernelVariant interface {
+	isAutoinstallSchemaKernel()
+}
+
+type AutoinstallSchemaKernel_0 struct {
+	// Flavor corresponds to the JSON schema field "flavor".
+	Flavor *string `json:"flavor,omitempty,omitzero" yaml:"flavor,omitempty" mapstructure:"flavor,omitempty"`
+
+	// Package corresponds to the JSON schema field "package".
+	Package string `json:"package" yaml:"package" mapstructure:"package"`
+}
+
+func (AutoinstallSchemaKernel_0) isAutoinstallSchemaKernel() {}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *AutoinstallSchemaKernel_0) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["package"]; raw != nil && !ok {
+		return fmt.Errorf("field package in AutoinstallSchemaKernel_0: required")
+	}
+	type Plain AutoinstallSchemaKernel_0
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = AutoinstallSchemaKernel_0(plain)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *AutoinstallSchemaKernel_0) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["package"]; raw != nil && !ok {
+		return fmt.Errorf("field package in AutoinstallSchemaKernel_0: required")
+	}
+	type Plain AutoinstallSchemaKernel_0
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = AutoinstallSchemaKernel_0(plain)
+	return nil
+}
+
+type AutoinstallSchemaKernel_1 struct {
+	// Flavor corresponds to the JSON schema field "flavor".
+	Flavor string `json:"flavor" yaml:"flavor" mapstructure:"flavor"`
+
+	// Package corresponds to the JSON schema field "package".
+	Package *string `json:"package,omitempty,omitzero" yaml:"package,omitempty" mapstructure:"package,omitempty"`
+}
+
+func (AutoinstallSchemaKernel_1) isAutoinstallSchemaKernel() {}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *AutoinstallSchemaKernel_1) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["flavor"]; raw != nil && !ok {
+		return fmt.Errorf("field flavor in AutoinstallSchemaKernel_1: required")
+	}
+	type Plain AutoinstallSchemaKernel_1
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = AutoinstallSchemaKernel_1(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *AutoinstallSchemaKernel_1) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["flavor"]; raw != nil && !ok {
+		return fmt.Errorf("field flavor in AutoinstallSchemaKernel_1: required")
+	}
+	type Plain AutoinstallSchemaKernel_1
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = AutoinstallSchemaKernel_1(plain)
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (j AutoinstallSchemaKernel) MarshalYAML() (interface{}, error) {
+	return j.Value, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (j AutoinstallSchemaKernel) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Value)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *AutoinstallSchemaKernel) UnmarshalYAML(value *yaml.Node) error {
+	var raw interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return fmt.Errorf("AutoinstallSchemaKernel: value cannot be null")
+	}
+	var matches []AutoinstallSchemaKernelVariant
+	var errs []error
+	var v0 AutoinstallSchemaKernel_0
+	if value.Kind != yaml.MappingNode {
+		errs = append(errs, fmt.Errorf("AutoinstallSchemaKernel_0: input is not of type object"))
+	} else if err := value.Decode(&v0); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v0)
+	}
+	var v1 AutoinstallSchemaKernel_1
+	if value.Kind != yaml.MappingNode {
+		errs = append(errs, fmt.Errorf("AutoinstallSchemaKernel_1: input is not of type object"))
+	} else if err := value.Decode(&v1); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v1)
+	}
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("AutoinstallSchemaKernel: no oneOf variant matched: %w", errors.Join(errs...))
+	case 1:
+		j.Value = matches[0]
+		return nil
+	default:
+		return fmt.Errorf("AutoinstallSchemaKernel: value matches %d oneOf variants, expected exactly one", len(matches))
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *AutoinstallSchemaKernel) UnmarshalJSON(value []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return fmt.Errorf("AutoinstallSchemaKernel: value cannot be null")
+	}
+	var matches []AutoinstallSchemaKernelVariant
+	var errs []error
+	var v0 AutoinstallSchemaKernel_0
+	if err := json.Unmarshal(value, &v0); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v0)
+	}
+	var v1 AutoinstallSchemaKernel_1
+	if err := json.Unmarshal(value, &v1); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v1)
+	}
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("AutoinstallSchemaKernel: no oneOf variant matched: %w", errors.Join(errs...))
+	case 1:
+		j.Value = matches[0]
+		return nil
+	default:
+		return fmt.Errorf("AutoinstallSchemaKernel: value matches %d oneOf variants, expected exactly one", len(matches))
+	}
+}
+
 type AutoinstallSchemaKeyboard struct {
 	// Layout corresponds to the JSON schema field "layout".
 	Layout string `json:"layout" yaml:"layout" mapstructure:"layout"`
@@ -501,6 +682,24 @@ type AutoinstallSchemaKeyboard struct {
 
 type AutoinstallSchemaKeyboardToggle *string
 
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *AutoinstallSchemaKeyboard) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["layout"]; raw != nil && !ok {
+		return fmt.Errorf("field layout in AutoinstallSchemaKeyboard: required")
+	}
+	type Plain AutoinstallSchemaKeyboard
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = AutoinstallSchemaKeyboard(plain)
+	return nil
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 func (j *AutoinstallSchemaKeyboard) UnmarshalJSON(value []byte) error {
 	var raw map[string]interface{}
@@ -519,27 +718,500 @@ func (j *AutoinstallSchemaKeyboard) UnmarshalJSON(value []byte) error {
 	return nil
 }
 
+type AutoinstallSchemaNetwork struct {
+	// Value holds exactly one of the types implementing
+	// AutoinstallSchemaNetworkVariant.
+	Value AutoinstallSchemaNetworkVariant
+}
+
+// AutoinstallSchemaNetworkVariant is implemented by every type that
+// AutoinstallSchemaNetwork can hold.
+type AutoinstallSchemaNetworkVariant interface {
+	isAutoinstallSchemaNetwork()
+}
+
+type AutoinstallSchemaNetwork_0 struct {
+	// Bonds corresponds to the JSON schema field "bonds".
+	Bonds AutoinstallSchemaNetwork_0Bonds `json:"bonds,omitempty,omitzero" yaml:"bonds,omitempty" mapstructure:"bonds,omitempty"`
+
+	// Bridges corresponds to the JSON schema field "bridges".
+	Bridges AutoinstallSchemaNetwork_0Bridges `json:"bridges,omitempty,omitzero" yaml:"bridges,omitempty" mapstructure:"bridges,omitempty"`
+
+	// Ethernets corresponds to the JSON schema field "ethernets".
+	Ethernets *AutoinstallSchemaNetwork_0Ethernets `json:"ethernets,omitempty,omitzero" yaml:"ethernets,omitempty" mapstructure:"ethernets,omitempty"`
+
+	// Tunnels corresponds to the JSON schema field "tunnels".
+	Tunnels AutoinstallSchemaNetwork_0Tunnels `json:"tunnels,omitempty,omitzero" yaml:"tunnels,omitempty" mapstructure:"tunnels,omitempty"`
+
+	// Version corresponds to the JSON schema field "version".
+	Version int `json:"version" yaml:"version" mapstructure:"version"`
+
+	// Vlans corresponds to the JSON schema field "vlans".
+	Vlans AutoinstallSchemaNetwork_0Vlans `json:"vlans,omitempty,omitzero" yaml:"vlans,omitempty" mapstructure:"vlans,omitempty"`
+
+	// Wifis corresponds to the JSON schema field "wifis".
+	Wifis *AutoinstallSchemaNetwork_0Wifis `json:"wifis,omitempty,omitzero" yaml:"wifis,omitempty" mapstructure:"wifis,omitempty"`
+}
+
+type AutoinstallSchemaNetwork_0Bonds map[string]interface{}
+
+type AutoinstallSchemaNetwork_0Bridges map[string]interface{}
+
+type AutoinstallSchemaNetwork_0Ethernets struct {
+	// Match corresponds to the JSON schema field "match".
+	Match *AutoinstallSchemaNetwork_0EthernetsMatch `json:"match,omitempty,omitzero" yaml:"match,omitempty" mapstructure:"match,omitempty"`
+}
+
+type AutoinstallSchemaNetwork_0EthernetsMatch struct {
+	// Driver corresponds to the JSON schema field "driver".
+	Driver *string `json:"driver,omitempty,omitzero" yaml:"driver,omitempty" mapstructure:"driver,omitempty"`
+
+	// Macaddress corresponds to the JSON schema field "macaddress".
+	Macaddress *string `json:"macaddress,omitempty,omitzero" yaml:"macaddress,omitempty" mapstructure:"macaddress,omitempty"`
+
+	// Name corresponds to the JSON schema field "name".
+	Name *string `json:"name,omitempty,omitzero" yaml:"name,omitempty" mapstructure:"name,omitempty"`
+}
+
+type AutoinstallSchemaNetwork_0Tunnels map[string]interface{}
+
+type AutoinstallSchemaNetwork_0Vlans map[string]interface{}
+
+type AutoinstallSchemaNetwork_0Wifis struct {
+	// Match corresponds to the JSON schema field "match".
+	Match *AutoinstallSchemaNetwork_0WifisMatch `json:"match,omitempty,omitzero" yaml:"match,omitempty" mapstructure:"match,omitempty"`
+}
+
+type AutoinstallSchemaNetwork_0WifisMatch struct {
+	// Driver corresponds to the JSON schema field "driver".
+	Driver *string `json:"driver,omitempty,omitzero" yaml:"driver,omitempty" mapstructure:"driver,omitempty"`
+
+	// Macaddress corresponds to the JSON schema field "macaddress".
+	Macaddress *string `json:"macaddress,omitempty,omitzero" yaml:"macaddress,omitempty" mapstructure:"macaddress,omitempty"`
+
+	// Name corresponds to the JSON schema field "name".
+	Name *string `json:"name,omitempty,omitzero" yaml:"name,omitempty" mapstructure:"name,omitempty"`
+}
+
+func (AutoinstallSchemaNetwork_0) isAutoinstallSchemaNetwork() {}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *AutoinstallSchemaNetwork_0) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["version"]; raw != nil && !ok {
+		return fmt.Errorf("field version in AutoinstallSchemaNetwork_0: required")
+	}
+	type Plain AutoinstallSchemaNetwork_0
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if 2 < plain.Version {
+		return fmt.Errorf("field %s: must be <= %v", "version", 2)
+	}
+	if 2 > plain.Version {
+		return fmt.Errorf("field %s: must be >= %v", "version", 2)
+	}
+	*j = AutoinstallSchemaNetwork_0(plain)
+	return nil
+}
+
 // UnmarshalYAML implements yaml.Unmarshaler.
-func (j *AutoinstallSchemaKeyboard) UnmarshalYAML(value *yaml.Node) error {
+func (j *AutoinstallSchemaNetwork_0) UnmarshalYAML(value *yaml.Node) error {
 	var raw map[string]interface{}
 	if err := value.Decode(&raw); err != nil {
 		return err
 	}
-	if _, ok := raw["layout"]; raw != nil && !ok {
-		return fmt.Errorf("field layout in AutoinstallSchemaKeyboard: required")
+	if _, ok := raw["version"]; raw != nil && !ok {
+		return fmt.Errorf("field version in AutoinstallSchemaNetwork_0: required")
 	}
-	type Plain AutoinstallSchemaKeyboard
+	type Plain AutoinstallSchemaNetwork_0
 	var plain Plain
 	if err := value.Decode(&plain); err != nil {
 		return err
 	}
-	*j = AutoinstallSchemaKeyboard(plain)
+	if 2 < plain.Version {
+		return fmt.Errorf("field %s: must be <= %v", "version", 2)
+	}
+	if 2 > plain.Version {
+		return fmt.Errorf("field %s: must be >= %v", "version", 2)
+	}
+	*j = AutoinstallSchemaNetwork_0(plain)
+	return nil
+}
+
+type AutoinstallSchemaNetwork_1 struct {
+	// Network corresponds to the JSON schema field "network".
+	Network AutoinstallSchemaNetwork_1Network `json:"network" yaml:"network" mapstructure:"network"`
+}
+
+type AutoinstallSchemaNetwork_1Network struct {
+	// Bonds corresponds to the JSON schema field "bonds".
+	Bonds AutoinstallSchemaNetwork_1NetworkBonds `json:"bonds,omitempty,omitzero" yaml:"bonds,omitempty" mapstructure:"bonds,omitempty"`
+
+	// Bridges corresponds to the JSON schema field "bridges".
+	Bridges AutoinstallSchemaNetwork_1NetworkBridges `json:"bridges,omitempty,omitzero" yaml:"bridges,omitempty" mapstructure:"bridges,omitempty"`
+
+	// Ethernets corresponds to the JSON schema field "ethernets".
+	Ethernets *AutoinstallSchemaNetwork_1NetworkEthernets `json:"ethernets,omitempty,omitzero" yaml:"ethernets,omitempty" mapstructure:"ethernets,omitempty"`
+
+	// Tunnels corresponds to the JSON schema field "tunnels".
+	Tunnels AutoinstallSchemaNetwork_1NetworkTunnels `json:"tunnels,omitempty,omitzero" yaml:"tunnels,omitempty" mapstructure:"tunnels,omitempty"`
+
+	// Version corresponds to the JSON schema field "version".
+	Version int `json:"version" yaml:"version" mapstructure:"version"`
+
+	// Vlans corresponds to the JSON schema field "vlans".
+	Vlans AutoinstallSchemaNetwork_1NetworkVlans `json:"vlans,omitempty,omitzero" yaml:"vlans,omitempty" mapstructure:"vlans,omitempty"`
+
+	// Wifis corresponds to the JSON schema field "wifis".
+	Wifis *AutoinstallSchemaNetwork_1NetworkWifis `json:"wifis,omitempty,omitzero" yaml:"wifis,omitempty" mapstructure:"wifis,omitempty"`
+}
+
+type AutoinstallSchemaNetwork_1NetworkBonds map[string]interface{}
+
+type AutoinstallSchemaNetwork_1NetworkBridges map[string]interface{}
+
+type AutoinstallSchemaNetwork_1NetworkEthernets struct {
+	// Match corresponds to the JSON schema field "match".
+	Match *AutoinstallSchemaNetwork_1NetworkEthernetsMatch `json:"match,omitempty,omitzero" yaml:"match,omitempty" mapstructure:"match,omitempty"`
+}
+
+type AutoinstallSchemaNetwork_1NetworkEthernetsMatch struct {
+	// Driver corresponds to the JSON schema field "driver".
+	Driver *string `json:"driver,omitempty,omitzero" yaml:"driver,omitempty" mapstructure:"driver,omitempty"`
+
+	// Macaddress corresponds to the JSON schema field "macaddress".
+	Macaddress *string `json:"macaddress,omitempty,omitzero" yaml:"macaddress,omitempty" mapstructure:"macaddress,omitempty"`
+
+	// Name corresponds to the JSON schema field "name".
+	Name *string `json:"name,omitempty,omitzero" yaml:"name,omitempty" mapstructure:"name,omitempty"`
+}
+
+type AutoinstallSchemaNetwork_1NetworkTunnels map[string]interface{}
+
+type AutoinstallSchemaNetwork_1NetworkVlans map[string]interface{}
+
+type AutoinstallSchemaNetwork_1NetworkWifis struct {
+	// Match corresponds to the JSON schema field "match".
+	Match *AutoinstallSchemaNetwork_1NetworkWifisMatch `json:"match,omitempty,omitzero" yaml:"match,omitempty" mapstructure:"match,omitempty"`
+}
+
+type AutoinstallSchemaNetwork_1NetworkWifisMatch struct {
+	// Driver corresponds to the JSON schema field "driver".
+	Driver *string `json:"driver,omitempty,omitzero" yaml:"driver,omitempty" mapstructure:"driver,omitempty"`
+
+	// Macaddress corresponds to the JSON schema field "macaddress".
+	Macaddress *string `json:"macaddress,omitempty,omitzero" yaml:"macaddress,omitempty" mapstructure:"macaddress,omitempty"`
+
+	// Name corresponds to the JSON schema field "name".
+	Name *string `json:"name,omitempty,omitzero" yaml:"name,omitempty" mapstructure:"name,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *AutoinstallSchemaNetwork_1Network) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["version"]; raw != nil && !ok {
+		return fmt.Errorf("field version in AutoinstallSchemaNetwork_1Network: required")
+	}
+	type Plain AutoinstallSchemaNetwork_1Network
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if 2 < plain.Version {
+		return fmt.Errorf("field %s: must be <= %v", "version", 2)
+	}
+	if 2 > plain.Version {
+		return fmt.Errorf("field %s: must be >= %v", "version", 2)
+	}
+	*j = AutoinstallSchemaNetwork_1Network(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *AutoinstallSchemaNetwork_1Network) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["version"]; raw != nil && !ok {
+		return fmt.Errorf("field version in AutoinstallSchemaNetwork_1Network: required")
+	}
+	type Plain AutoinstallSchemaNetwork_1Network
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if 2 < plain.Version {
+		return fmt.Errorf("field %s: must be <= %v", "version", 2)
+	}
+	if 2 > plain.Version {
+		return fmt.Errorf("field %s: must be >= %v", "version", 2)
+	}
+	*j = AutoinstallSchemaNetwork_1Network(plain)
+	return nil
+}
+
+func (AutoinstallSchemaNetwork_1) isAutoinstallSchemaNetwork() {}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *AutoinstallSchemaNetwork_1) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["network"]; raw != nil && !ok {
+		return fmt.Errorf("field network in AutoinstallSchemaNetwork_1: required")
+	}
+	type Plain AutoinstallSchemaNetwork_1
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = AutoinstallSchemaNetwork_1(plain)
 	return nil
 }
 
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *AutoinstallSchemaNetwork_1) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["network"]; raw != nil && !ok {
+		return fmt.Errorf("field network in AutoinstallSchemaNetwork_1: required")
+	}
+	type Plain AutoinstallSchemaNetwork_1
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = AutoinstallSchemaNetwork_1(plain)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (j AutoinstallSchemaNetwork) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Value)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (j AutoinstallSchemaNetwork) MarshalYAML() (interface{}, error) {
+	return j.Value, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *AutoinstallSchemaNetwork) UnmarshalJSON(value []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return fmt.Errorf("AutoinstallSchemaNetwork: value cannot be null")
+	}
+	var matches []AutoinstallSchemaNetworkVariant
+	var errs []error
+	var v0 AutoinstallSchemaNetwork_0
+	if err := json.Unmarshal(value, &v0); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v0)
+	}
+	var v1 AutoinstallSchemaNetwork_1
+	if err := json.Unmarshal(value, &v1); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v1)
+	}
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("AutoinstallSchemaNetwork: no oneOf variant matched: %w", errors.Join(errs...))
+	case 1:
+		j.Value = matches[0]
+		return nil
+	default:
+		return fmt.Errorf("AutoinstallSchemaNetwork: value matches %d oneOf variants, expected exactly one", len(matches))
+	}
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *AutoinstallSchemaNetwork) UnmarshalYAML(value *yaml.Node) error {
+	var raw interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return fmt.Errorf("AutoinstallSchemaNetwork: value cannot be null")
+	}
+	var matches []AutoinstallSchemaNetworkVariant
+	var errs []error
+	var v0 AutoinstallSchemaNetwork_0
+	if value.Kind != yaml.MappingNode {
+		errs = append(errs, fmt.Errorf("AutoinstallSchemaNetwork_0: input is not of type object"))
+	} else if err := value.Decode(&v0); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v0)
+	}
+	var v1 AutoinstallSchemaNetwork_1
+	if value.Kind != yaml.MappingNode {
+		errs = append(errs, fmt.Errorf("AutoinstallSchemaNetwork_1: input is not of type object"))
+	} else if err := value.Decode(&v1); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v1)
+	}
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("AutoinstallSchemaNetwork: no oneOf variant matched: %w", errors.Join(errs...))
+	case 1:
+		j.Value = matches[0]
+		return nil
+	default:
+		return fmt.Errorf("AutoinstallSchemaNetwork: value matches %d oneOf variants, expected exactly one", len(matches))
+	}
+}
+
 type AutoinstallSchemaOem struct {
 	// Install corresponds to the JSON schema field "install".
-	Install interface{} `json:"install" yaml:"install" mapstructure:"install"`
+	Install AutoinstallSchemaOemInstall `json:"install" yaml:"install" mapstructure:"install"`
+}
+
+type AutoinstallSchemaOemInstall struct {
+	// Value holds exactly one of the types implementing
+	// AutoinstallSchemaOemInstallVariant.
+	Value AutoinstallSchemaOemInstallVariant
+}
+
+// AutoinstallSchemaOemInstallVariant is implemented by every type that
+// AutoinstallSchemaOemInstall can hold.
+type AutoinstallSchemaOemInstallVariant interface {
+	isAutoinstallSchemaOemInstall()
+}
+
+type AutoinstallSchemaOemInstall_0 bool
+
+func (AutoinstallSchemaOemInstall_0) isAutoinstallSchemaOemInstall() {}
+
+type AutoinstallSchemaOemInstall_1 string
+
+func (AutoinstallSchemaOemInstall_1) isAutoinstallSchemaOemInstall() {}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *AutoinstallSchemaOemInstall_1) UnmarshalYAML(value *yaml.Node) error {
+	type Plain AutoinstallSchemaOemInstall_1
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if plain != "auto" {
+		return fmt.Errorf("field %s: must be equal to %s", "", "auto")
+	}
+	*j = AutoinstallSchemaOemInstall_1(plain)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *AutoinstallSchemaOemInstall_1) UnmarshalJSON(value []byte) error {
+	type Plain AutoinstallSchemaOemInstall_1
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if plain != "auto" {
+		return fmt.Errorf("field %s: must be equal to %s", "", "auto")
+	}
+	*j = AutoinstallSchemaOemInstall_1(plain)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (j AutoinstallSchemaOemInstall) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Value)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (j AutoinstallSchemaOemInstall) MarshalYAML() (interface{}, error) {
+	return j.Value, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *AutoinstallSchemaOemInstall) UnmarshalJSON(value []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return fmt.Errorf("AutoinstallSchemaOemInstall: value cannot be null")
+	}
+	var matches []AutoinstallSchemaOemInstallVariant
+	var errs []error
+	var v0 AutoinstallSchemaOemInstall_0
+	if err := json.Unmarshal(value, &v0); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v0)
+	}
+	var v1 AutoinstallSchemaOemInstall_1
+	if err := json.Unmarshal(value, &v1); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v1)
+	}
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("AutoinstallSchemaOemInstall: no oneOf variant matched: %w", errors.Join(errs...))
+	case 1:
+		j.Value = matches[0]
+		return nil
+	default:
+		return fmt.Errorf("AutoinstallSchemaOemInstall: value matches %d oneOf variants, expected exactly one", len(matches))
+	}
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *AutoinstallSchemaOemInstall) UnmarshalYAML(value *yaml.Node) error {
+	var raw interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return fmt.Errorf("AutoinstallSchemaOemInstall: value cannot be null")
+	}
+	var matches []AutoinstallSchemaOemInstallVariant
+	var errs []error
+	var v0 AutoinstallSchemaOemInstall_0
+	if value.ShortTag() != "!!bool" {
+		errs = append(errs, fmt.Errorf("AutoinstallSchemaOemInstall_0: input is not of type boolean"))
+	} else if err := value.Decode(&v0); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v0)
+	}
+	var v1 AutoinstallSchemaOemInstall_1
+	if value.ShortTag() != "!!str" {
+		errs = append(errs, fmt.Errorf("AutoinstallSchemaOemInstall_1: input is not of type string"))
+	} else if err := value.Decode(&v1); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v1)
+	}
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("AutoinstallSchemaOemInstall: no oneOf variant matched: %w", errors.Join(errs...))
+	case 1:
+		j.Value = matches[0]
+		return nil
+	default:
+		return fmt.Errorf("AutoinstallSchemaOemInstall: value matches %d oneOf variants, expected exactly one", len(matches))
+	}
 }
 
 // UnmarshalJSON implements json.Unmarshaler.
@@ -714,6 +1386,8 @@ type AutoinstallSchemaSsh struct {
 type AutoinstallSchemaStorage map[string]interface{}
 
 // Compatibility only - use ubuntu-pro instead
+//
+// Deprecated: marked as deprecated in the JSON schema.
 type AutoinstallSchemaUbuntuAdvantage struct {
 	// A valid token starts with a C and is followed by 23 to 29 Base58 characters.
 	// See https://pkg.go.dev/github.com/btcsuite/btcutil/base58#CheckEncode
@@ -770,11 +1444,11 @@ type AutoinstallSchemaUbuntuPro struct {
 	Token *string `json:"token,omitempty,omitzero" yaml:"token,omitempty" mapstructure:"token,omitempty"`
 }
 
-// UnmarshalJSON implements json.Unmarshaler.
-func (j *AutoinstallSchemaUbuntuPro) UnmarshalJSON(value []byte) error {
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *AutoinstallSchemaUbuntuPro) UnmarshalYAML(value *yaml.Node) error {
 	type Plain AutoinstallSchemaUbuntuPro
 	var plain Plain
-	if err := json.Unmarshal(value, &plain); err != nil {
+	if err := value.Decode(&plain); err != nil {
 		return err
 	}
 	if plain.Token != nil {
@@ -792,11 +1466,11 @@ func (j *AutoinstallSchemaUbuntuPro) UnmarshalJSON(value []byte) error {
 	return nil
 }
 
-// UnmarshalYAML implements yaml.Unmarshaler.
-func (j *AutoinstallSchemaUbuntuPro) UnmarshalYAML(value *yaml.Node) error {
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *AutoinstallSchemaUbuntuPro) UnmarshalJSON(value []byte) error {
 	type Plain AutoinstallSchemaUbuntuPro
 	var plain Plain
-	if err := value.Decode(&plain); err != nil {
+	if err := json.Unmarshal(value, &plain); err != nil {
 		return err
 	}
 	if plain.Token != nil {
@@ -0,0 +1,170 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "errors"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+
+type Circle struct {
+	// Radius corresponds to the JSON schema field "radius".
+	Radius *float64 `json:"radius,omitempty,omitzero" yaml:"radius,omitempty" mapstructure:"radius,omitempty"`
+
+	// Shape corresponds to the JSON schema field "shape".
+	Shape interface{} `json:"shape" yaml:"shape" mapstructure:"shape"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Circle) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["shape"]; raw != nil && !ok {
+		return fmt.Errorf("field shape in Circle: required")
+	}
+	type Plain Circle
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = Circle(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Circle) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["shape"]; raw != nil && !ok {
+		return fmt.Errorf("field shape in Circle: required")
+	}
+	type Plain Circle
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = Circle(plain)
+	return nil
+}
+
+type Square struct {
+	// Shape corresponds to the JSON schema field "shape".
+	Shape interface{} `json:"shape" yaml:"shape" mapstructure:"shape"`
+
+	// Side corresponds to the JSON schema field "side".
+	Side *float64 `json:"side,omitempty,omitzero" yaml:"side,omitempty" mapstructure:"side,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Square) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["shape"]; raw != nil && !ok {
+		return fmt.Errorf("field shape in Square: required")
+	}
+	type Plain Square
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = Square(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Square) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["shape"]; raw != nil && !ok {
+		return fmt.Errorf("field shape in Square: required")
+	}
+	type Plain Square
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = Square(plain)
+	return nil
+}
+
+type AnyOfConstTagFigure_0 = Circle
+
+type AnyOfConstTagFigure_1 = Square
+
+type AnyOfConstTag struct {
+	// Figure corresponds to the JSON schema field "figure".
+	Figure *AnyOfConstTagFigure `json:"figure,omitempty,omitzero" yaml:"figure,omitempty" mapstructure:"figure,omitempty"`
+}
+
+type AnyOfConstTagFigure struct {
+	// Radius corresponds to the JSON schema field "radius".
+	Radius *float64 `json:"radius,omitempty,omitzero" yaml:"radius,omitempty" mapstructure:"radius,omitempty"`
+
+	// Shape corresponds to the JSON schema field "shape".
+	Shape interface{} `json:"shape" yaml:"shape" mapstructure:"shape"`
+
+	// Side corresponds to the JSON schema field "side".
+	Side *float64 `json:"side,omitempty,omitzero" yaml:"side,omitempty" mapstructure:"side,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *AnyOfConstTagFigure) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	var anyOfConstTagFigure_0 AnyOfConstTagFigure_0
+	var anyOfConstTagFigure_1 AnyOfConstTagFigure_1
+	var errs []error
+	if err := anyOfConstTagFigure_0.UnmarshalJSON(value); err != nil {
+		errs = append(errs, err)
+	}
+	if err := anyOfConstTagFigure_1.UnmarshalJSON(value); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) == 2 {
+		return fmt.Errorf("all validators failed: %s", errors.Join(errs...))
+	}
+	type Plain AnyOfConstTagFigure
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = AnyOfConstTagFigure(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *AnyOfConstTagFigure) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	var anyOfConstTagFigure_0 AnyOfConstTagFigure_0
+	var anyOfConstTagFigure_1 AnyOfConstTagFigure_1
+	var errs []error
+	if err := anyOfConstTagFigure_0.UnmarshalYAML(value); err != nil {
+		errs = append(errs, err)
+	}
+	if err := anyOfConstTagFigure_1.UnmarshalYAML(value); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) == 2 {
+		return fmt.Errorf("all validators failed: %s", errors.Join(errs...))
+	}
+	type Plain AnyOfConstTagFigure
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = AnyOfConstTagFigure(plain)
+	return nil
+}
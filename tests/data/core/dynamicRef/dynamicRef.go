@@ -0,0 +1,16 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+type DynamicRef struct {
+	// Root corresponds to the JSON schema field "root".
+	Root *Node `json:"root,omitempty,omitzero" yaml:"root,omitempty" mapstructure:"root,omitempty"`
+}
+
+type Node struct {
+	// Children corresponds to the JSON schema field "children".
+	Children []Node `json:"children,omitempty,omitzero" yaml:"children,omitempty" mapstructure:"children,omitempty"`
+
+	// Value corresponds to the JSON schema field "value".
+	Value *int `json:"value,omitempty,omitzero" yaml:"value,omitempty" mapstructure:"value,omitempty"`
+}
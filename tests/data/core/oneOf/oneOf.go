@@ -0,0 +1,341 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "errors"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+
+type OneOf struct {
+	// Shape corresponds to the JSON schema field "shape".
+	Shape OneOfShape `json:"shape" yaml:"shape" mapstructure:"shape"`
+
+	// Value corresponds to the JSON schema field "value".
+	Value *OneOfValue `json:"value,omitempty,omitzero" yaml:"value,omitempty" mapstructure:"value,omitempty"`
+}
+
+type OneOfShape struct {
+	// Value holds exactly one of the types implementing OneOfShapeVariant.
+	Value OneOfShapeVariant
+}
+
+// OneOfShapeVariant is implemented by every type that OneOfShape can hold.
+type OneOfShapeVariant interface {
+	isOneOfShape()
+}
+
+type OneOfShape_0 struct {
+	// Radius corresponds to the JSON schema field "radius".
+	Radius float64 `json:"radius" yaml:"radius" mapstructure:"radius"`
+}
+
+func (OneOfShape_0) isOneOfShape() {}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *OneOfShape_0) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["radius"]; raw != nil && !ok {
+		return fmt.Errorf("field radius in OneOfShape_0: required")
+	}
+	type Plain OneOfShape_0
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = OneOfShape_0(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *OneOfShape_0) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["radius"]; raw != nil && !ok {
+		return fmt.Errorf("field radius in OneOfShape_0: required")
+	}
+	type Plain OneOfShape_0
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = OneOfShape_0(plain)
+	return nil
+}
+
+type OneOfShape_1 struct {
+	// Height corresponds to the JSON schema field "height".
+	Height float64 `json:"height" yaml:"height" mapstructure:"height"`
+
+	// Width corresponds to the JSON schema field "width".
+	Width float64 `json:"width" yaml:"width" mapstructure:"width"`
+}
+
+func (OneOfShape_1) isOneOfShape() {}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *OneOfShape_1) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["height"]; raw != nil && !ok {
+		return fmt.Errorf("field height in OneOfShape_1: required")
+	}
+	if _, ok := raw["width"]; raw != nil && !ok {
+		return fmt.Errorf("field width in OneOfShape_1: required")
+	}
+	type Plain OneOfShape_1
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = OneOfShape_1(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *OneOfShape_1) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["height"]; raw != nil && !ok {
+		return fmt.Errorf("field height in OneOfShape_1: required")
+	}
+	if _, ok := raw["width"]; raw != nil && !ok {
+		return fmt.Errorf("field width in OneOfShape_1: required")
+	}
+	type Plain OneOfShape_1
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = OneOfShape_1(plain)
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (j OneOfShape) MarshalYAML() (interface{}, error) {
+	return j.Value, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (j OneOfShape) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Value)
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *OneOfShape) UnmarshalYAML(value *yaml.Node) error {
+	var raw interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return fmt.Errorf("OneOfShape: value cannot be null")
+	}
+	var matches []OneOfShapeVariant
+	var errs []error
+	var v0 OneOfShape_0
+	if value.Kind != yaml.MappingNode {
+		errs = append(errs, fmt.Errorf("OneOfShape_0: input is not of type object"))
+	} else if err := value.Decode(&v0); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v0)
+	}
+	var v1 OneOfShape_1
+	if value.Kind != yaml.MappingNode {
+		errs = append(errs, fmt.Errorf("OneOfShape_1: input is not of type object"))
+	} else if err := value.Decode(&v1); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v1)
+	}
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("OneOfShape: no oneOf variant matched: %w", errors.Join(errs...))
+	case 1:
+		j.Value = matches[0]
+		return nil
+	default:
+		return fmt.Errorf("OneOfShape: value matches %d oneOf variants, expected exactly one", len(matches))
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *OneOfShape) UnmarshalJSON(value []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return fmt.Errorf("OneOfShape: value cannot be null")
+	}
+	var matches []OneOfShapeVariant
+	var errs []error
+	var v0 OneOfShape_0
+	if err := json.Unmarshal(value, &v0); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v0)
+	}
+	var v1 OneOfShape_1
+	if err := json.Unmarshal(value, &v1); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v1)
+	}
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("OneOfShape: no oneOf variant matched: %w", errors.Join(errs...))
+	case 1:
+		j.Value = matches[0]
+		return nil
+	default:
+		return fmt.Errorf("OneOfShape: value matches %d oneOf variants, expected exactly one", len(matches))
+	}
+}
+
+type OneOfValue struct {
+	// Value holds exactly one of the types implementing OneOfValueVariant.
+	Value OneOfValueVariant
+}
+
+// OneOfValueVariant is implemented by every type that OneOfValue can hold.
+type OneOfValueVariant interface {
+	isOneOfValue()
+}
+
+type OneOfValue_0 string
+
+func (OneOfValue_0) isOneOfValue() {}
+
+type OneOfValue_1 int
+
+func (OneOfValue_1) isOneOfValue() {}
+
+// MarshalYAML implements yaml.Marshaler.
+func (j OneOfValue) MarshalYAML() (interface{}, error) {
+	return j.Value, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (j OneOfValue) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *OneOfValue) UnmarshalJSON(value []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		j.Value = nil
+		return nil
+	}
+	var matches []OneOfValueVariant
+	var errs []error
+	var v0 OneOfValue_0
+	if err := json.Unmarshal(value, &v0); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v0)
+	}
+	var v1 OneOfValue_1
+	if err := json.Unmarshal(value, &v1); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v1)
+	}
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("OneOfValue: no oneOf variant matched: %w", errors.Join(errs...))
+	case 1:
+		j.Value = matches[0]
+		return nil
+	default:
+		return fmt.Errorf("OneOfValue: value matches %d oneOf variants, expected exactly one", len(matches))
+	}
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *OneOfValue) UnmarshalYAML(value *yaml.Node) error {
+	var raw interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		j.Value = nil
+		return nil
+	}
+	var matches []OneOfValueVariant
+	var errs []error
+	var v0 OneOfValue_0
+	if value.ShortTag() != "!!str" {
+		errs = append(errs, fmt.Errorf("OneOfValue_0: input is not of type string"))
+	} else if err := value.Decode(&v0); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v0)
+	}
+	var v1 OneOfValue_1
+	if value.ShortTag() != "!!int" {
+		errs = append(errs, fmt.Errorf("OneOfValue_1: input is not of type integer"))
+	} else if err := value.Decode(&v1); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v1)
+	}
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("OneOfValue: no oneOf variant matched: %w", errors.Join(errs...))
+	case 1:
+		j.Value = matches[0]
+		return nil
+	default:
+		return fmt.Errorf("OneOfValue: value matches %d oneOf variants, expected exactly one", len(matches))
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *OneOf) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["shape"]; raw != nil && !ok {
+		return fmt.Errorf("field shape in OneOf: required")
+	}
+	type Plain OneOf
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = OneOf(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *OneOf) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["shape"]; raw != nil && !ok {
+		return fmt.Errorf("field shape in OneOf: required")
+	}
+	type Plain OneOf
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = OneOf(plain)
+	return nil
+}
@@ -0,0 +1,299 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+import "bytes"
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+
+type GroupEvent struct {
+	// Kind corresponds to the JSON schema field "kind".
+	Kind interface{} `json:"kind" yaml:"kind" mapstructure:"kind"`
+
+	// Members corresponds to the JSON schema field "members".
+	Members []string `json:"members,omitempty,omitzero" yaml:"members,omitempty" mapstructure:"members,omitempty"`
+}
+
+func (GroupEvent) isOneOfDiscriminatorEvent() {}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *GroupEvent) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["kind"]; raw != nil && !ok {
+		return fmt.Errorf("field kind in GroupEvent: required")
+	}
+	type Plain GroupEvent
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = GroupEvent(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *GroupEvent) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["kind"]; raw != nil && !ok {
+		return fmt.Errorf("field kind in GroupEvent: required")
+	}
+	type Plain GroupEvent
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = GroupEvent(plain)
+	return nil
+}
+
+type OneOfDiscriminator struct {
+	// Event corresponds to the JSON schema field "event".
+	Event OneOfDiscriminatorEvent `json:"event" yaml:"event" mapstructure:"event"`
+}
+
+type OneOfDiscriminatorEvent struct {
+	// Value holds exactly one of the types implementing
+	// OneOfDiscriminatorEventVariant.
+	Value OneOfDiscriminatorEventVariant
+}
+
+// OneOfDiscriminatorEventVariant is implemented by every type that
+// OneOfDiscriminatorEvent can hold.
+type OneOfDiscriminatorEventVariant interface {
+	isOneOfDiscriminatorEvent()
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (j OneOfDiscriminatorEvent) MarshalYAML() (interface{}, error) {
+	var tag string
+	switch j.Value.(type) {
+	case UserEvent:
+		tag = "user"
+	case GroupEvent:
+		tag = "group"
+	default:
+		return j.Value, nil
+	}
+	var node yaml.Node
+	if err := node.Encode(j.Value); err != nil {
+		return nil, err
+	}
+	content := []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "kind"},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: tag},
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != "kind" {
+			content = append(content, node.Content[i], node.Content[i+1])
+		}
+	}
+	node.Content = content
+	return &node, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (j OneOfDiscriminatorEvent) MarshalJSON() ([]byte, error) {
+	var tag string
+	switch j.Value.(type) {
+	case UserEvent:
+		tag = "user"
+	case GroupEvent:
+		tag = "group"
+	default:
+		return json.Marshal(j.Value)
+	}
+	data, err := json.Marshal(j.Value)
+	if err != nil {
+		return nil, err
+	}
+	tagValue, err := json.Marshal(tag)
+	if err != nil {
+		return nil, err
+	}
+	buf := append([]byte(`{"kind":`), tagValue...)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var field json.RawMessage
+		if err := dec.Decode(&field); err != nil {
+			return nil, err
+		}
+		if key == "kind" {
+			continue
+		}
+		keyName, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(append(append(append(buf, ','), keyName...), ':'), field...)
+	}
+	return append(buf, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *OneOfDiscriminatorEvent) UnmarshalJSON(value []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return fmt.Errorf("OneOfDiscriminatorEvent: value cannot be null")
+	}
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("OneOfDiscriminatorEvent: expected an object with discriminator kind")
+	}
+	switch obj["kind"] {
+	case "user":
+		var v UserEvent
+		if err := json.Unmarshal(value, &v); err != nil {
+			return err
+		}
+		j.Value = v
+	case "group":
+		var v GroupEvent
+		if err := json.Unmarshal(value, &v); err != nil {
+			return err
+		}
+		j.Value = v
+	default:
+		return fmt.Errorf("OneOfDiscriminatorEvent: unexpected value %#v for discriminator kind", obj["kind"])
+	}
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *OneOfDiscriminatorEvent) UnmarshalYAML(value *yaml.Node) error {
+	var raw interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return fmt.Errorf("OneOfDiscriminatorEvent: value cannot be null")
+	}
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("OneOfDiscriminatorEvent: expected an object with discriminator kind")
+	}
+	switch obj["kind"] {
+	case "user":
+		var v UserEvent
+		if err := value.Decode(&v); err != nil {
+			return err
+		}
+		j.Value = v
+	case "group":
+		var v GroupEvent
+		if err := value.Decode(&v); err != nil {
+			return err
+		}
+		j.Value = v
+	default:
+		return fmt.Errorf("OneOfDiscriminatorEvent: unexpected value %#v for discriminator kind", obj["kind"])
+	}
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *OneOfDiscriminator) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["event"]; raw != nil && !ok {
+		return fmt.Errorf("field event in OneOfDiscriminator: required")
+	}
+	type Plain OneOfDiscriminator
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = OneOfDiscriminator(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *OneOfDiscriminator) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["event"]; raw != nil && !ok {
+		return fmt.Errorf("field event in OneOfDiscriminator: required")
+	}
+	type Plain OneOfDiscriminator
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = OneOfDiscriminator(plain)
+	return nil
+}
+
+type UserEvent struct {
+	// Id corresponds to the JSON schema field "id".
+	Id *int `json:"id,omitempty,omitzero" yaml:"id,omitempty" mapstructure:"id,omitempty"`
+
+	// Kind corresponds to the JSON schema field "kind".
+	Kind interface{} `json:"kind" yaml:"kind" mapstructure:"kind"`
+
+	// Name corresponds to the JSON schema field "name".
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+}
+
+func (UserEvent) isOneOfDiscriminatorEvent() {}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *UserEvent) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["kind"]; raw != nil && !ok {
+		return fmt.Errorf("field kind in UserEvent: required")
+	}
+	if _, ok := raw["name"]; raw != nil && !ok {
+		return fmt.Errorf("field name in UserEvent: required")
+	}
+	type Plain UserEvent
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = UserEvent(plain)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *UserEvent) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["kind"]; raw != nil && !ok {
+		return fmt.Errorf("field kind in UserEvent: required")
+	}
+	if _, ok := raw["name"]; raw != nil && !ok {
+		return fmt.Errorf("field name in UserEvent: required")
+	}
+	type Plain UserEvent
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = UserEvent(plain)
+	return nil
+}
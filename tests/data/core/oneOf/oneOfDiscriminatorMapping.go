@@ -0,0 +1,176 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+import "bytes"
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+
+type Circle struct {
+	// Radius corresponds to the JSON schema field "radius".
+	Radius *float64 `json:"radius,omitempty,omitzero" yaml:"radius,omitempty" mapstructure:"radius,omitempty"`
+}
+
+func (Circle) isOneOfDiscriminatorMappingShape() {}
+
+type OneOfDiscriminatorMapping struct {
+	// Shape corresponds to the JSON schema field "shape".
+	Shape *OneOfDiscriminatorMappingShape `json:"shape,omitempty,omitzero" yaml:"shape,omitempty" mapstructure:"shape,omitempty"`
+}
+
+type OneOfDiscriminatorMappingShape struct {
+	// Value holds exactly one of the types implementing
+	// OneOfDiscriminatorMappingShapeVariant.
+	Value OneOfDiscriminatorMappingShapeVariant
+}
+
+// OneOfDiscriminatorMappingShapeVariant is implemented by every type that
+// OneOfDiscriminatorMappingShape can hold.
+type OneOfDiscriminatorMappingShapeVariant interface {
+	isOneOfDiscriminatorMappingShape()
+}
+
+// MarshalJSON implements json.Marshaler.
+func (j OneOfDiscriminatorMappingShape) MarshalJSON() ([]byte, error) {
+	var tag string
+	switch j.Value.(type) {
+	case Circle:
+		tag = "circle"
+	case Square:
+		tag = "Square"
+	default:
+		return json.Marshal(j.Value)
+	}
+	data, err := json.Marshal(j.Value)
+	if err != nil {
+		return nil, err
+	}
+	tagValue, err := json.Marshal(tag)
+	if err != nil {
+		return nil, err
+	}
+	buf := append([]byte(`{"shapeType":`), tagValue...)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var field json.RawMessage
+		if err := dec.Decode(&field); err != nil {
+			return nil, err
+		}
+		if key == "shapeType" {
+			continue
+		}
+		keyName, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(append(append(append(buf, ','), keyName...), ':'), field...)
+	}
+	return append(buf, '}'), nil
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (j OneOfDiscriminatorMappingShape) MarshalYAML() (interface{}, error) {
+	var tag string
+	switch j.Value.(type) {
+	case Circle:
+		tag = "circle"
+	case Square:
+		tag = "Square"
+	default:
+		return j.Value, nil
+	}
+	var node yaml.Node
+	if err := node.Encode(j.Value); err != nil {
+		return nil, err
+	}
+	content := []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "shapeType"},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: tag},
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != "shapeType" {
+			content = append(content, node.Content[i], node.Content[i+1])
+		}
+	}
+	node.Content = content
+	return &node, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *OneOfDiscriminatorMappingShape) UnmarshalJSON(value []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return fmt.Errorf("OneOfDiscriminatorMappingShape: value cannot be null")
+	}
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("OneOfDiscriminatorMappingShape: expected an object with discriminator shapeType")
+	}
+	switch obj["shapeType"] {
+	case "circle", "round":
+		var v Circle
+		if err := json.Unmarshal(value, &v); err != nil {
+			return err
+		}
+		j.Value = v
+	case "Square":
+		var v Square
+		if err := json.Unmarshal(value, &v); err != nil {
+			return err
+		}
+		j.Value = v
+	default:
+		return fmt.Errorf("OneOfDiscriminatorMappingShape: unexpected value %#v for discriminator shapeType", obj["shapeType"])
+	}
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *OneOfDiscriminatorMappingShape) UnmarshalYAML(value *yaml.Node) error {
+	var raw interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return fmt.Errorf("OneOfDiscriminatorMappingShape: value cannot be null")
+	}
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("OneOfDiscriminatorMappingShape: expected an object with discriminator shapeType")
+	}
+	switch obj["shapeType"] {
+	case "circle", "round":
+		var v Circle
+		if err := value.Decode(&v); err != nil {
+			return err
+		}
+		j.Value = v
+	case "Square":
+		var v Square
+		if err := value.Decode(&v); err != nil {
+			return err
+		}
+		j.Value = v
+	default:
+		return fmt.Errorf("OneOfDiscriminatorMappingShape: unexpected value %#v for discriminator shapeType", obj["shapeType"])
+	}
+	return nil
+}
+
+type Square struct {
+	// Side corresponds to the JSON schema field "side".
+	Side *float64 `json:"side,omitempty,omitzero" yaml:"side,omitempty" mapstructure:"side,omitempty"`
+}
+
+func (Square) isOneOfDiscriminatorMappingShape() {}
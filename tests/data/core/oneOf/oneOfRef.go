@@ -0,0 +1,191 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "errors"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+
+type Cat struct {
+	// Meows corresponds to the JSON schema field "meows".
+	Meows bool `json:"meows" yaml:"meows" mapstructure:"meows"`
+}
+
+func (Cat) isPet() {}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Cat) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["meows"]; raw != nil && !ok {
+		return fmt.Errorf("field meows in Cat: required")
+	}
+	type Plain Cat
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = Cat(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Cat) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["meows"]; raw != nil && !ok {
+		return fmt.Errorf("field meows in Cat: required")
+	}
+	type Plain Cat
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = Cat(plain)
+	return nil
+}
+
+type Dog struct {
+	// Barks corresponds to the JSON schema field "barks".
+	Barks bool `json:"barks" yaml:"barks" mapstructure:"barks"`
+}
+
+func (Dog) isPet() {}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Dog) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["barks"]; raw != nil && !ok {
+		return fmt.Errorf("field barks in Dog: required")
+	}
+	type Plain Dog
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = Dog(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Dog) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["barks"]; raw != nil && !ok {
+		return fmt.Errorf("field barks in Dog: required")
+	}
+	type Plain Dog
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = Dog(plain)
+	return nil
+}
+
+type OneOfRef struct {
+	// Pets corresponds to the JSON schema field "pets".
+	Pets []Pet `json:"pets,omitempty,omitzero" yaml:"pets,omitempty" mapstructure:"pets,omitempty"`
+}
+
+type Pet struct {
+	// Value holds exactly one of the types implementing PetVariant.
+	Value PetVariant
+}
+
+// PetVariant is implemented by every type that Pet can hold.
+type PetVariant interface {
+	isPet()
+}
+
+// MarshalJSON implements json.Marshaler.
+func (j Pet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Value)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (j Pet) MarshalYAML() (interface{}, error) {
+	return j.Value, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Pet) UnmarshalJSON(value []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return fmt.Errorf("Pet: value cannot be null")
+	}
+	var matches []PetVariant
+	var errs []error
+	var v0 Cat
+	if err := json.Unmarshal(value, &v0); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v0)
+	}
+	var v1 Dog
+	if err := json.Unmarshal(value, &v1); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v1)
+	}
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("Pet: no oneOf variant matched: %w", errors.Join(errs...))
+	case 1:
+		j.Value = matches[0]
+		return nil
+	default:
+		return fmt.Errorf("Pet: value matches %d oneOf variants, expected exactly one", len(matches))
+	}
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Pet) UnmarshalYAML(value *yaml.Node) error {
+	var raw interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return fmt.Errorf("Pet: value cannot be null")
+	}
+	var matches []PetVariant
+	var errs []error
+	var v0 Cat
+	if value.Kind != yaml.MappingNode {
+		errs = append(errs, fmt.Errorf("Cat: input is not of type object"))
+	} else if err := value.Decode(&v0); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v0)
+	}
+	var v1 Dog
+	if value.Kind != yaml.MappingNode {
+		errs = append(errs, fmt.Errorf("Dog: input is not of type object"))
+	} else if err := value.Decode(&v1); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v1)
+	}
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("Pet: no oneOf variant matched: %w", errors.Join(errs...))
+	case 1:
+		j.Value = matches[0]
+		return nil
+	default:
+		return fmt.Errorf("Pet: value matches %d oneOf variants, expected exactly one", len(matches))
+	}
+}
@@ -0,0 +1,206 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "errors"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+
+type OneOfWithDirectProperties struct {
+	// Value holds exactly one of the types implementing
+	// OneOfWithDirectPropertiesVariant.
+	Value OneOfWithDirectPropertiesVariant
+}
+
+// OneOfWithDirectPropertiesVariant is implemented by every type that
+// OneOfWithDirectProperties can hold.
+type OneOfWithDirectPropertiesVariant interface {
+	isOneOfWithDirectProperties()
+}
+
+type OneOfWithDirectProperties_0 struct {
+	// Email corresponds to the JSON schema field "email".
+	Email string `json:"email" yaml:"email" mapstructure:"email"`
+
+	// Id corresponds to the JSON schema field "id".
+	Id string `json:"id" yaml:"id" mapstructure:"id"`
+}
+
+func (OneOfWithDirectProperties_0) isOneOfWithDirectProperties() {}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *OneOfWithDirectProperties_0) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["email"]; raw != nil && !ok {
+		return fmt.Errorf("field email in OneOfWithDirectProperties_0: required")
+	}
+	if _, ok := raw["id"]; raw != nil && !ok {
+		return fmt.Errorf("field id in OneOfWithDirectProperties_0: required")
+	}
+	type Plain OneOfWithDirectProperties_0
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = OneOfWithDirectProperties_0(plain)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *OneOfWithDirectProperties_0) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["email"]; raw != nil && !ok {
+		return fmt.Errorf("field email in OneOfWithDirectProperties_0: required")
+	}
+	if _, ok := raw["id"]; raw != nil && !ok {
+		return fmt.Errorf("field id in OneOfWithDirectProperties_0: required")
+	}
+	type Plain OneOfWithDirectProperties_0
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = OneOfWithDirectProperties_0(plain)
+	return nil
+}
+
+type OneOfWithDirectProperties_1 struct {
+	// Id corresponds to the JSON schema field "id".
+	Id string `json:"id" yaml:"id" mapstructure:"id"`
+
+	// Phone corresponds to the JSON schema field "phone".
+	Phone string `json:"phone" yaml:"phone" mapstructure:"phone"`
+}
+
+func (OneOfWithDirectProperties_1) isOneOfWithDirectProperties() {}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *OneOfWithDirectProperties_1) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["id"]; raw != nil && !ok {
+		return fmt.Errorf("field id in OneOfWithDirectProperties_1: required")
+	}
+	if _, ok := raw["phone"]; raw != nil && !ok {
+		return fmt.Errorf("field phone in OneOfWithDirectProperties_1: required")
+	}
+	type Plain OneOfWithDirectProperties_1
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = OneOfWithDirectProperties_1(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *OneOfWithDirectProperties_1) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["id"]; raw != nil && !ok {
+		return fmt.Errorf("field id in OneOfWithDirectProperties_1: required")
+	}
+	if _, ok := raw["phone"]; raw != nil && !ok {
+		return fmt.Errorf("field phone in OneOfWithDirectProperties_1: required")
+	}
+	type Plain OneOfWithDirectProperties_1
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = OneOfWithDirectProperties_1(plain)
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (j OneOfWithDirectProperties) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Value)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (j OneOfWithDirectProperties) MarshalYAML() (interface{}, error) {
+	return j.Value, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *OneOfWithDirectProperties) UnmarshalJSON(value []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return fmt.Errorf("OneOfWithDirectProperties: value cannot be null")
+	}
+	var matches []OneOfWithDirectPropertiesVariant
+	var errs []error
+	var v0 OneOfWithDirectProperties_0
+	if err := json.Unmarshal(value, &v0); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v0)
+	}
+	var v1 OneOfWithDirectProperties_1
+	if err := json.Unmarshal(value, &v1); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v1)
+	}
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("OneOfWithDirectProperties: no oneOf variant matched: %w", errors.Join(errs...))
+	case 1:
+		j.Value = matches[0]
+		return nil
+	default:
+		return fmt.Errorf("OneOfWithDirectProperties: value matches %d oneOf variants, expected exactly one", len(matches))
+	}
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *OneOfWithDirectProperties) UnmarshalYAML(value *yaml.Node) error {
+	var raw interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return fmt.Errorf("OneOfWithDirectProperties: value cannot be null")
+	}
+	var matches []OneOfWithDirectPropertiesVariant
+	var errs []error
+	var v0 OneOfWithDirectProperties_0
+	if value.Kind != yaml.MappingNode {
+		errs = append(errs, fmt.Errorf("OneOfWithDirectProperties_0: input is not of type object"))
+	} else if err := value.Decode(&v0); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v0)
+	}
+	var v1 OneOfWithDirectProperties_1
+	if value.Kind != yaml.MappingNode {
+		errs = append(errs, fmt.Errorf("OneOfWithDirectProperties_1: input is not of type object"))
+	} else if err := value.Decode(&v1); err != nil {
+		errs = append(errs, err)
+	} else {
+		matches = append(matches, v1)
+	}
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("OneOfWithDirectProperties: no oneOf variant matched: %w", errors.Join(errs...))
+	case 1:
+		j.Value = matches[0]
+		return nil
+	default:
+		return fmt.Errorf("OneOfWithDirectProperties: value matches %d oneOf variants, expected exactly one", len(matches))
+	}
+}
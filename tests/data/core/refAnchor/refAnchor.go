@@ -0,0 +1,45 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+type BillingAddress struct {
+	// Street corresponds to the JSON schema field "street".
+	Street *string `json:"street,omitempty,omitzero" yaml:"street,omitempty" mapstructure:"street,omitempty"`
+}
+
+type Customer struct {
+	// Name corresponds to the JSON schema field "name".
+	Name *string `json:"name,omitempty,omitzero" yaml:"name,omitempty" mapstructure:"name,omitempty"`
+}
+
+type Note struct {
+	// Text corresponds to the JSON schema field "text".
+	Text *string `json:"text,omitempty,omitzero" yaml:"text,omitempty" mapstructure:"text,omitempty"`
+}
+
+type Order struct {
+	// Lines corresponds to the JSON schema field "lines".
+	Lines []OrderLine `json:"lines,omitempty,omitzero" yaml:"lines,omitempty" mapstructure:"lines,omitempty"`
+}
+
+type OrderLine struct {
+	// Sku corresponds to the JSON schema field "sku".
+	Sku *string `json:"sku,omitempty,omitzero" yaml:"sku,omitempty" mapstructure:"sku,omitempty"`
+}
+
+type RefAnchor struct {
+	// Billing corresponds to the JSON schema field "billing".
+	Billing *BillingAddress `json:"billing,omitempty,omitzero" yaml:"billing,omitempty" mapstructure:"billing,omitempty"`
+
+	// Customer corresponds to the JSON schema field "customer".
+	Customer *Customer `json:"customer,omitempty,omitzero" yaml:"customer,omitempty" mapstructure:"customer,omitempty"`
+
+	// Line corresponds to the JSON schema field "line".
+	Line *OrderLine `json:"line,omitempty,omitzero" yaml:"line,omitempty" mapstructure:"line,omitempty"`
+
+	// Note corresponds to the JSON schema field "note".
+	Note *Note `json:"note,omitempty,omitzero" yaml:"note,omitempty" mapstructure:"note,omitempty"`
+
+	// Order corresponds to the JSON schema field "order".
+	Order *Order `json:"order,omitempty,omitzero" yaml:"order,omitempty" mapstructure:"order,omitempty"`
+}
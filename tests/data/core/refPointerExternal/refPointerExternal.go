@@ -0,0 +1,56 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+type ABC struct {
+	// Escaped corresponds to the JSON schema field "escaped".
+	Escaped *bool `json:"escaped,omitempty,omitzero" yaml:"escaped,omitempty" mapstructure:"escaped,omitempty"`
+}
+
+type Address struct {
+	// Country corresponds to the JSON schema field "country".
+	Country *AddressCountry `json:"country,omitempty,omitzero" yaml:"country,omitempty" mapstructure:"country,omitempty"`
+
+	// Street corresponds to the JSON schema field "street".
+	Street *string `json:"street,omitempty,omitzero" yaml:"street,omitempty" mapstructure:"street,omitempty"`
+}
+
+type AddressCountry struct {
+	// Code corresponds to the JSON schema field "code".
+	Code *string `json:"code,omitempty,omitzero" yaml:"code,omitempty" mapstructure:"code,omitempty"`
+}
+
+type AddressUnreferenced struct {
+	// Note corresponds to the JSON schema field "note".
+	Note *string `json:"note,omitempty,omitzero" yaml:"note,omitempty" mapstructure:"note,omitempty"`
+}
+
+type RefPointer struct {
+	// Country corresponds to the JSON schema field "country".
+	Country *AddressCountry `json:"country,omitempty,omitzero" yaml:"country,omitempty" mapstructure:"country,omitempty"`
+
+	// Escaped corresponds to the JSON schema field "escaped".
+	Escaped *ABC `json:"escaped,omitempty,omitzero" yaml:"escaped,omitempty" mapstructure:"escaped,omitempty"`
+
+	// FirstTag corresponds to the JSON schema field "firstTag".
+	FirstTag *RefPointerTagsElem `json:"firstTag,omitempty,omitzero" yaml:"firstTag,omitempty" mapstructure:"firstTag,omitempty"`
+
+	// Home corresponds to the JSON schema field "home".
+	Home *Address `json:"home,omitempty,omitzero" yaml:"home,omitempty" mapstructure:"home,omitempty"`
+
+	// Tags corresponds to the JSON schema field "tags".
+	Tags []RefPointerTagsElem `json:"tags,omitempty,omitzero" yaml:"tags,omitempty" mapstructure:"tags,omitempty"`
+}
+
+type RefPointerExternal struct {
+	// Country corresponds to the JSON schema field "country".
+	Country *AddressCountry `json:"country,omitempty,omitzero" yaml:"country,omitempty" mapstructure:"country,omitempty"`
+
+	// Tag corresponds to the JSON schema field "tag".
+	Tag *RefPointerTagsElem `json:"tag,omitempty,omitzero" yaml:"tag,omitempty" mapstructure:"tag,omitempty"`
+}
+
+type RefPointerTagsElem struct {
+	// Label corresponds to the JSON schema field "label".
+	Label *string `json:"label,omitempty,omitzero" yaml:"label,omitempty" mapstructure:"label,omitempty"`
+}
@@ -0,0 +1,194 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+
+type Position struct {
+	// Longitude corresponds to item 0 of the JSON schema tuple.
+	Longitude float64
+
+	// Latitude corresponds to item 1 of the JSON schema tuple.
+	Latitude float64
+}
+
+// MarshalJSON implements json.Marshaler.
+func (j Position) MarshalJSON() ([]byte, error) {
+	items := []interface{}{j.Longitude, j.Latitude}
+	return json.Marshal(items)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (j Position) MarshalYAML() (interface{}, error) {
+	items := []interface{}{j.Longitude, j.Latitude}
+	return items, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Position) UnmarshalJSON(value []byte) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(value, &items); err != nil {
+		return err
+	}
+	if len(items) < 2 {
+		return fmt.Errorf("Position: expected at least 2 items, got %d", len(items))
+	}
+	if len(items) > 2 {
+		return fmt.Errorf("Position: expected at most 2 items, got %d", len(items))
+	}
+	var v Position
+	if err := json.Unmarshal(items[0], &v.Longitude); err != nil {
+		return fmt.Errorf("Position: item 0: %w", err)
+	}
+	if err := json.Unmarshal(items[1], &v.Latitude); err != nil {
+		return fmt.Errorf("Position: item 1: %w", err)
+	}
+	*j = v
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Position) UnmarshalYAML(value *yaml.Node) error {
+	var items []yaml.Node
+	if err := value.Decode(&items); err != nil {
+		return err
+	}
+	if len(items) < 2 {
+		return fmt.Errorf("Position: expected at least 2 items, got %d", len(items))
+	}
+	if len(items) > 2 {
+		return fmt.Errorf("Position: expected at most 2 items, got %d", len(items))
+	}
+	var v Position
+	if err := items[0].Decode(&v.Longitude); err != nil {
+		return fmt.Errorf("Position: item 0: %w", err)
+	}
+	if err := items[1].Decode(&v.Latitude); err != nil {
+		return fmt.Errorf("Position: item 1: %w", err)
+	}
+	*j = v
+	return nil
+}
+
+type Tuple struct {
+	// Call corresponds to the JSON schema field "call".
+	Call *TupleCall `json:"call,omitempty,omitzero" yaml:"call,omitempty" mapstructure:"call,omitempty"`
+
+	// Position corresponds to the JSON schema field "position".
+	Position *Position `json:"position,omitempty,omitzero" yaml:"position,omitempty" mapstructure:"position,omitempty"`
+}
+
+type TupleCall struct {
+	// Name of the method to call.
+	Method string
+
+	// Item1 corresponds to item 1 of the JSON schema tuple.
+	Item1 int
+
+	// Item2 corresponds to item 2 of the JSON schema tuple.
+	Item2 *TupleCallItem2
+
+	// Rest holds the items following the positional ones.
+	Rest []string
+}
+
+type TupleCallItem2 struct {
+	// Timeout corresponds to the JSON schema field "timeout".
+	Timeout *float64 `json:"timeout,omitempty,omitzero" yaml:"timeout,omitempty" mapstructure:"timeout,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (j TupleCall) MarshalJSON() ([]byte, error) {
+	items := []interface{}{j.Method, j.Item1}
+	if j.Item2 != nil {
+		items = append(items, j.Item2)
+	}
+	if len(j.Rest) > 0 && len(items) != 3 {
+		return nil, fmt.Errorf("TupleCall: Rest cannot be set without Item2")
+	}
+	for _, item := range j.Rest {
+		items = append(items, item)
+	}
+	return json.Marshal(items)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (j TupleCall) MarshalYAML() (interface{}, error) {
+	items := []interface{}{j.Method, j.Item1}
+	if j.Item2 != nil {
+		items = append(items, j.Item2)
+	}
+	if len(j.Rest) > 0 && len(items) != 3 {
+		return nil, fmt.Errorf("TupleCall: Rest cannot be set without Item2")
+	}
+	for _, item := range j.Rest {
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *TupleCall) UnmarshalJSON(value []byte) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(value, &items); err != nil {
+		return err
+	}
+	if len(items) < 2 {
+		return fmt.Errorf("TupleCall: expected at least 2 items, got %d", len(items))
+	}
+	var v TupleCall
+	if err := json.Unmarshal(items[0], &v.Method); err != nil {
+		return fmt.Errorf("TupleCall: item 0: %w", err)
+	}
+	if err := json.Unmarshal(items[1], &v.Item1); err != nil {
+		return fmt.Errorf("TupleCall: item 1: %w", err)
+	}
+	if len(items) > 2 {
+		if err := json.Unmarshal(items[2], &v.Item2); err != nil {
+			return fmt.Errorf("TupleCall: item 2: %w", err)
+		}
+	}
+	for i := 3; i < len(items); i++ {
+		var item string
+		if err := json.Unmarshal(items[i], &item); err != nil {
+			return fmt.Errorf("TupleCall: item %d: %w", i, err)
+		}
+		v.Rest = append(v.Rest, item)
+	}
+	*j = v
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *TupleCall) UnmarshalYAML(value *yaml.Node) error {
+	var items []yaml.Node
+	if err := value.Decode(&items); err != nil {
+		return err
+	}
+	if len(items) < 2 {
+		return fmt.Errorf("TupleCall: expected at least 2 items, got %d", len(items))
+	}
+	var v TupleCall
+	if err := items[0].Decode(&v.Method); err != nil {
+		return fmt.Errorf("TupleCall: item 0: %w", err)
+	}
+	if err := items[1].Decode(&v.Item1); err != nil {
+		return fmt.Errorf("TupleCall: item 1: %w", err)
+	}
+	if len(items) > 2 {
+		if err := items[2].Decode(&v.Item2); err != nil {
+			return fmt.Errorf("TupleCall: item 2: %w", err)
+		}
+	}
+	for i := 3; i < len(items); i++ {
+		var item string
+		if err := items[i].Decode(&item); err != nil {
+			return fmt.Errorf("TupleCall: item %d: %w", i, err)
+		}
+		v.Rest = append(v.Rest, item)
+	}
+	*j = v
+	return nil
+}
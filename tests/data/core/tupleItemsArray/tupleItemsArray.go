@@ -0,0 +1,121 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+
+type TupleItemsArray struct {
+	// Pair corresponds to the JSON schema field "pair".
+	Pair *TupleItemsArrayPair `json:"pair,omitempty,omitzero" yaml:"pair,omitempty" mapstructure:"pair,omitempty"`
+}
+
+type TupleItemsArrayPair struct {
+	// Item0 corresponds to item 0 of the JSON schema tuple.
+	Item0 *string
+
+	// Item1 corresponds to item 1 of the JSON schema tuple.
+	Item1 *bool
+
+	// Rest holds the items following the positional ones.
+	Rest []int
+}
+
+// MarshalJSON implements json.Marshaler.
+func (j TupleItemsArrayPair) MarshalJSON() ([]byte, error) {
+	items := []interface{}{}
+	if j.Item0 != nil {
+		items = append(items, j.Item0)
+	}
+	if j.Item1 != nil {
+		if len(items) != 1 {
+			return nil, fmt.Errorf("TupleItemsArrayPair: Item1 cannot be set without Item0")
+		}
+		items = append(items, j.Item1)
+	}
+	if len(j.Rest) > 0 && len(items) != 2 {
+		return nil, fmt.Errorf("TupleItemsArrayPair: Rest cannot be set without Item1")
+	}
+	for _, item := range j.Rest {
+		items = append(items, item)
+	}
+	return json.Marshal(items)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (j TupleItemsArrayPair) MarshalYAML() (interface{}, error) {
+	items := []interface{}{}
+	if j.Item0 != nil {
+		items = append(items, j.Item0)
+	}
+	if j.Item1 != nil {
+		if len(items) != 1 {
+			return nil, fmt.Errorf("TupleItemsArrayPair: Item1 cannot be set without Item0")
+		}
+		items = append(items, j.Item1)
+	}
+	if len(j.Rest) > 0 && len(items) != 2 {
+		return nil, fmt.Errorf("TupleItemsArrayPair: Rest cannot be set without Item1")
+	}
+	for _, item := range j.Rest {
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *TupleItemsArrayPair) UnmarshalJSON(value []byte) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(value, &items); err != nil {
+		return err
+	}
+	var v TupleItemsArrayPair
+	if len(items) > 0 {
+		if err := json.Unmarshal(items[0], &v.Item0); err != nil {
+			return fmt.Errorf("TupleItemsArrayPair: item 0: %w", err)
+		}
+	}
+	if len(items) > 1 {
+		if err := json.Unmarshal(items[1], &v.Item1); err != nil {
+			return fmt.Errorf("TupleItemsArrayPair: item 1: %w", err)
+		}
+	}
+	for i := 2; i < len(items); i++ {
+		var item int
+		if err := json.Unmarshal(items[i], &item); err != nil {
+			return fmt.Errorf("TupleItemsArrayPair: item %d: %w", i, err)
+		}
+		v.Rest = append(v.Rest, item)
+	}
+	*j = v
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *TupleItemsArrayPair) UnmarshalYAML(value *yaml.Node) error {
+	var items []yaml.Node
+	if err := value.Decode(&items); err != nil {
+		return err
+	}
+	var v TupleItemsArrayPair
+	if len(items) > 0 {
+		if err := items[0].Decode(&v.Item0); err != nil {
+			return fmt.Errorf("TupleItemsArrayPair: item 0: %w", err)
+		}
+	}
+	if len(items) > 1 {
+		if err := items[1].Decode(&v.Item1); err != nil {
+			return fmt.Errorf("TupleItemsArrayPair: item 1: %w", err)
+		}
+	}
+	for i := 2; i < len(items); i++ {
+		var item int
+		if err := items[i].Decode(&item); err != nil {
+			return fmt.Errorf("TupleItemsArrayPair: item %d: %w", i, err)
+		}
+		v.Rest = append(v.Rest, item)
+	}
+	*j = v
+	return nil
+}
@@ -10,8 +10,14 @@ type Schema struct {
 
 	// DefInSameSchema corresponds to the JSON schema field "defInSameSchema".
 	DefInSameSchema *Thing `json:"defInSameSchema,omitempty,omitzero" yaml:"defInSameSchema,omitempty" mapstructure:"defInSameSchema,omitempty"`
+
+	// OneOfWithOtherSchema corresponds to the JSON schema field
+	// "oneOfWithOtherSchema".
+	OneOfWithOtherSchema SchemaOneOfWithOtherSchema `json:"oneOfWithOtherSchema,omitempty,omitzero" yaml:"oneOfWithOtherSchema,omitempty" mapstructure:"oneOfWithOtherSchema,omitempty"`
 }
 
+type SchemaOneOfWithOtherSchema interface{}
+
 type Thing struct {
 	// S corresponds to the JSON schema field "s".
 	S *string `json:"s,omitempty,omitzero" yaml:"s,omitempty" mapstructure:"s,omitempty"`
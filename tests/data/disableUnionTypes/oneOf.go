@@ -0,0 +1,51 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+
+type OneOf struct {
+	// Shape corresponds to the JSON schema field "shape".
+	Shape interface{} `json:"shape" yaml:"shape" mapstructure:"shape"`
+
+	// Value corresponds to the JSON schema field "value".
+	Value interface{} `json:"value,omitempty,omitzero" yaml:"value,omitempty" mapstructure:"value,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *OneOf) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["shape"]; raw != nil && !ok {
+		return fmt.Errorf("field shape in OneOf: required")
+	}
+	type Plain OneOf
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = OneOf(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *OneOf) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["shape"]; raw != nil && !ok {
+		return fmt.Errorf("field shape in OneOf: required")
+	}
+	type Plain OneOf
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = OneOf(plain)
+	return nil
+}
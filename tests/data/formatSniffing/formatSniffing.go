@@ -0,0 +1,16 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+type FormatSniffing struct {
+	// Name corresponds to the JSON schema field "name".
+	Name *string `json:"name,omitempty,omitzero" yaml:"name,omitempty" mapstructure:"name,omitempty"`
+
+	// Pet corresponds to the JSON schema field "pet".
+	Pet *Pet `json:"pet,omitempty,omitzero" yaml:"pet,omitempty" mapstructure:"pet,omitempty"`
+}
+
+type Pet struct {
+	// Kind corresponds to the JSON schema field "kind".
+	Kind *string `json:"kind,omitempty,omitzero" yaml:"kind,omitempty" mapstructure:"kind,omitempty"`
+}
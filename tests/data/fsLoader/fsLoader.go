@@ -0,0 +1,62 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+import "unicode/utf8"
+
+type FsLoader struct {
+	// Owner corresponds to the JSON schema field "owner".
+	Owner *Person `json:"owner,omitempty,omitzero" yaml:"owner,omitempty" mapstructure:"owner,omitempty"`
+
+	// Pet corresponds to the JSON schema field "pet".
+	Pet *Pet `json:"pet,omitempty,omitzero" yaml:"pet,omitempty" mapstructure:"pet,omitempty"`
+}
+
+type Name string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Name) UnmarshalJSON(value []byte) error {
+	type Plain Name
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(string(plain)) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "", 1)
+	}
+	*j = Name(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Name) UnmarshalYAML(value *yaml.Node) error {
+	type Plain Name
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(string(plain)) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "", 1)
+	}
+	*j = Name(plain)
+	return nil
+}
+
+type Person struct {
+	// Name corresponds to the JSON schema field "name".
+	Name *Name `json:"name,omitempty,omitzero" yaml:"name,omitempty" mapstructure:"name,omitempty"`
+
+	// Pets corresponds to the JSON schema field "pets".
+	Pets []Pet `json:"pets,omitempty,omitzero" yaml:"pets,omitempty" mapstructure:"pets,omitempty"`
+}
+
+type Pet struct {
+	// Name corresponds to the JSON schema field "name".
+	Name *string `json:"name,omitempty,omitzero" yaml:"name,omitempty" mapstructure:"name,omitempty"`
+
+	// Owner corresponds to the JSON schema field "owner".
+	Owner *Person `json:"owner,omitempty,omitzero" yaml:"owner,omitempty" mapstructure:"owner,omitempty"`
+}
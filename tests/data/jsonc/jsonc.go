@@ -0,0 +1,145 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+import "reflect"
+import "unicode/utf8"
+
+type Jsonc struct {
+	// Name corresponds to the JSON schema field "name".
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+
+	// Pet corresponds to the JSON schema field "pet".
+	Pet *Pet `json:"pet,omitempty,omitzero" yaml:"pet,omitempty" mapstructure:"pet,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Jsonc) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["name"]; raw != nil && !ok {
+		return fmt.Errorf("field name in Jsonc: required")
+	}
+	type Plain Jsonc
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(string(plain.Name)) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "name", 1)
+	}
+	*j = Jsonc(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Jsonc) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["name"]; raw != nil && !ok {
+		return fmt.Errorf("field name in Jsonc: required")
+	}
+	type Plain Jsonc
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(string(plain.Name)) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "name", 1)
+	}
+	*j = Jsonc(plain)
+	return nil
+}
+
+type Pet struct {
+	// Kind corresponds to the JSON schema field "kind".
+	Kind *PetKind `json:"kind,omitempty,omitzero" yaml:"kind,omitempty" mapstructure:"kind,omitempty"`
+
+	// Legs corresponds to the JSON schema field "legs".
+	Legs *int `json:"legs,omitempty,omitzero" yaml:"legs,omitempty" mapstructure:"legs,omitempty"`
+}
+
+type PetKind string
+
+const PetKindCat PetKind = "cat"
+const PetKindDog PetKind = "dog"
+
+var enumValues_PetKind = []interface{}{
+	"cat",
+	"dog",
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *PetKind) UnmarshalJSON(value []byte) error {
+	var v string
+	if err := json.Unmarshal(value, &v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_PetKind {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_PetKind, v)
+	}
+	*j = PetKind(v)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *PetKind) UnmarshalYAML(value *yaml.Node) error {
+	var v string
+	if err := value.Decode(&v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_PetKind {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_PetKind, v)
+	}
+	*j = PetKind(v)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Pet) UnmarshalJSON(value []byte) error {
+	type Plain Pet
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if plain.Legs != nil && 16 < *plain.Legs {
+		return fmt.Errorf("field %s: must be <= %v", "legs", 16)
+	}
+	*j = Pet(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Pet) UnmarshalYAML(value *yaml.Node) error {
+	type Plain Pet
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if plain.Legs != nil && 16 < *plain.Legs {
+		return fmt.Errorf("field %s: must be <= %v", "legs", 16)
+	}
+	*j = Pet(plain)
+	return nil
+}
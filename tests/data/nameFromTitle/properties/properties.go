@@ -8,6 +8,7 @@ import "github.com/go-viper/mapstructure/v2"
 import yaml "gopkg.in/yaml.v3"
 import "reflect"
 import "strings"
+import "unicode/utf8"
 
 type Alpha struct {
 	// Beta corresponds to the JSON schema field "beta".
@@ -21,6 +22,265 @@ type Alpha struct {
 
 type Beta interface{}
 
+type BetaDelta struct {
+	// CommType corresponds to the JSON schema field "commType".
+	CommType string `json:"commType" yaml:"commType" mapstructure:"commType"`
+
+	// Epsilon corresponds to the JSON schema field "epsilon".
+	Epsilon BetaEpsilon `json:"epsilon" yaml:"epsilon" mapstructure:"epsilon"`
+
+	// Theta corresponds to the JSON schema field "theta".
+	Theta Theta `json:"theta" yaml:"theta" mapstructure:"theta"`
+
+	// Zeta corresponds to the JSON schema field "zeta".
+	Zeta BetaZeta `json:"zeta" yaml:"zeta" mapstructure:"zeta"`
+
+	AdditionalProperties interface{} `mapstructure:",remain"`
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *BetaDelta) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["commType"]; raw != nil && !ok {
+		return fmt.Errorf("field commType in BetaDelta: required")
+	}
+	if _, ok := raw["epsilon"]; raw != nil && !ok {
+		return fmt.Errorf("field epsilon in BetaDelta: required")
+	}
+	if _, ok := raw["theta"]; raw != nil && !ok {
+		return fmt.Errorf("field theta in BetaDelta: required")
+	}
+	if _, ok := raw["zeta"]; raw != nil && !ok {
+		return fmt.Errorf("field zeta in BetaDelta: required")
+	}
+	type Plain BetaDelta
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if plain.CommType != "GRPC" {
+		return fmt.Errorf("field %s: must be equal to %s", "commType", "GRPC")
+	}
+	st := reflect.TypeOf(Plain{})
+	for i := range st.NumField() {
+		delete(raw, st.Field(i).Name)
+		delete(raw, strings.Split(st.Field(i).Tag.Get("json"), ",")[0])
+	}
+	if err := mapstructure.Decode(raw, &plain.AdditionalProperties); err != nil {
+		return err
+	}
+	*j = BetaDelta(plain)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *BetaDelta) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["commType"]; raw != nil && !ok {
+		return fmt.Errorf("field commType in BetaDelta: required")
+	}
+	if _, ok := raw["epsilon"]; raw != nil && !ok {
+		return fmt.Errorf("field epsilon in BetaDelta: required")
+	}
+	if _, ok := raw["theta"]; raw != nil && !ok {
+		return fmt.Errorf("field theta in BetaDelta: required")
+	}
+	if _, ok := raw["zeta"]; raw != nil && !ok {
+		return fmt.Errorf("field zeta in BetaDelta: required")
+	}
+	type Plain BetaDelta
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if plain.CommType != "GRPC" {
+		return fmt.Errorf("field %s: must be equal to %s", "commType", "GRPC")
+	}
+	st := reflect.TypeOf(Plain{})
+	for i := range st.NumField() {
+		delete(raw, st.Field(i).Name)
+		delete(raw, strings.Split(st.Field(i).Tag.Get("json"), ",")[0])
+	}
+	if err := mapstructure.Decode(raw, &plain.AdditionalProperties); err != nil {
+		return err
+	}
+	*j = BetaDelta(plain)
+	return nil
+}
+
+type BetaEpsilon string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *BetaEpsilon) UnmarshalJSON(value []byte) error {
+	type Plain BetaEpsilon
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(string(plain)) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "", 1)
+	}
+	*j = BetaEpsilon(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *BetaEpsilon) UnmarshalYAML(value *yaml.Node) error {
+	type Plain BetaEpsilon
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if utf8.RuneCountInString(string(plain)) < 1 {
+		return fmt.Errorf("field %s length: must be >= %d", "", 1)
+	}
+	*j = BetaEpsilon(plain)
+	return nil
+}
+
+type BetaGamma struct {
+	// CommType corresponds to the JSON schema field "commType".
+	CommType string `json:"commType" yaml:"commType" mapstructure:"commType"`
+
+	// Epsilon corresponds to the JSON schema field "epsilon".
+	Epsilon BetaEpsilon `json:"epsilon" yaml:"epsilon" mapstructure:"epsilon"`
+
+	// Route corresponds to the JSON schema field "route".
+	Route string `json:"route" yaml:"route" mapstructure:"route"`
+
+	// Theta corresponds to the JSON schema field "theta".
+	Theta Theta `json:"theta" yaml:"theta" mapstructure:"theta"`
+
+	// Zeta corresponds to the JSON schema field "zeta".
+	Zeta BetaZeta `json:"zeta" yaml:"zeta" mapstructure:"zeta"`
+
+	AdditionalProperties interface{} `mapstructure:",remain"`
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *BetaGamma) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["commType"]; raw != nil && !ok {
+		return fmt.Errorf("field commType in BetaGamma: required")
+	}
+	if _, ok := raw["epsilon"]; raw != nil && !ok {
+		return fmt.Errorf("field epsilon in BetaGamma: required")
+	}
+	if _, ok := raw["route"]; raw != nil && !ok {
+		return fmt.Errorf("field route in BetaGamma: required")
+	}
+	if _, ok := raw["theta"]; raw != nil && !ok {
+		return fmt.Errorf("field theta in BetaGamma: required")
+	}
+	if _, ok := raw["zeta"]; raw != nil && !ok {
+		return fmt.Errorf("field zeta in BetaGamma: required")
+	}
+	type Plain BetaGamma
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if plain.CommType != "HTTP" {
+		return fmt.Errorf("field %s: must be equal to %s", "commType", "HTTP")
+	}
+	st := reflect.TypeOf(Plain{})
+	for i := range st.NumField() {
+		delete(raw, st.Field(i).Name)
+		delete(raw, strings.Split(st.Field(i).Tag.Get("json"), ",")[0])
+	}
+	if err := mapstructure.Decode(raw, &plain.AdditionalProperties); err != nil {
+		return err
+	}
+	*j = BetaGamma(plain)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *BetaGamma) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["commType"]; raw != nil && !ok {
+		return fmt.Errorf("field commType in BetaGamma: required")
+	}
+	if _, ok := raw["epsilon"]; raw != nil && !ok {
+		return fmt.Errorf("field epsilon in BetaGamma: required")
+	}
+	if _, ok := raw["route"]; raw != nil && !ok {
+		return fmt.Errorf("field route in BetaGamma: required")
+	}
+	if _, ok := raw["theta"]; raw != nil && !ok {
+		return fmt.Errorf("field theta in BetaGamma: required")
+	}
+	if _, ok := raw["zeta"]; raw != nil && !ok {
+		return fmt.Errorf("field zeta in BetaGamma: required")
+	}
+	type Plain BetaGamma
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if plain.CommType != "HTTP" {
+		return fmt.Errorf("field %s: must be equal to %s", "commType", "HTTP")
+	}
+	st := reflect.TypeOf(Plain{})
+	for i := range st.NumField() {
+		delete(raw, st.Field(i).Name)
+		delete(raw, strings.Split(st.Field(i).Tag.Get("json"), ",")[0])
+	}
+	if err := mapstructure.Decode(raw, &plain.AdditionalProperties); err != nil {
+		return err
+	}
+	*j = BetaGamma(plain)
+	return nil
+}
+
+type BetaZeta int
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *BetaZeta) UnmarshalJSON(value []byte) error {
+	type Plain BetaZeta
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if 60 < plain {
+		return fmt.Errorf("field %s: must be <= %v", "", 60)
+	}
+	if 1 > plain {
+		return fmt.Errorf("field %s: must be >= %v", "", 1)
+	}
+	*j = BetaZeta(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *BetaZeta) UnmarshalYAML(value *yaml.Node) error {
+	type Plain BetaZeta
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if 60 < plain {
+		return fmt.Errorf("field %s: must be <= %v", "", 60)
+	}
+	if 1 > plain {
+		return fmt.Errorf("field %s: must be >= %v", "", 1)
+	}
+	*j = BetaZeta(plain)
+	return nil
+}
+
 type Eta struct {
 	// Epsilon corresponds to the JSON schema field "epsilon".
 	Epsilon string `json:"epsilon" yaml:"epsilon" mapstructure:"epsilon"`
@@ -110,10 +370,10 @@ type Properties struct {
 	AdditionalProperties interface{} `mapstructure:",remain"`
 }
 
-// UnmarshalYAML implements yaml.Unmarshaler.
-func (j *Properties) UnmarshalYAML(value *yaml.Node) error {
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Properties) UnmarshalJSON(value []byte) error {
 	var raw map[string]interface{}
-	if err := value.Decode(&raw); err != nil {
+	if err := json.Unmarshal(value, &raw); err != nil {
 		return err
 	}
 	if _, ok := raw["iota"]; raw != nil && !ok {
@@ -121,7 +381,7 @@ func (j *Properties) UnmarshalYAML(value *yaml.Node) error {
 	}
 	type Plain Properties
 	var plain Plain
-	if err := value.Decode(&plain); err != nil {
+	if err := json.Unmarshal(value, &plain); err != nil {
 		return err
 	}
 	st := reflect.TypeOf(Plain{})
@@ -136,10 +396,10 @@ func (j *Properties) UnmarshalYAML(value *yaml.Node) error {
 	return nil
 }
 
-// UnmarshalJSON implements json.Unmarshaler.
-func (j *Properties) UnmarshalJSON(value []byte) error {
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Properties) UnmarshalYAML(value *yaml.Node) error {
 	var raw map[string]interface{}
-	if err := json.Unmarshal(value, &raw); err != nil {
+	if err := value.Decode(&raw); err != nil {
 		return err
 	}
 	if _, ok := raw["iota"]; raw != nil && !ok {
@@ -147,7 +407,7 @@ func (j *Properties) UnmarshalJSON(value []byte) error {
 	}
 	type Plain Properties
 	var plain Plain
-	if err := json.Unmarshal(value, &plain); err != nil {
+	if err := value.Decode(&plain); err != nil {
 		return err
 	}
 	st := reflect.TypeOf(Plain{})
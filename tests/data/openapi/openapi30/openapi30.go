@@ -0,0 +1,323 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+import "bytes"
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+import "net/netip"
+
+type Animal struct {
+	// Value holds exactly one of the types implementing AnimalVariant.
+	Value AnimalVariant
+}
+
+// AnimalVariant is implemented by every type that Animal can hold.
+type AnimalVariant interface {
+	isAnimal()
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (j Animal) MarshalYAML() (interface{}, error) {
+	var tag string
+	switch j.Value.(type) {
+	case Cat:
+		tag = "cat"
+	case Dog:
+		tag = "dog"
+	default:
+		return j.Value, nil
+	}
+	var node yaml.Node
+	if err := node.Encode(j.Value); err != nil {
+		return nil, err
+	}
+	content := []*yaml.Node{
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: "petType"},
+		{Kind: yaml.ScalarNode, Tag: "!!str", Value: tag},
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value != "petType" {
+			content = append(content, node.Content[i], node.Content[i+1])
+		}
+	}
+	node.Content = content
+	return &node, nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (j Animal) MarshalJSON() ([]byte, error) {
+	var tag string
+	switch j.Value.(type) {
+	case Cat:
+		tag = "cat"
+	case Dog:
+		tag = "dog"
+	default:
+		return json.Marshal(j.Value)
+	}
+	data, err := json.Marshal(j.Value)
+	if err != nil {
+		return nil, err
+	}
+	tagValue, err := json.Marshal(tag)
+	if err != nil {
+		return nil, err
+	}
+	buf := append([]byte(`{"petType":`), tagValue...)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var field json.RawMessage
+		if err := dec.Decode(&field); err != nil {
+			return nil, err
+		}
+		if key == "petType" {
+			continue
+		}
+		keyName, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		buf = append(append(append(append(buf, ','), keyName...), ':'), field...)
+	}
+	return append(buf, '}'), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Animal) UnmarshalJSON(value []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return fmt.Errorf("Animal: value cannot be null")
+	}
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("Animal: expected an object with discriminator petType")
+	}
+	switch obj["petType"] {
+	case "cat":
+		var v Cat
+		if err := json.Unmarshal(value, &v); err != nil {
+			return err
+		}
+		j.Value = v
+	case "dog":
+		var v Dog
+		if err := json.Unmarshal(value, &v); err != nil {
+			return err
+		}
+		j.Value = v
+	default:
+		return fmt.Errorf("Animal: unexpected value %#v for discriminator petType", obj["petType"])
+	}
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Animal) UnmarshalYAML(value *yaml.Node) error {
+	var raw interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		return fmt.Errorf("Animal: value cannot be null")
+	}
+	obj, ok := raw.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("Animal: expected an object with discriminator petType")
+	}
+	switch obj["petType"] {
+	case "cat":
+		var v Cat
+		if err := value.Decode(&v); err != nil {
+			return err
+		}
+		j.Value = v
+	case "dog":
+		var v Dog
+		if err := value.Decode(&v); err != nil {
+			return err
+		}
+		j.Value = v
+	default:
+		return fmt.Errorf("Animal: unexpected value %#v for discriminator petType", obj["petType"])
+	}
+	return nil
+}
+
+type Cat struct {
+	// Meows corresponds to the JSON schema field "meows".
+	Meows *bool `json:"meows,omitempty,omitzero" yaml:"meows,omitempty" mapstructure:"meows,omitempty"`
+
+	// PetType corresponds to the JSON schema field "petType".
+	PetType string `json:"petType" yaml:"petType" mapstructure:"petType"`
+}
+
+func (Cat) isAnimal() {}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Cat) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["petType"]; raw != nil && !ok {
+		return fmt.Errorf("field petType in Cat: required")
+	}
+	type Plain Cat
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = Cat(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Cat) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["petType"]; raw != nil && !ok {
+		return fmt.Errorf("field petType in Cat: required")
+	}
+	type Plain Cat
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = Cat(plain)
+	return nil
+}
+
+type Dog struct {
+	// Barks corresponds to the JSON schema field "barks".
+	Barks *bool `json:"barks,omitempty,omitzero" yaml:"barks,omitempty" mapstructure:"barks,omitempty"`
+
+	// PetType corresponds to the JSON schema field "petType".
+	PetType string `json:"petType" yaml:"petType" mapstructure:"petType"`
+}
+
+func (Dog) isAnimal() {}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Dog) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["petType"]; raw != nil && !ok {
+		return fmt.Errorf("field petType in Dog: required")
+	}
+	type Plain Dog
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = Dog(plain)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Dog) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["petType"]; raw != nil && !ok {
+		return fmt.Errorf("field petType in Dog: required")
+	}
+	type Plain Dog
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = Dog(plain)
+	return nil
+}
+
+type Owner struct {
+	// Name corresponds to the JSON schema field "name".
+	Name *string `json:"name,omitempty,omitzero" yaml:"name,omitempty" mapstructure:"name,omitempty" validate:"required"`
+}
+
+type Pet struct {
+	// ChipAddress corresponds to the JSON schema field "chipAddress".
+	ChipAddress *netip.Addr `json:"chipAddress,omitempty,omitzero" yaml:"chipAddress,omitempty" mapstructure:"chipAddress,omitempty"`
+
+	// ID corresponds to the JSON schema field "id".
+	ID int `json:"id" yaml:"id" mapstructure:"id"`
+
+	// Name corresponds to the JSON schema field "name".
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+
+	// Owner corresponds to the JSON schema field "owner".
+	Owner *Owner `json:"owner,omitempty,omitzero" yaml:"owner,omitempty" mapstructure:"owner,omitempty"`
+
+	// Tag corresponds to the JSON schema field "tag".
+	Tag PetTag `json:"tag,omitempty,omitzero" yaml:"tag,omitempty" mapstructure:"tag,omitempty"`
+
+	// Weight corresponds to the JSON schema field "weight".
+	Weight *float64 `json:"weight,omitempty,omitzero" yaml:"weight,omitempty" mapstructure:"weight,omitempty"`
+}
+
+type PetTag *string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Pet) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["id"]; raw != nil && !ok {
+		return fmt.Errorf("field id in Pet: required")
+	}
+	if _, ok := raw["name"]; raw != nil && !ok {
+		return fmt.Errorf("field name in Pet: required")
+	}
+	type Plain Pet
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if plain.Weight != nil && 0 >= *plain.Weight {
+		return fmt.Errorf("field %s: must be > %v", "weight", 0)
+	}
+	*j = Pet(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Pet) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["id"]; raw != nil && !ok {
+		return fmt.Errorf("field id in Pet: required")
+	}
+	if _, ok := raw["name"]; raw != nil && !ok {
+		return fmt.Errorf("field name in Pet: required")
+	}
+	type Plain Pet
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if plain.Weight != nil && 0 >= *plain.Weight {
+		return fmt.Errorf("field %s: must be > %v", "weight", 0)
+	}
+	*j = Pet(plain)
+	return nil
+}
@@ -0,0 +1,255 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+import "reflect"
+
+type Order struct {
+	// Id corresponds to the JSON schema field "id".
+	Id string `json:"id" yaml:"id" mapstructure:"id"`
+
+	// Lines corresponds to the JSON schema field "lines".
+	Lines []OrderLine `json:"lines" yaml:"lines" mapstructure:"lines"`
+
+	// Note corresponds to the JSON schema field "note".
+	Note OrderNote `json:"note,omitempty,omitzero" yaml:"note,omitempty" mapstructure:"note,omitempty"`
+
+	// Status corresponds to the JSON schema field "status".
+	Status *Status `json:"status,omitempty,omitzero" yaml:"status,omitempty" mapstructure:"status,omitempty"`
+}
+
+type OrderLine struct {
+	// Position corresponds to the JSON schema field "position".
+	Position *OrderLinePosition `json:"position,omitempty,omitzero" yaml:"position,omitempty" mapstructure:"position,omitempty"`
+
+	// Quantity corresponds to the JSON schema field "quantity".
+	Quantity *int `json:"quantity,omitempty,omitzero" yaml:"quantity,omitempty" mapstructure:"quantity,omitempty"`
+
+	// Sku corresponds to the JSON schema field "sku".
+	Sku string `json:"sku" yaml:"sku" mapstructure:"sku"`
+}
+
+type OrderLinePosition struct {
+	// Item0 corresponds to item 0 of the JSON schema tuple.
+	Item0 *int
+
+	// Item1 corresponds to item 1 of the JSON schema tuple.
+	Item1 *int
+}
+
+// MarshalJSON implements json.Marshaler.
+func (j OrderLinePosition) MarshalJSON() ([]byte, error) {
+	items := []interface{}{}
+	if j.Item0 != nil {
+		items = append(items, j.Item0)
+	}
+	if j.Item1 != nil {
+		if len(items) != 1 {
+			return nil, fmt.Errorf("OrderLinePosition: Item1 cannot be set without Item0")
+		}
+		items = append(items, j.Item1)
+	}
+	return json.Marshal(items)
+}
+
+// MarshalYAML implements yaml.Marshaler.
+func (j OrderLinePosition) MarshalYAML() (interface{}, error) {
+	items := []interface{}{}
+	if j.Item0 != nil {
+		items = append(items, j.Item0)
+	}
+	if j.Item1 != nil {
+		if len(items) != 1 {
+			return nil, fmt.Errorf("OrderLinePosition: Item1 cannot be set without Item0")
+		}
+		items = append(items, j.Item1)
+	}
+	return items, nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *OrderLinePosition) UnmarshalJSON(value []byte) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(value, &items); err != nil {
+		return err
+	}
+	if len(items) > 2 {
+		return fmt.Errorf("OrderLinePosition: expected at most 2 items, got %d", len(items))
+	}
+	var v OrderLinePosition
+	if len(items) > 0 {
+		if err := json.Unmarshal(items[0], &v.Item0); err != nil {
+			return fmt.Errorf("OrderLinePosition: item 0: %w", err)
+		}
+	}
+	if len(items) > 1 {
+		if err := json.Unmarshal(items[1], &v.Item1); err != nil {
+			return fmt.Errorf("OrderLinePosition: item 1: %w", err)
+		}
+	}
+	*j = v
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *OrderLinePosition) UnmarshalYAML(value *yaml.Node) error {
+	var items []yaml.Node
+	if err := value.Decode(&items); err != nil {
+		return err
+	}
+	if len(items) > 2 {
+		return fmt.Errorf("OrderLinePosition: expected at most 2 items, got %d", len(items))
+	}
+	var v OrderLinePosition
+	if len(items) > 0 {
+		if err := items[0].Decode(&v.Item0); err != nil {
+			return fmt.Errorf("OrderLinePosition: item 0: %w", err)
+		}
+	}
+	if len(items) > 1 {
+		if err := items[1].Decode(&v.Item1); err != nil {
+			return fmt.Errorf("OrderLinePosition: item 1: %w", err)
+		}
+	}
+	*j = v
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *OrderLine) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["sku"]; raw != nil && !ok {
+		return fmt.Errorf("field sku in OrderLine: required")
+	}
+	type Plain OrderLine
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	if plain.Quantity != nil && 0 >= *plain.Quantity {
+		return fmt.Errorf("field %s: must be > %v", "quantity", 0)
+	}
+	*j = OrderLine(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *OrderLine) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["sku"]; raw != nil && !ok {
+		return fmt.Errorf("field sku in OrderLine: required")
+	}
+	type Plain OrderLine
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	if plain.Quantity != nil && 0 >= *plain.Quantity {
+		return fmt.Errorf("field %s: must be > %v", "quantity", 0)
+	}
+	*j = OrderLine(plain)
+	return nil
+}
+
+type OrderNote *string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Order) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["id"]; raw != nil && !ok {
+		return fmt.Errorf("field id in Order: required")
+	}
+	if _, ok := raw["lines"]; raw != nil && !ok {
+		return fmt.Errorf("field lines in Order: required")
+	}
+	type Plain Order
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = Order(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Order) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["id"]; raw != nil && !ok {
+		return fmt.Errorf("field id in Order: required")
+	}
+	if _, ok := raw["lines"]; raw != nil && !ok {
+		return fmt.Errorf("field lines in Order: required")
+	}
+	type Plain Order
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = Order(plain)
+	return nil
+}
+
+type Status string
+
+const StatusOpen Status = "open"
+const StatusShipped Status = "shipped"
+
+var enumValues_Status = []interface{}{
+	"open",
+	"shipped",
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Status) UnmarshalJSON(value []byte) error {
+	var v string
+	if err := json.Unmarshal(value, &v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_Status {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_Status, v)
+	}
+	*j = Status(v)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Status) UnmarshalYAML(value *yaml.Node) error {
+	var v string
+	if err := value.Decode(&v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_Status {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_Status, v)
+	}
+	*j = Status(v)
+	return nil
+}
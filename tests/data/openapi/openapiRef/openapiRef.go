@@ -0,0 +1,59 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+
+type Address struct {
+	// City corresponds to the JSON schema field "city".
+	City string `json:"city" yaml:"city" mapstructure:"city"`
+
+	// Street corresponds to the JSON schema field "street".
+	Street *string `json:"street,omitempty,omitzero" yaml:"street,omitempty" mapstructure:"street,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Address) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["city"]; raw != nil && !ok {
+		return fmt.Errorf("field city in Address: required")
+	}
+	type Plain Address
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = Address(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Address) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["city"]; raw != nil && !ok {
+		return fmt.Errorf("field city in Address: required")
+	}
+	type Plain Address
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = Address(plain)
+	return nil
+}
+
+type OpenapiRef struct {
+	// Billing corresponds to the JSON schema field "billing".
+	Billing *Address `json:"billing,omitempty,omitzero" yaml:"billing,omitempty" mapstructure:"billing,omitempty"`
+
+	// Shipping corresponds to the JSON schema field "shipping".
+	Shipping *Address `json:"shipping,omitempty,omitzero" yaml:"shipping,omitempty" mapstructure:"shipping,omitempty"`
+}
@@ -0,0 +1,13 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package pets
+
+import people "github.com/atombender/go-jsonschema/tests/data/outputRules/people"
+
+type Pet struct {
+	// Name corresponds to the JSON schema field "name".
+	Name *string `json:"name,omitempty,omitzero" yaml:"name,omitempty" mapstructure:"name,omitempty"`
+
+	// Owner corresponds to the JSON schema field "owner".
+	Owner *people.Person `json:"owner,omitempty,omitzero" yaml:"owner,omitempty" mapstructure:"owner,omitempty"`
+}
@@ -0,0 +1,112 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+import "reflect"
+
+type Address struct {
+	// Country corresponds to the JSON schema field "country".
+	Country *Country `json:"country,omitempty,omitzero" yaml:"country,omitempty" mapstructure:"country,omitempty"`
+
+	// Street corresponds to the JSON schema field "street".
+	Street string `json:"street" yaml:"street" mapstructure:"street"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Address) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["street"]; raw != nil && !ok {
+		return fmt.Errorf("field street in Address: required")
+	}
+	type Plain Address
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = Address(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Address) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["street"]; raw != nil && !ok {
+		return fmt.Errorf("field street in Address: required")
+	}
+	type Plain Address
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = Address(plain)
+	return nil
+}
+
+type Country string
+
+const CountryNL Country = "NL"
+const CountryNO Country = "NO"
+const CountrySE Country = "SE"
+
+var enumValues_Country = []interface{}{
+	"NL",
+	"NO",
+	"SE",
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *Country) UnmarshalJSON(value []byte) error {
+	var v string
+	if err := json.Unmarshal(value, &v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_Country {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_Country, v)
+	}
+	*j = Country(v)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *Country) UnmarshalYAML(value *yaml.Node) error {
+	var v string
+	if err := value.Decode(&v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_Country {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_Country, v)
+	}
+	*j = Country(v)
+	return nil
+}
+
+type SchemaMap struct {
+	// Country corresponds to the JSON schema field "country".
+	Country *Country `json:"country,omitempty,omitzero" yaml:"country,omitempty" mapstructure:"country,omitempty"`
+
+	// Home corresponds to the JSON schema field "home".
+	Home *Address `json:"home,omitempty,omitzero" yaml:"home,omitempty" mapstructure:"home,omitempty"`
+}
@@ -0,0 +1,21 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+type Address struct {
+	// Street corresponds to the JSON schema field "street".
+	Street *string `json:"street,omitempty,omitzero" yaml:"street,omitempty" mapstructure:"street,omitempty"`
+}
+
+type Person struct {
+	// Address corresponds to the JSON schema field "address".
+	Address *Address `json:"address,omitempty,omitzero" yaml:"address,omitempty" mapstructure:"address,omitempty"`
+
+	// Name corresponds to the JSON schema field "name".
+	Name *string `json:"name,omitempty,omitzero" yaml:"name,omitempty" mapstructure:"name,omitempty"`
+}
+
+type SchemeLoader struct {
+	// Owner corresponds to the JSON schema field "owner".
+	Owner *Person `json:"owner,omitempty,omitzero" yaml:"owner,omitempty" mapstructure:"owner,omitempty"`
+}
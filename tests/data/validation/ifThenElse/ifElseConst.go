@@ -0,0 +1,74 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+import "slices"
+
+type IfElseConst struct {
+	// Features corresponds to the JSON schema field "features".
+	Features []string `json:"features,omitempty,omitzero" yaml:"features,omitempty" mapstructure:"features,omitempty"`
+
+	// Version corresponds to the JSON schema field "version".
+	Version *int `json:"version,omitempty,omitzero" yaml:"version,omitempty" mapstructure:"version,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *IfElseConst) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if raw != nil {
+		ifMatches := true
+		if _, ok := raw["version"]; !ok {
+			ifMatches = false
+		}
+		if v, ok := raw["version"]; ok && !slices.Contains([]interface{}{float64(1), 1}, v) {
+			ifMatches = false
+		}
+		if !ifMatches {
+			if _, ok := raw["features"]; !ok {
+				return fmt.Errorf("field features in IfElseConst: required by else")
+			}
+		}
+	}
+	type Plain IfElseConst
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = IfElseConst(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *IfElseConst) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if raw != nil {
+		ifMatches := true
+		if _, ok := raw["version"]; !ok {
+			ifMatches = false
+		}
+		if v, ok := raw["version"]; ok && !slices.Contains([]interface{}{float64(1), 1}, v) {
+			ifMatches = false
+		}
+		if !ifMatches {
+			if _, ok := raw["features"]; !ok {
+				return fmt.Errorf("field features in IfElseConst: required by else")
+			}
+		}
+	}
+	type Plain IfElseConst
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = IfElseConst(plain)
+	return nil
+}
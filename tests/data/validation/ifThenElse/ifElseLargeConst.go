@@ -0,0 +1,74 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+import "slices"
+
+type IfElseLargeConst struct {
+	// CreatedAt corresponds to the JSON schema field "createdAt".
+	CreatedAt *int `json:"createdAt,omitempty,omitzero" yaml:"createdAt,omitempty" mapstructure:"createdAt,omitempty"`
+
+	// Legacy corresponds to the JSON schema field "legacy".
+	Legacy *bool `json:"legacy,omitempty,omitzero" yaml:"legacy,omitempty" mapstructure:"legacy,omitempty"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *IfElseLargeConst) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if raw != nil {
+		ifMatches := true
+		if _, ok := raw["createdAt"]; !ok {
+			ifMatches = false
+		}
+		if v, ok := raw["createdAt"]; ok && !slices.Contains([]interface{}{float64(1700000000000), 1700000000000}, v) {
+			ifMatches = false
+		}
+		if ifMatches {
+			if _, ok := raw["legacy"]; !ok {
+				return fmt.Errorf("field legacy in IfElseLargeConst: required by then")
+			}
+		}
+	}
+	type Plain IfElseLargeConst
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = IfElseLargeConst(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *IfElseLargeConst) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if raw != nil {
+		ifMatches := true
+		if _, ok := raw["createdAt"]; !ok {
+			ifMatches = false
+		}
+		if v, ok := raw["createdAt"]; ok && !slices.Contains([]interface{}{float64(1700000000000), 1700000000000}, v) {
+			ifMatches = false
+		}
+		if ifMatches {
+			if _, ok := raw["legacy"]; !ok {
+				return fmt.Errorf("field legacy in IfElseLargeConst: required by then")
+			}
+		}
+	}
+	type Plain IfElseLargeConst
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = IfElseLargeConst(plain)
+	return nil
+}
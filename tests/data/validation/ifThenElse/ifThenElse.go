@@ -0,0 +1,147 @@
+// Code generated by github.com/atombender/go-jsonschema, DO NOT EDIT.
+
+package test
+
+import "encoding/json"
+import "fmt"
+import yaml "gopkg.in/yaml.v3"
+import "reflect"
+import "slices"
+
+type IfThenElse struct {
+	// Mode corresponds to the JSON schema field "mode".
+	Mode *string `json:"mode,omitempty,omitzero" yaml:"mode,omitempty" mapstructure:"mode,omitempty"`
+
+	// Path corresponds to the JSON schema field "path".
+	Path *string `json:"path,omitempty,omitzero" yaml:"path,omitempty" mapstructure:"path,omitempty"`
+
+	// Port corresponds to the JSON schema field "port".
+	Port *int `json:"port,omitempty,omitzero" yaml:"port,omitempty" mapstructure:"port,omitempty"`
+
+	// Protocol corresponds to the JSON schema field "protocol".
+	Protocol IfThenElseProtocol `json:"protocol" yaml:"protocol" mapstructure:"protocol"`
+}
+
+type IfThenElseProtocol string
+
+const IfThenElseProtocolTcp IfThenElseProtocol = "tcp"
+const IfThenElseProtocolUdp IfThenElseProtocol = "udp"
+const IfThenElseProtocolUnix IfThenElseProtocol = "unix"
+
+var enumValues_IfThenElseProtocol = []interface{}{
+	"tcp",
+	"udp",
+	"unix",
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *IfThenElseProtocol) UnmarshalJSON(value []byte) error {
+	var v string
+	if err := json.Unmarshal(value, &v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_IfThenElseProtocol {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_IfThenElseProtocol, v)
+	}
+	*j = IfThenElseProtocol(v)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *IfThenElseProtocol) UnmarshalYAML(value *yaml.Node) error {
+	var v string
+	if err := value.Decode(&v); err != nil {
+		return err
+	}
+	var ok bool
+	for _, expected := range enumValues_IfThenElseProtocol {
+		if reflect.DeepEqual(v, expected) {
+			ok = true
+			break
+		}
+	}
+	if !ok {
+		return fmt.Errorf("invalid value (expected one of %#v): %#v", enumValues_IfThenElseProtocol, v)
+	}
+	*j = IfThenElseProtocol(v)
+	return nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (j *IfThenElse) UnmarshalJSON(value []byte) error {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(value, &raw); err != nil {
+		return err
+	}
+	if _, ok := raw["protocol"]; raw != nil && !ok {
+		return fmt.Errorf("field protocol in IfThenElse: required")
+	}
+	if raw != nil {
+		ifMatches := true
+		if v, ok := raw["protocol"]; ok && !slices.Contains([]interface{}{"tcp", "udp"}, v) {
+			ifMatches = false
+		}
+		if ifMatches {
+			if _, ok := raw["port"]; !ok {
+				return fmt.Errorf("field port in IfThenElse: required by then")
+			}
+		} else {
+			if _, ok := raw["path"]; !ok {
+				return fmt.Errorf("field path in IfThenElse: required by else")
+			}
+			if v, ok := raw["mode"]; ok && !slices.Contains([]interface{}{"stream", "datagram"}, v) {
+				return fmt.Errorf("field mode in IfThenElse: invalid value (else expects one of %#v): %#v", []interface{}{"stream", "datagram"}, v)
+			}
+		}
+	}
+	type Plain IfThenElse
+	var plain Plain
+	if err := json.Unmarshal(value, &plain); err != nil {
+		return err
+	}
+	*j = IfThenElse(plain)
+	return nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (j *IfThenElse) UnmarshalYAML(value *yaml.Node) error {
+	var raw map[string]interface{}
+	if err := value.Decode(&raw); err != nil {
+		return err
+	}
+	if _, ok := raw["protocol"]; raw != nil && !ok {
+		return fmt.Errorf("field protocol in IfThenElse: required")
+	}
+	if raw != nil {
+		ifMatches := true
+		if v, ok := raw["protocol"]; ok && !slices.Contains([]interface{}{"tcp", "udp"}, v) {
+			ifMatches = false
+		}
+		if ifMatches {
+			if _, ok := raw["port"]; !ok {
+				return fmt.Errorf("field port in IfThenElse: required by then")
+			}
+		} else {
+			if _, ok := raw["path"]; !ok {
+				return fmt.Errorf("field path in IfThenElse: required by else")
+			}
+			if v, ok := raw["mode"]; ok && !slices.Contains([]interface{}{"stream", "datagram"}, v) {
+				return fmt.Errorf("field mode in IfThenElse: invalid value (else expects one of %#v): %#v", []interface{}{"stream", "datagram"}, v)
+			}
+		}
+	}
+	type Plain IfThenElse
+	var plain Plain
+	if err := value.Decode(&plain); err != nil {
+		return err
+	}
+	*j = IfThenElse(plain)
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/google/go-cmp/cmp"
 
 	"github.com/atombender/go-jsonschema/pkg/generator"
+	"github.com/atombender/go-jsonschema/pkg/schemas"
 )
 
 var (
@@ -109,6 +110,15 @@ func TestDisableCustomTypesForMaps(t *testing.T) {
 	testExamples(t, cfg, "./data/disableCustomTypesForMaps")
 }
 
+func TestDisableUnionTypes(t *testing.T) {
+	t.Parallel()
+
+	cfg := basicConfig
+	cfg.DisableUnionTypes = true
+
+	testExamples(t, cfg, "./data/disableUnionTypes")
+}
+
 func TestMiscWithDefaults(t *testing.T) {
 	t.Parallel()
 
@@ -171,6 +181,58 @@ func TestCrossPackageNoOutput(t *testing.T) {
 	testExampleFile(t, cfg, "./data/crossPackageNoOutput/schema/schema.json")
 }
 
+func TestOutputRules(t *testing.T) {
+	t.Parallel()
+
+	cfg := basicConfig
+	cfg.OutputRules = []generator.OutputRule{
+		{
+			IDPrefix:    "https://example.com/",
+			PackageName: "github.com/atombender/go-jsonschema/tests/data/outputRules/{{dir}}",
+			OutputName:  "../{{dir}}/{{basename}}.go",
+		},
+		{
+			Glob:        "outputRules/**/*.json",
+			BaseDir:     "./data",
+			PackageName: "github.com/atombender/go-jsonschema/tests/data/outputRules/{{dir}}",
+			OutputName:  "../{{dir}}/{{basename}}.go",
+		},
+	}
+	testExampleFile(t, cfg, "./data/outputRules/pets/pet.json")
+}
+
+func TestInvalidOutputRules(t *testing.T) {
+	t.Parallel()
+
+	for _, rule := range []generator.OutputRule{
+		{OutputName: "{{basename}}.go"},
+		{IDPrefix: "https://example.com/", Path: "schemas"},
+		{Glob: "schemas/[.json"},
+		{Path: "schemas", OutputName: "{{name}}.go"},
+	} {
+		cfg := basicConfig
+		cfg.OutputRules = []generator.OutputRule{rule}
+
+		if _, err := generator.New(cfg); err == nil {
+			t.Errorf("Expected output rule %+v to be invalid", rule)
+		}
+	}
+}
+
+func TestSchemaMap(t *testing.T) {
+	t.Parallel()
+
+	cfg := basicConfig
+	cfg.URIMappings = []schemas.URIMapping{
+		{
+			Prefix: "https://schemas.example.com/common/",
+			Dir:    "./data/schemaMap/common",
+		},
+	}
+	testExampleFile(t, cfg, "./data/schemaMap/remote/schemaMap.json")
+	testExampleFile(t, cfg, "./data/schemaMap/local/schemaMap.json")
+}
+
 func TestBooleanAsSchema(t *testing.T) {
 	t.Parallel()
 
@@ -304,6 +366,112 @@ func TestStructWithConstraints(t *testing.T) {
 	testExamples(t, basicConfig, "./data/structWithConstraints")
 }
 
+func TestOpenAPI(t *testing.T) {
+	t.Parallel()
+
+	cfg := basicConfig
+	testExampleFile(t, cfg, "./data/openapi/openapi30/openapi30.yaml")
+	testExampleFile(t, cfg, "./data/openapi/openapi31/openapi31.json")
+	testExampleFile(t, cfg, "./data/openapi/openapiRef/openapiRef.json")
+}
+
+func TestFSLoader(t *testing.T) {
+	t.Parallel()
+
+	cfg := basicConfig
+	cfg.Loader = schemas.NewFSLoader(os.DirFS("."), cfg.ResolveExtensions, cfg.YAMLExtensions)
+	testExampleFile(t, cfg, "./data/fsLoader/fsLoader.json")
+}
+
+func TestJSONC(t *testing.T) {
+	t.Parallel()
+
+	cfg := basicConfig
+	cfg.ResolveExtensions = []string{".json", ".jsonc", ".json5"}
+	cfg.JSONCExtensions = []string{".jsonc", ".json5"}
+	testExampleFile(t, cfg, "./data/jsonc/jsonc.jsonc")
+}
+
+func TestFormatSniffing(t *testing.T) {
+	t.Parallel()
+
+	cfg := basicConfig
+	testExampleFile(t, cfg, "./data/formatSniffing/formatSniffing")
+}
+
+func TestSchemeLoader(t *testing.T) {
+	t.Parallel()
+
+	cfg := basicConfig
+	cfg.LoaderOptions = []schemas.LoaderOption{
+		schemas.WithSchemeLoader("mem", schemas.LoaderFunc(func(uri, _ string) (*schemas.Schema, error) {
+			return schemas.FromJSONFile(filepath.Join("./data/schemeLoader", strings.TrimPrefix(uri, "mem://")))
+		})),
+	}
+
+	testExampleFile(t, cfg, "./data/schemeLoader/schemeLoader.json")
+}
+
+func TestBaseURI(t *testing.T) {
+	t.Parallel()
+
+	cfg := basicConfig
+	cfg.LoaderOptions = []schemas.LoaderOption{
+		schemas.WithSchemeLoader("mirror", schemas.LoaderFunc(func(uri, _ string) (*schemas.Schema, error) {
+			return schemas.FromJSONFile(filepath.Join("./data/baseURI", strings.TrimPrefix(uri, "mirror://")))
+		})),
+	}
+
+	testExampleFile(t, cfg, "./data/baseURI/baseURI.json")
+}
+
+func TestTransformers(t *testing.T) {
+	t.Parallel()
+
+	data, err := os.ReadFile("./data/transformers/owner.patch.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	patch, err := schemas.ParsePatch(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := basicConfig
+	cfg.Transformers = []schemas.Transformer{
+		func(_ string, schema *schemas.Schema) error {
+			delete(schema.Properties, "internal")
+
+			return nil
+		},
+		schemas.PatchTransformer("./data/transformers/owner.json", patch),
+	}
+
+	testExampleFile(t, cfg, "./data/transformers/transformers.json")
+}
+
+func TestErrorPositions(t *testing.T) {
+	t.Parallel()
+
+	g, err := generator.New(basicConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fileName := "./data/errorPositions/missingRef.FAIL.json"
+
+	err = g.DoFile(fileName)
+	if err == nil {
+		t.Fatal("Expected test to fail")
+	}
+
+	want := fmt.Sprintf("%s:8:10 (#/properties/b): ", filepath.Clean(fileName))
+	if !strings.Contains(err.Error(), want) {
+		t.Fatalf("Expected error to be located at %q, got: %v", want, err)
+	}
+}
+
 func testExamples(t *testing.T, cfg generator.Config, dataDir string) {
 	t.Helper()
 
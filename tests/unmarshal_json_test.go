@@ -10,6 +10,8 @@ import (
 	testAdditionalProperties "github.com/atombender/go-jsonschema/tests/data/core/additionalProperties"
 	testAllOf "github.com/atombender/go-jsonschema/tests/data/core/allOf"
 	testAnyOf "github.com/atombender/go-jsonschema/tests/data/core/anyOf"
+	testOneOf "github.com/atombender/go-jsonschema/tests/data/core/oneOf"
+	testTuple "github.com/atombender/go-jsonschema/tests/data/core/tuple"
 	test "github.com/atombender/go-jsonschema/tests/data/extraImports/gopkgYAMLv3"
 	testValudationRequiredFields "github.com/atombender/go-jsonschema/tests/data/validation/requiredFields"
 )
@@ -296,6 +298,304 @@ func TestJsonUmarshalAllOf(t *testing.T) {
 	}
 }
 
+func TestJsonUmarshalOneOf(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc     string
+		json     string
+		target   any
+		wantErr  bool
+		assertFn func(target any)
+	}{
+		{
+			desc:   "oneOf - primitive and object variants",
+			json:   `{"value": "hello", "shape": {"radius": 2}}`,
+			target: &testOneOf.OneOf{},
+			assertFn: func(target any) {
+				assert.Equal(
+					t,
+					&testOneOf.OneOf{
+						Value: &testOneOf.OneOfValue{Value: testOneOf.OneOfValue_0("hello")},
+						Shape: testOneOf.OneOfShape{Value: testOneOf.OneOfShape_0{Radius: 2}},
+					},
+					target,
+				)
+			},
+		},
+		{
+			desc:   "oneOf - second variant",
+			json:   `{"value": 3, "shape": {"width": 1, "height": 2}}`,
+			target: &testOneOf.OneOf{},
+			assertFn: func(target any) {
+				assert.Equal(
+					t,
+					&testOneOf.OneOf{
+						Value: &testOneOf.OneOfValue{Value: testOneOf.OneOfValue_1(3)},
+						Shape: testOneOf.OneOfShape{Value: testOneOf.OneOfShape_1{Width: 1, Height: 2}},
+					},
+					target,
+				)
+			},
+		},
+		{
+			desc:    "oneOf - no variant matches",
+			json:    `{"shape": {"side": 2}}`,
+			target:  &testOneOf.OneOf{},
+			wantErr: true,
+		},
+		{
+			desc:    "oneOf - more than one variant matches",
+			json:    `{"shape": {"radius": 1, "width": 1, "height": 2}}`,
+			target:  &testOneOf.OneOf{},
+			wantErr: true,
+		},
+		{
+			desc:    "oneOf - null is not a variant",
+			json:    `{"shape": null}`,
+			target:  &testOneOf.OneOf{},
+			wantErr: true,
+		},
+		{
+			desc:   "oneOf - refs",
+			json:   `{"pets": [{"meows": true}, {"barks": false}]}`,
+			target: &testOneOf.OneOfRef{},
+			assertFn: func(target any) {
+				assert.Equal(
+					t,
+					&testOneOf.OneOfRef{
+						Pets: []testOneOf.Pet{
+							{Value: testOneOf.Cat{Meows: true}},
+							{Value: testOneOf.Dog{Barks: false}},
+						},
+					},
+					target,
+				)
+			},
+		},
+		{
+			desc:   "oneOf - with direct properties",
+			json:   `{"id": "1", "phone": "555"}`,
+			target: &testOneOf.OneOfWithDirectProperties{},
+			assertFn: func(target any) {
+				assert.Equal(
+					t,
+					&testOneOf.OneOfWithDirectProperties{
+						Value: testOneOf.OneOfWithDirectProperties_1{Id: "1", Phone: "555"},
+					},
+					target,
+				)
+			},
+		},
+		{
+			desc:   "oneOf - inferred discriminator",
+			json:   `{"event": {"kind": "group", "members": ["a"]}}`,
+			target: &testOneOf.OneOfDiscriminator{},
+			assertFn: func(target any) {
+				assert.Equal(
+					t,
+					&testOneOf.OneOfDiscriminator{
+						Event: testOneOf.OneOfDiscriminatorEvent{
+							Value: testOneOf.GroupEvent{Kind: "group", Members: []string{"a"}},
+						},
+					},
+					target,
+				)
+			},
+		},
+		{
+			desc:    "oneOf - inferred discriminator reports the variant's error",
+			json:    `{"event": {"kind": "user"}}`,
+			target:  &testOneOf.OneOfDiscriminator{},
+			wantErr: true,
+		},
+		{
+			desc:    "oneOf - unknown discriminator value",
+			json:    `{"event": {"kind": "robot", "name": "x"}}`,
+			target:  &testOneOf.OneOfDiscriminator{},
+			wantErr: true,
+		},
+		{
+			desc:   "oneOf - discriminator mapping alias",
+			json:   `{"shape": {"shapeType": "round", "radius": 1}}`,
+			target: &testOneOf.OneOfDiscriminatorMapping{},
+			assertFn: func(target any) {
+				radius := 1.0
+
+				assert.Equal(
+					t,
+					&testOneOf.OneOfDiscriminatorMapping{
+						Shape: &testOneOf.OneOfDiscriminatorMappingShape{Value: testOneOf.Circle{Radius: &radius}},
+					},
+					target,
+				)
+			},
+		},
+		{
+			desc:   "oneOf - discriminator defaults to the ref name",
+			json:   `{"shape": {"shapeType": "Square"}}`,
+			target: &testOneOf.OneOfDiscriminatorMapping{},
+			assertFn: func(target any) {
+				assert.Equal(
+					t,
+					&testOneOf.OneOfDiscriminatorMapping{
+						Shape: &testOneOf.OneOfDiscriminatorMappingShape{Value: testOneOf.Square{}},
+					},
+					target,
+				)
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			err := json.Unmarshal([]byte(tC.json), tC.target)
+			if tC.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unmarshal error: %s", err)
+			}
+
+			tC.assertFn(tC.target)
+		})
+	}
+}
+
+func TestJsonMarshalOneOf(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(testOneOf.OneOf{
+		Value: &testOneOf.OneOfValue{Value: testOneOf.OneOfValue_1(3)},
+		Shape: testOneOf.OneOfShape{Value: testOneOf.OneOfShape_0{Radius: 2}},
+	})
+	if err != nil {
+		t.Fatalf("marshal error: %s", err)
+	}
+
+	assert.JSONEq(t, `{"value": 3, "shape": {"radius": 2}}`, string(data))
+}
+
+func TestJsonMarshalOneOfDiscriminator(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(testOneOf.OneOfDiscriminatorMapping{
+		Shape: &testOneOf.OneOfDiscriminatorMappingShape{Value: testOneOf.Square{}},
+	})
+	if err != nil {
+		t.Fatalf("marshal error: %s", err)
+	}
+
+	assert.JSONEq(t, `{"shape": {"shapeType": "Square"}}`, string(data))
+}
+
+func TestJsonMarshalOneOfDiscriminatorKeepsFields(t *testing.T) {
+	t.Parallel()
+
+	id := 9007199254740993
+
+	data, err := json.Marshal(testOneOf.OneOfDiscriminator{
+		Event: testOneOf.OneOfDiscriminatorEvent{Value: testOneOf.UserEvent{Id: &id, Name: "x"}},
+	})
+	if err != nil {
+		t.Fatalf("marshal error: %s", err)
+	}
+
+	assert.Equal(t, `{"event":{"kind":"user","id":9007199254740993,"name":"x"}}`, string(data))
+}
+
+func TestJsonUnmarshalTuple(t *testing.T) {
+	t.Parallel()
+
+	timeout := 1.5
+
+	testCases := []struct {
+		desc    string
+		json    string
+		want    testTuple.Tuple
+		wantErr bool
+	}{
+		{
+			desc: "required items only",
+			json: `{"position": [4.9, 52.3], "call": ["ping", 1]}`,
+			want: testTuple.Tuple{
+				Position: &testTuple.Position{Longitude: 4.9, Latitude: 52.3},
+				Call:     &testTuple.TupleCall{Method: "ping", Item1: 1},
+			},
+		},
+		{
+			desc: "optional item and rest",
+			json: `{"call": ["send", 2, {"timeout": 1.5}, "a", "b"]}`,
+			want: testTuple.Tuple{
+				Call: &testTuple.TupleCall{
+					Method: "send",
+					Item1:  2,
+					Item2:  &testTuple.TupleCallItem2{Timeout: &timeout},
+					Rest:   []string{"a", "b"},
+				},
+			},
+		},
+		{
+			desc:    "too few items",
+			json:    `{"position": [4.9]}`,
+			wantErr: true,
+		},
+		{
+			desc:    "items not allowed after prefixItems",
+			json:    `{"position": [4.9, 52.3, 0]}`,
+			wantErr: true,
+		},
+		{
+			desc:    "wrongly typed item",
+			json:    `{"call": ["send", "two"]}`,
+			wantErr: true,
+		},
+		{
+			desc:    "wrongly typed rest",
+			json:    `{"call": ["send", 2, {}, 3]}`,
+			wantErr: true,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var got testTuple.Tuple
+
+			err := json.Unmarshal([]byte(tC.json), &got)
+			if tC.wantErr {
+				assert.Error(t, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tC.want, got)
+		})
+	}
+}
+
+func TestJsonMarshalTuple(t *testing.T) {
+	t.Parallel()
+
+	data, err := json.Marshal(testTuple.Tuple{
+		Position: &testTuple.Position{Longitude: 4.9, Latitude: 52.3},
+		Call:     &testTuple.TupleCall{Method: "send", Item1: 2, Item2: &testTuple.TupleCallItem2{}, Rest: []string{"a"}},
+	})
+	if err != nil {
+		t.Fatalf("marshal error: %s", err)
+	}
+
+	assert.JSONEq(t, `{"position": [4.9, 52.3], "call": ["send", 2, {}, "a"]}`, string(data))
+
+	_, err = json.Marshal(testTuple.TupleCall{Method: "send", Rest: []string{"a"}})
+	assert.Error(t, err, "rest items cannot follow an absent optional item")
+}
+
 func TestJSONUnmarshalAdditionalProperties(t *testing.T) {
 	t.Parallel()
 
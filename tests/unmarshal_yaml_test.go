@@ -8,6 +8,8 @@ import (
 
 	yamlv3 "gopkg.in/yaml.v3"
 
+	testOneOf "github.com/atombender/go-jsonschema/tests/data/core/oneOf"
+	testTuple "github.com/atombender/go-jsonschema/tests/data/core/tuple"
 	test "github.com/atombender/go-jsonschema/tests/data/extraImports/gopkgYAMLv3"
 )
 
@@ -68,3 +70,120 @@ func TestYamlV3UnmarshalInvalidEnum(t *testing.T) {
 		t.Error("Expected unmarshal error to contain enum values")
 	}
 }
+
+func TestYamlV3RoundTripTuple(t *testing.T) {
+	t.Parallel()
+
+	want := testTuple.Tuple{
+		Position: &testTuple.Position{Longitude: 4.9, Latitude: 52.3},
+		Call:     &testTuple.TupleCall{Method: "ping", Item1: 1, Rest: nil},
+	}
+
+	data, err := yamlv3.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got testTuple.Tuple
+
+	if err := yamlv3.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Round-tripped data does not match\nWant: %+v\nGot:  %+v\nYAML: %s", want, got, data)
+	}
+
+	if err := yamlv3.Unmarshal([]byte("position: [4.9]\n"), &got); err == nil {
+		t.Error("Expected an error for a tuple with too few items")
+	}
+}
+
+func TestYamlV3UnmarshalOneOf(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		yaml    string
+		want    testOneOf.OneOf
+		wantErr bool
+	}{
+		{
+			desc: "string variant",
+			yaml: "value: hello\nshape: {radius: 2}\n",
+			want: testOneOf.OneOf{
+				Value: &testOneOf.OneOfValue{Value: testOneOf.OneOfValue_0("hello")},
+				Shape: testOneOf.OneOfShape{Value: testOneOf.OneOfShape_0{Radius: 2}},
+			},
+		},
+		{
+			desc: "integer variant",
+			yaml: "value: 3\nshape: {width: 1, height: 2}\n",
+			want: testOneOf.OneOf{
+				Value: &testOneOf.OneOfValue{Value: testOneOf.OneOfValue_1(3)},
+				Shape: testOneOf.OneOfShape{Value: testOneOf.OneOfShape_1{Width: 1, Height: 2}},
+			},
+		},
+		{
+			desc: "quoted integer is a string",
+			yaml: "value: \"3\"\nshape: {radius: 2}\n",
+			want: testOneOf.OneOf{
+				Value: &testOneOf.OneOfValue{Value: testOneOf.OneOfValue_0("3")},
+				Shape: testOneOf.OneOfShape{Value: testOneOf.OneOfShape_0{Radius: 2}},
+			},
+		},
+		{
+			desc:    "no variant matches",
+			yaml:    "value: true\nshape: {radius: 2}\n",
+			wantErr: true,
+		},
+		{
+			desc:    "more than one variant matches",
+			yaml:    "shape: {radius: 1, width: 1, height: 2}\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var got testOneOf.OneOf
+
+			err := yamlv3.Unmarshal([]byte(tC.yaml), &got)
+			if tC.wantErr {
+				if err == nil {
+					t.Fatalf("Expected unmarshal error, got %+v", got)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(got, tC.want) {
+				t.Errorf("Unmarshalled data does not match expected\nWant: %+v\nGot:  %+v", tC.want, got)
+			}
+		})
+	}
+}
+
+func TestYamlV3MarshalOneOfDiscriminator(t *testing.T) {
+	t.Parallel()
+
+	id := 9007199254740993
+
+	data, err := yamlv3.Marshal(testOneOf.OneOfDiscriminator{
+		Event: testOneOf.OneOfDiscriminatorEvent{Value: testOneOf.UserEvent{Id: &id, Name: "x"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "event:\n    kind: user\n    id: 9007199254740993\n    name: x\n"
+	if string(data) != want {
+		t.Errorf("Marshalled data does not match expected\nWant: %s\nGot:  %s", want, data)
+	}
+}
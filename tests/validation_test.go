@@ -5,8 +5,11 @@ import (
 	"errors"
 	"testing"
 
+	yamlv3 "gopkg.in/yaml.v3"
+
 	testExclusiveMaximum "github.com/atombender/go-jsonschema/tests/data/validation/exclusiveMaximum"
 	testExclusiveMinimum "github.com/atombender/go-jsonschema/tests/data/validation/exclusiveMinimum"
+	testIfThenElse "github.com/atombender/go-jsonschema/tests/data/validation/ifThenElse"
 	testMaxLength "github.com/atombender/go-jsonschema/tests/data/validation/maxLength"
 	testMaximum "github.com/atombender/go-jsonschema/tests/data/validation/maximum"
 	testMinLength "github.com/atombender/go-jsonschema/tests/data/validation/minLength"
@@ -537,3 +540,130 @@ func TestExclusiveMinimum(t *testing.T) {
 		})
 	}
 }
+
+func TestIfThenElse(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		data    string
+		wantErr error
+	}{
+		{
+			desc:    "then is satisfied",
+			data:    `{"protocol": "tcp", "port": 80}`,
+			wantErr: nil,
+		},
+		{
+			desc:    "then is violated",
+			data:    `{"protocol": "udp"}`,
+			wantErr: errors.New("field port in IfThenElse: required by then"),
+		},
+		{
+			desc:    "else is satisfied",
+			data:    `{"protocol": "unix", "path": "/run/app.sock", "mode": "stream"}`,
+			wantErr: nil,
+		},
+		{
+			desc:    "else is violated by a missing field",
+			data:    `{"protocol": "unix", "port": 80}`,
+			wantErr: errors.New("field path in IfThenElse: required by else"),
+		},
+		{
+			desc: "else is violated by a value",
+			data: `{"protocol": "unix", "path": "/run/app.sock", "mode": "raw"}`,
+			wantErr: errors.New(`field mode in IfThenElse: invalid value (else expects one of ` +
+				`[]interface {}{"stream", "datagram"}): "raw"`),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			model := testIfThenElse.IfThenElse{}
+
+			err := json.Unmarshal([]byte(tC.data), &model)
+
+			helpers.CheckError(t, tC.wantErr, err)
+		})
+	}
+}
+
+func TestIfElseConst(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		data    string
+		wantErr error
+	}{
+		{
+			desc:    "if matches",
+			data:    "version: 1",
+			wantErr: nil,
+		},
+		{
+			desc:    "else is satisfied",
+			data:    "version: 2\nfeatures: [a]",
+			wantErr: nil,
+		},
+		{
+			desc:    "else is violated",
+			data:    "version: 2",
+			wantErr: errors.New("field features in IfElseConst: required by else"),
+		},
+		{
+			desc:    "if requires the field",
+			data:    "{}",
+			wantErr: errors.New("field features in IfElseConst: required by else"),
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			model := testIfThenElse.IfElseConst{}
+
+			err := yamlv3.Unmarshal([]byte(tC.data), &model)
+
+			helpers.CheckError(t, tC.wantErr, err)
+		})
+	}
+}
+
+func TestIfElseLargeConst(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		desc    string
+		data    string
+		wantErr error
+	}{
+		{
+			desc:    "then is satisfied",
+			data:    `{"createdAt": 1700000000000, "legacy": true}`,
+			wantErr: nil,
+		},
+		{
+			desc:    "then is violated",
+			data:    `{"createdAt": 1700000000000}`,
+			wantErr: errors.New("field legacy in IfElseLargeConst: required by then"),
+		},
+		{
+			desc:    "if does not match",
+			data:    `{"createdAt": 1700000000001}`,
+			wantErr: nil,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			t.Parallel()
+
+			jsonModel := testIfThenElse.IfElseLargeConst{}
+			helpers.CheckError(t, tC.wantErr, json.Unmarshal([]byte(tC.data), &jsonModel))
+
+			yamlModel := testIfThenElse.IfElseLargeConst{}
+			helpers.CheckError(t, tC.wantErr, yamlv3.Unmarshal([]byte(tC.data), &yamlModel))
+		})
+	}
+}